@@ -4,6 +4,7 @@ import (
 	"kanban/internal/config"
 	"kanban/internal/postgres"
 	"kanban/internal/server"
+	"kanban/internal/signing"
 )
 
 func main() {
@@ -12,7 +13,9 @@ func main() {
 	db := postgres.NewPostgres()
 	defer db.Close()
 
-	s := server.New(config.Get().Host)
+	signing.Load(db, config.Get().JWTAlgorithm, config.Get().JWTKeyRotation, config.Get().JWTKeySecrets)
+
+	s := server.New(config.Get().Host, config.Get().TrustedProxies)
 	s.NewAPI(db)
 	s.Start()
 }
@@ -0,0 +1,5 @@
+ALTER TABLE "column" DROP COLUMN IF EXISTS flag;
+
+ALTER TABLE "board" DROP COLUMN IF EXISTS enforce_dependencies;
+
+DROP TABLE IF EXISTS "task_dependency";
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS "task_dependency"(
+    blocker_id uuid REFERENCES "task"(id) ON DELETE CASCADE,
+    blocked_id uuid REFERENCES "task"(id) ON DELETE CASCADE,
+    created_at timestamptz NOT NULL,
+    PRIMARY KEY (blocker_id, blocked_id),
+    CHECK (blocker_id <> blocked_id)
+);
+
+CREATE INDEX IF NOT EXISTS task_dependency_blocked_id_idx ON "task_dependency"(blocked_id);
+
+ALTER TABLE "board" ADD COLUMN IF NOT EXISTS enforce_dependencies boolean NOT NULL DEFAULT false;
+
+ALTER TABLE "column" ADD COLUMN IF NOT EXISTS flag text CHECK (flag IN ('in_progress', 'done'));
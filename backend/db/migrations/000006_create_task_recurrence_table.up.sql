@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS "task_recurrence"(
+    task_id uuid PRIMARY KEY REFERENCES "task"(id) ON DELETE CASCADE,
+    column_id uuid NOT NULL REFERENCES "column"(id) ON DELETE CASCADE,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    rrule text NOT NULL,
+    dtstart timestamptz NOT NULL,
+    next_run_at timestamptz NOT NULL,
+    occurrences integer NOT NULL DEFAULT 1,
+    paused boolean NOT NULL DEFAULT false
+);
+
+CREATE INDEX IF NOT EXISTS task_recurrence_next_run_at_idx ON "task_recurrence"(next_run_at) WHERE NOT paused;
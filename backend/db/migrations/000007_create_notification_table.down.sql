@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "deadline_reminder_sent";
+
+DROP TABLE IF EXISTS "deadline_reminder_setting";
+
+DROP TABLE IF EXISTS "notification";
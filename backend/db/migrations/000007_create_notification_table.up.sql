@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS "notification"(
+    id uuid PRIMARY KEY,
+    user_id uuid NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+    created_at timestamptz NOT NULL,
+    kind text NOT NULL,
+    title text NOT NULL,
+    body text NOT NULL,
+    board_id uuid REFERENCES "board"(id) ON DELETE SET NULL,
+    task_id uuid REFERENCES "task"(id) ON DELETE SET NULL,
+    read_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS notification_user_id_created_at_idx ON "notification"(user_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS "deadline_reminder_setting"(
+    user_id uuid PRIMARY KEY REFERENCES "user"(id) ON DELETE CASCADE,
+    updated_at timestamptz NOT NULL,
+    remind_before_minutes integer[] NOT NULL DEFAULT '{1440}',
+    notify_overdue boolean NOT NULL DEFAULT true
+);
+
+CREATE TABLE IF NOT EXISTS "deadline_reminder_sent"(
+    task_id uuid REFERENCES "task"(id) ON DELETE CASCADE,
+    user_id uuid REFERENCES "user"(id) ON DELETE CASCADE,
+    before_minutes integer NOT NULL,
+    deadline timestamptz NOT NULL,
+    sent_at timestamptz NOT NULL,
+    PRIMARY KEY (task_id, user_id, before_minutes, deadline)
+);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS notification_unread_idx;
+
+DROP TABLE IF EXISTS "notification_preference";
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS "notification_preference"(
+    user_id uuid REFERENCES "user"(id) ON DELETE CASCADE,
+    kind text NOT NULL,
+    updated_at timestamptz NOT NULL,
+    channels text[] NOT NULL,
+    PRIMARY KEY (user_id, kind)
+);
+
+CREATE INDEX IF NOT EXISTS notification_unread_idx ON "notification"(user_id) WHERE read_at IS NULL;
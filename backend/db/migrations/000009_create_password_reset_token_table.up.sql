@@ -0,0 +1,10 @@
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS tokens_valid_after timestamptz NOT NULL DEFAULT 'epoch';
+
+CREATE TABLE IF NOT EXISTS "password_reset_token"(
+    id uuid PRIMARY KEY,
+    user_id uuid NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+    token_hash text NOT NULL UNIQUE,
+    created_at timestamptz NOT NULL,
+    expires_at timestamptz NOT NULL,
+    used_at timestamptz
+);
@@ -0,0 +1,3 @@
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS email_verified_at timestamptz;
+
+UPDATE "user" SET email_verified_at = created_at WHERE email_verified_at IS NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE "user" DROP COLUMN IF EXISTS locale;
+
+ALTER TABLE "user" DROP COLUMN IF EXISTS timezone;
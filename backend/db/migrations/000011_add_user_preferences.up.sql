@@ -0,0 +1,3 @@
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS timezone text NOT NULL DEFAULT 'UTC';
+
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS locale text NOT NULL DEFAULT 'en';
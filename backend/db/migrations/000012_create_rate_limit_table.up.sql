@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS "rate_limit"(
+    key text PRIMARY KEY,
+    attempts integer NOT NULL,
+    window_start timestamptz NOT NULL,
+    failures integer NOT NULL,
+    lockouts integer NOT NULL,
+    locked_until timestamptz,
+    updated_at timestamptz NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS rate_limit_updated_at_idx ON "rate_limit"(updated_at);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS "user_mfa"(
+    user_id uuid PRIMARY KEY REFERENCES "user"(id) ON DELETE CASCADE,
+    secret text NOT NULL,
+    created_at timestamptz NOT NULL,
+    enabled_at timestamptz,
+    last_used_step bigint NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS "mfa_recovery_code"(
+    id uuid PRIMARY KEY,
+    user_id uuid NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+    code_hash text NOT NULL,
+    created_at timestamptz NOT NULL,
+    used_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS mfa_recovery_code_user_idx ON "mfa_recovery_code"(user_id);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS "personal_access_token"(
+    id uuid PRIMARY KEY,
+    user_id uuid NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+    board_id uuid REFERENCES "board"(id) ON DELETE CASCADE,
+    name text NOT NULL,
+    token_hash text NOT NULL UNIQUE,
+    scopes text[] NOT NULL,
+    created_at timestamptz NOT NULL,
+    expires_at timestamptz,
+    last_used_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS personal_access_token_user_idx ON "personal_access_token"(user_id);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS "user_identity"(
+    issuer text NOT NULL,
+    subject text NOT NULL,
+    user_id uuid NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+    created_at timestamptz NOT NULL,
+    PRIMARY KEY (issuer, subject)
+);
+
+CREATE INDEX IF NOT EXISTS user_identity_user_idx ON "user_identity"(user_id);
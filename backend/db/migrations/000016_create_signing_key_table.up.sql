@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS "signing_key"(
+    kid text PRIMARY KEY,
+    algorithm text NOT NULL,
+    private_key bytea NOT NULL,
+    created_at timestamptz NOT NULL,
+    activates_at timestamptz NOT NULL,
+    retires_at timestamptz NOT NULL,
+    expires_at timestamptz NOT NULL
+);
@@ -0,0 +1,10 @@
+DELETE FROM "board" WHERE user_id IS NULL;
+
+ALTER TABLE "board" DROP CONSTRAINT IF EXISTS board_user_id_fkey;
+ALTER TABLE "board" ADD CONSTRAINT board_user_id_fkey FOREIGN KEY (user_id) REFERENCES "user"(id) ON DELETE CASCADE;
+
+DROP INDEX IF EXISTS board_workspace_idx;
+ALTER TABLE "board" DROP COLUMN IF EXISTS workspace_id;
+
+DROP TABLE IF EXISTS "workspace_member";
+DROP TABLE IF EXISTS "workspace";
@@ -0,0 +1,47 @@
+CREATE TABLE IF NOT EXISTS "workspace"(
+    id uuid PRIMARY KEY,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    name text NOT NULL,
+    personal_user_id uuid UNIQUE REFERENCES "user"(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS "workspace_member"(
+    workspace_id uuid NOT NULL REFERENCES "workspace"(id) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+    role text NOT NULL,
+    created_at timestamptz NOT NULL,
+    PRIMARY KEY (workspace_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS workspace_member_user_idx ON "workspace_member"(user_id);
+
+-- Every existing user gets a personal workspace holding their boards.
+INSERT INTO "workspace" (id, created_at, updated_at, name, personal_user_id)
+SELECT gen_random_uuid(), now(), now(), "user".username, "user".id
+FROM "user"
+ON CONFLICT (personal_user_id) DO NOTHING;
+
+INSERT INTO "workspace_member" (workspace_id, user_id, role, created_at)
+SELECT id, personal_user_id, 'owner', created_at
+FROM "workspace"
+WHERE personal_user_id IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE "board" ADD COLUMN IF NOT EXISTS workspace_id uuid REFERENCES "workspace"(id) ON DELETE CASCADE;
+
+UPDATE "board"
+SET workspace_id = "workspace".id
+FROM "workspace"
+WHERE "workspace".personal_user_id = "board".user_id
+AND "board".workspace_id IS NULL;
+
+DELETE FROM "board" WHERE workspace_id IS NULL;
+
+ALTER TABLE "board" ALTER COLUMN workspace_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS board_workspace_idx ON "board"(workspace_id);
+
+-- Boards now belong to the workspace, user_id only records who created them.
+ALTER TABLE "board" DROP CONSTRAINT IF EXISTS board_user_id_fkey;
+ALTER TABLE "board" ADD CONSTRAINT board_user_id_fkey FOREIGN KEY (user_id) REFERENCES "user"(id) ON DELETE SET NULL;
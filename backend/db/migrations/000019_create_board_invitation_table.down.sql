@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "board_invitation";
+DROP TABLE IF EXISTS "board_member";
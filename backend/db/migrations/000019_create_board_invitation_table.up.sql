@@ -0,0 +1,24 @@
+-- Invited users get access to a single board without joining its workspace.
+CREATE TABLE IF NOT EXISTS "board_member"(
+    board_id uuid NOT NULL REFERENCES "board"(id) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+    role text NOT NULL,
+    created_at timestamptz NOT NULL,
+    PRIMARY KEY (board_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS board_member_user_idx ON "board_member"(user_id);
+
+CREATE TABLE IF NOT EXISTS "board_invitation"(
+    id uuid PRIMARY KEY,
+    board_id uuid NOT NULL REFERENCES "board"(id) ON DELETE CASCADE,
+    email text NOT NULL,
+    role text NOT NULL,
+    invited_by uuid REFERENCES "user"(id) ON DELETE SET NULL,
+    status text NOT NULL,
+    created_at timestamptz NOT NULL,
+    expires_at timestamptz NOT NULL,
+    responded_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS board_invitation_board_idx ON "board_invitation"(board_id);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS "board_share"(
+    id uuid PRIMARY KEY,
+    board_id uuid NOT NULL REFERENCES "board"(id) ON DELETE CASCADE,
+    token_hash text NOT NULL UNIQUE,
+    created_by uuid REFERENCES "user"(id) ON DELETE SET NULL,
+    created_at timestamptz NOT NULL,
+    expires_at timestamptz,
+    redact_descriptions boolean NOT NULL DEFAULT false
+);
+
+CREATE INDEX IF NOT EXISTS board_share_board_idx ON "board_share"(board_id);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS "idempotency_key"(
+    user_id uuid NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+    key text NOT NULL,
+    fingerprint text NOT NULL,
+    status integer,
+    content_type text,
+    body bytea,
+    created_at timestamptz NOT NULL,
+    expires_at timestamptz NOT NULL,
+    PRIMARY KEY (user_id, key)
+);
+
+CREATE INDEX IF NOT EXISTS idempotency_key_expires_idx ON "idempotency_key"(expires_at);
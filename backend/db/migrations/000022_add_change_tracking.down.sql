@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "change_tombstone";
+
+ALTER TABLE "task" DROP COLUMN IF EXISTS change_xid;
+ALTER TABLE "column" DROP COLUMN IF EXISTS change_xid;
+ALTER TABLE "board" DROP COLUMN IF EXISTS change_xid;
@@ -0,0 +1,24 @@
+-- Every write stamps the row with the id of its transaction. Clients sync
+-- from the oldest transaction that was still running at their last sync,
+-- so late commits are never skipped.
+ALTER TABLE "board" ADD COLUMN IF NOT EXISTS change_xid xid8 NOT NULL DEFAULT pg_current_xact_id();
+ALTER TABLE "column" ADD COLUMN IF NOT EXISTS change_xid xid8 NOT NULL DEFAULT pg_current_xact_id();
+ALTER TABLE "task" ADD COLUMN IF NOT EXISTS change_xid xid8 NOT NULL DEFAULT pg_current_xact_id();
+
+CREATE INDEX IF NOT EXISTS board_change_xid_idx ON "board"(change_xid);
+CREATE INDEX IF NOT EXISTS column_change_xid_idx ON "column"(change_xid);
+CREATE INDEX IF NOT EXISTS task_change_xid_idx ON "task"(change_xid);
+
+-- Deleted entities and boards that users lost access to. The board and
+-- its members are copied, since they may be gone by the time of the sync.
+CREATE TABLE IF NOT EXISTS "change_tombstone"(
+    entity text NOT NULL,
+    entity_id uuid NOT NULL,
+    board_id uuid NOT NULL,
+    workspace_id uuid,
+    member_ids uuid[] NOT NULL DEFAULT '{}',
+    change_xid xid8 NOT NULL DEFAULT pg_current_xact_id(),
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS change_tombstone_change_xid_idx ON "change_tombstone"(change_xid);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS "activity"(
+    id uuid PRIMARY KEY,
+    user_id uuid REFERENCES "user"(id) ON DELETE SET NULL,
+    board_id uuid NOT NULL REFERENCES "board"(id) ON DELETE CASCADE,
+    entity text NOT NULL,
+    entity_id uuid NOT NULL,
+    action text NOT NULL,
+    before jsonb,
+    after jsonb,
+    reverts_id uuid REFERENCES "activity"(id) ON DELETE SET NULL,
+    created_at timestamptz NOT NULL,
+    reverted_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS activity_user_idx ON "activity"(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS activity_board_idx ON "activity"(board_id, created_at DESC);
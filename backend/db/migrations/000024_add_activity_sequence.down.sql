@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS activity_user_idx;
+DROP INDEX IF EXISTS activity_board_idx;
+ALTER TABLE "activity" DROP COLUMN IF EXISTS seq;
+CREATE INDEX IF NOT EXISTS activity_user_idx ON "activity"(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS activity_board_idx ON "activity"(board_id, created_at DESC);
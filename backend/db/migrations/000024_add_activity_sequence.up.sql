@@ -0,0 +1,8 @@
+-- Events of one transaction can share a timestamp, the sequence keeps
+-- them in the order they were recorded.
+ALTER TABLE "activity" ADD COLUMN IF NOT EXISTS seq bigint GENERATED ALWAYS AS IDENTITY;
+
+DROP INDEX IF EXISTS activity_user_idx;
+DROP INDEX IF EXISTS activity_board_idx;
+CREATE INDEX IF NOT EXISTS activity_user_idx ON "activity"(user_id, created_at DESC, seq DESC);
+CREATE INDEX IF NOT EXISTS activity_board_idx ON "activity"(board_id, created_at DESC, seq DESC);
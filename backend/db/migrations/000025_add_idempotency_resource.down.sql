@@ -0,0 +1,2 @@
+ALTER TABLE "idempotency_key" DROP COLUMN IF EXISTS resource_id;
+ALTER TABLE "idempotency_key" DROP COLUMN IF EXISTS location;
@@ -0,0 +1,4 @@
+-- Responses carrying a secret keep only the ID of the created resource,
+-- which is read again on replay.
+ALTER TABLE "idempotency_key" ADD COLUMN IF NOT EXISTS location text;
+ALTER TABLE "idempotency_key" ADD COLUMN IF NOT EXISTS resource_id text;
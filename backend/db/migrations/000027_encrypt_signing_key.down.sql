@@ -0,0 +1,4 @@
+-- Encrypted keys are unreadable without the secret, new ones are created on start.
+DELETE FROM "signing_key" WHERE secret_id IS NOT NULL;
+
+ALTER TABLE "signing_key" DROP COLUMN IF EXISTS secret_id;
@@ -0,0 +1,3 @@
+-- Names the secret the private key is encrypted with, NULL while it is
+-- still stored in plain text. Such keys are encrypted on the next rotation.
+ALTER TABLE "signing_key" ADD COLUMN IF NOT EXISTS secret_id text;
@@ -0,0 +1,23 @@
+package accesstoken
+
+import (
+	"database/sql"
+	accessTokenHandler "kanban/internal/accesstoken/handler"
+	accessTokenProxy "kanban/internal/accesstoken/proxy"
+	accessTokenRepo "kanban/internal/accesstoken/repo"
+	accessTokenService "kanban/internal/accesstoken/service"
+	authMiddleware "kanban/internal/auth/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Init(db *sql.DB, grp *gin.RouterGroup) {
+	repo := accessTokenRepo.NewRepository(db)
+	service := accessTokenService.NewService(repo)
+	proxy := accessTokenProxy.NewProxy(service)
+	handler := accessTokenHandler.NewHandler(proxy)
+
+	grp.POST("/me/tokens", authMiddleware.SessionOnly(), handler.CreateTokenHandler())
+	grp.GET("/me/tokens", authMiddleware.SessionOnly(), handler.GetTokensHandler())
+	grp.DELETE("/me/tokens/:id", authMiddleware.SessionOnly(), handler.RevokeTokenHandler())
+}
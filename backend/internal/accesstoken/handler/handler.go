@@ -0,0 +1,141 @@
+package accessTokenHandler
+
+import (
+	"errors"
+	accessTokenModel "kanban/internal/accesstoken/model"
+	accessTokenProxy "kanban/internal/accesstoken/proxy"
+	accessTokenService "kanban/internal/accesstoken/service"
+	authctx "kanban/internal/auth/context"
+	"kanban/internal/idempotency"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Proxy interface {
+	CreateToken(userID string, req accessTokenModel.CreateRequest) (*accessTokenModel.CreateResponse, error)
+	GetTokens(userID string) ([]accessTokenModel.AccessToken, error)
+	GetToken(tokenID, userID string) (*accessTokenModel.AccessToken, error)
+	RevokeToken(tokenID, userID string) error
+}
+
+type Handler struct {
+	proxy Proxy
+}
+
+func NewHandler(proxy Proxy) *Handler {
+	return &Handler{proxy: proxy}
+}
+
+func (h *Handler) CreateTokenHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req accessTokenModel.CreateRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid request body",
+			})
+			return
+		}
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		// The secret is shown once, a retry gets the token without it.
+		if status, tokenID, ok := idempotency.Replayed(ctx); ok {
+			token, err := h.proxy.GetToken(tokenID, userID)
+			if err != nil {
+				log.Printf("Failed to get access token: %v", err)
+				h.handleError(ctx, err, "Failed to get access token")
+				return
+			}
+
+			ctx.JSON(status, token)
+			return
+		}
+
+		token, err := h.proxy.CreateToken(userID, req)
+		if err != nil {
+			log.Printf("Failed to create access token: %v", err)
+			h.handleError(ctx, err, "Failed to create access token")
+			return
+		}
+
+		idempotency.SetResource(ctx, token.ID)
+		ctx.JSON(http.StatusCreated, token)
+	}
+}
+
+func (h *Handler) GetTokensHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		tokens, err := h.proxy.GetTokens(userID)
+		if err != nil {
+			log.Printf("Failed to get access tokens: %v", err)
+			h.handleError(ctx, err, "Failed to get access tokens")
+			return
+		}
+
+		ctx.JSON(http.StatusOK, tokens)
+	}
+}
+
+func (h *Handler) RevokeTokenHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		tokenID := ctx.Param("id")
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		err := h.proxy.RevokeToken(tokenID, userID)
+		if err != nil {
+			log.Printf("Failed to revoke access token: %v", err)
+			h.handleError(ctx, err, "Failed to revoke access token")
+			return
+		}
+
+		ctx.Status(http.StatusOK)
+	}
+}
+
+func (h *Handler) handleError(ctx *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, accessTokenProxy.ErrForbidden):
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"detail": "Access denied",
+		})
+	case errors.Is(err, accessTokenService.ErrTokenNotFound):
+		ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+			"detail": "Access token not found",
+		})
+	case errors.Is(err, accessTokenService.ErrBoardNotFound):
+		ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+			"detail": "Board not found",
+		})
+	case errors.Is(err, accessTokenService.ErrExpiresInPast):
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"detail": "Expiry must be in the future",
+		})
+	default:
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"detail": message,
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package accessTokenModel
+
+import "time"
+
+type AccessToken struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	BoardID    *string    `json:"board_id"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+}
+
+type CreateRequest struct {
+	Name      string     `json:"name"       binding:"required,max=100"`
+	Scopes    []string   `json:"scopes"     binding:"required,min=1,dive,oneof=read write"`
+	BoardID   *string    `json:"board_id"   binding:"omitempty,uuid"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// CreateResponse carries the token itself, which is only stored hashed and
+// cannot be shown again.
+type CreateResponse struct {
+	AccessToken
+	Token string `json:"token"`
+}
@@ -0,0 +1,80 @@
+package accessTokenProxy
+
+import (
+	"errors"
+	"fmt"
+	accessTokenModel "kanban/internal/accesstoken/model"
+)
+
+var ErrForbidden = errors.New("access denied")
+
+type Service interface {
+	CreateToken(userID string, req accessTokenModel.CreateRequest) (*accessTokenModel.CreateResponse, error)
+	GetTokens(userID string) ([]accessTokenModel.AccessToken, error)
+	GetToken(tokenID, userID string) (*accessTokenModel.AccessToken, error)
+	RevokeToken(tokenID string) error
+	GetUserByToken(tokenID string) (*string, error)
+	HasBoardAccess(boardID, userID string) (bool, error)
+}
+
+type Proxy struct {
+	service Service
+}
+
+func NewProxy(service Service) *Proxy {
+	return &Proxy{service: service}
+}
+
+func (p *Proxy) CreateToken(userID string, req accessTokenModel.CreateRequest) (*accessTokenModel.CreateResponse, error) {
+	if req.BoardID != nil {
+		hasAccess, err := p.checkBoardAccess(*req.BoardID, userID)
+		if err != nil {
+			return nil, fmt.Errorf("accessTokenProxy.CreateToken: %w", err)
+		}
+		if !hasAccess {
+			return nil, fmt.Errorf("accessTokenProxy.CreateToken: %w", ErrForbidden)
+		}
+	}
+
+	return p.service.CreateToken(userID, req)
+}
+
+func (p *Proxy) GetTokens(userID string) ([]accessTokenModel.AccessToken, error) {
+	return p.service.GetTokens(userID)
+}
+
+// GetToken only finds tokens of the user.
+func (p *Proxy) GetToken(tokenID, userID string) (*accessTokenModel.AccessToken, error) {
+	return p.service.GetToken(tokenID, userID)
+}
+
+func (p *Proxy) RevokeToken(tokenID, userID string) error {
+	isOwner, err := p.checkTokenOwnership(tokenID, userID)
+	if err != nil {
+		return fmt.Errorf("accessTokenProxy.RevokeToken: %w", err)
+	}
+
+	if isOwner {
+		return p.service.RevokeToken(tokenID)
+	} else {
+		return fmt.Errorf("accessTokenProxy.RevokeToken: %w", ErrForbidden)
+	}
+}
+
+func (p *Proxy) checkTokenOwnership(tokenID, userID string) (bool, error) {
+	realUserID, err := p.service.GetUserByToken(tokenID)
+	if err != nil {
+		return false, fmt.Errorf("accessTokenProxy.checkTokenOwnership: %w", err)
+	}
+
+	return *realUserID == userID, nil
+}
+
+func (p *Proxy) checkBoardAccess(boardID, userID string) (bool, error) {
+	hasAccess, err := p.service.HasBoardAccess(boardID, userID)
+	if err != nil {
+		return false, fmt.Errorf("accessTokenProxy.checkBoardAccess: %w", err)
+	}
+
+	return hasAccess, nil
+}
@@ -0,0 +1,122 @@
+package accessTokenRepo
+
+import (
+	"database/sql"
+	"fmt"
+	accessTokenModel "kanban/internal/accesstoken/model"
+	"kanban/internal/postgres"
+
+	"github.com/lib/pq"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Create(userID, tokenHash string, token accessTokenModel.AccessToken) error {
+	_, err := r.db.Exec(
+		postgres.QueryCreateAccessToken,
+		token.ID,
+		userID,
+		token.BoardID,
+		token.Name,
+		tokenHash,
+		pq.StringArray(token.Scopes),
+		token.CreatedAt,
+		token.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("accessTokenRepo.Create: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) GetAll(userID string) ([]accessTokenModel.AccessToken, error) {
+	rows, err := r.db.Query(postgres.QueryGetAccessTokens, userID)
+	if err != nil {
+		return nil, fmt.Errorf("accessTokenRepo.GetAll: %w", err)
+	}
+	defer rows.Close()
+
+	tokens := []accessTokenModel.AccessToken{}
+	for rows.Next() {
+		var token accessTokenModel.AccessToken
+		var scopes pq.StringArray
+		err = rows.Scan(
+			&token.ID,
+			&token.Name,
+			&scopes,
+			&token.BoardID,
+			&token.CreatedAt,
+			&token.ExpiresAt,
+			&token.LastUsedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("accessTokenRepo.GetAll: %w", err)
+		}
+		token.Scopes = scopes
+		tokens = append(tokens, token)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("accessTokenRepo.GetAll: %w", err)
+	}
+
+	return tokens, nil
+}
+
+func (r *Repository) Get(tokenID, userID string) (*accessTokenModel.AccessToken, error) {
+	var token accessTokenModel.AccessToken
+	var scopes pq.StringArray
+	err := r.db.QueryRow(postgres.QueryGetAccessToken, tokenID, userID).Scan(
+		&token.ID,
+		&token.Name,
+		&scopes,
+		&token.BoardID,
+		&token.CreatedAt,
+		&token.ExpiresAt,
+		&token.LastUsedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("accessTokenRepo.Get: %w", err)
+	}
+	token.Scopes = scopes
+	return &token, nil
+}
+
+func (r *Repository) Delete(tokenID string) error {
+	res, err := r.db.Exec(postgres.QueryDeleteAccessToken, tokenID)
+	if err != nil {
+		return fmt.Errorf("accessTokenRepo.Delete: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("accessTokenRepo.Delete: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("accessTokenRepo.Delete: %w", sql.ErrNoRows)
+	}
+	return nil
+}
+
+func (r *Repository) GetUserByToken(tokenID string) (*string, error) {
+	var userID string
+	err := r.db.QueryRow(postgres.QueryGetUserByAccessTokenID, tokenID).Scan(&userID)
+	if err != nil {
+		return nil, fmt.Errorf("accessTokenRepo.GetUserByToken: %w", err)
+	}
+	return &userID, nil
+}
+
+func (r *Repository) HasBoardAccess(boardID, userID string) (bool, error) {
+	var hasAccess bool
+	err := r.db.QueryRow(postgres.QueryHasBoardAccess, boardID, userID, postgres.OwnBoardsOnly()).Scan(&hasAccess)
+	if err != nil {
+		return false, fmt.Errorf("accessTokenRepo.HasBoardAccess: %w", err)
+	}
+	return hasAccess, nil
+}
@@ -0,0 +1,124 @@
+package accessTokenService
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	accessTokenModel "kanban/internal/accesstoken/model"
+	authModel "kanban/internal/auth/model"
+	"kanban/internal/utils"
+	"slices"
+)
+
+var ErrTokenNotFound = errors.New("access token not found")
+var ErrBoardNotFound = errors.New("board not found")
+var ErrExpiresInPast = errors.New("expiry is in the past")
+
+type Repository interface {
+	Create(userID, tokenHash string, token accessTokenModel.AccessToken) error
+	GetAll(userID string) ([]accessTokenModel.AccessToken, error)
+	Get(tokenID, userID string) (*accessTokenModel.AccessToken, error)
+	Delete(tokenID string) error
+	GetUserByToken(tokenID string) (*string, error)
+	HasBoardAccess(boardID, userID string) (bool, error)
+}
+
+type Service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+func (s *Service) CreateToken(userID string, req accessTokenModel.CreateRequest) (*accessTokenModel.CreateResponse, error) {
+	now := utils.GenerateTimestamp()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, fmt.Errorf("accessTokenService.CreateToken: %w", ErrExpiresInPast)
+	}
+
+	secret, err := utils.GenerateToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("accessTokenService.CreateToken: %w", err)
+	}
+	raw := authModel.AccessTokenPrefix + secret
+
+	scopes := []string{authModel.ScopeRead}
+	if slices.Contains(req.Scopes, authModel.ScopeWrite) {
+		scopes = append(scopes, authModel.ScopeWrite)
+	}
+
+	token := accessTokenModel.AccessToken{
+		ID:        utils.NewUUID(),
+		Name:      req.Name,
+		Scopes:    scopes,
+		BoardID:   req.BoardID,
+		CreatedAt: now,
+		ExpiresAt: req.ExpiresAt,
+	}
+
+	err = s.repo.Create(userID, utils.HashToken(raw), token)
+	if err != nil {
+		return nil, fmt.Errorf("accessTokenService.CreateToken: %w", err)
+	}
+
+	return &accessTokenModel.CreateResponse{AccessToken: token, Token: raw}, nil
+}
+
+func (s *Service) GetTokens(userID string) ([]accessTokenModel.AccessToken, error) {
+	tokens, err := s.repo.GetAll(userID)
+	if err != nil {
+		return nil, fmt.Errorf("accessTokenService.GetTokens: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// GetToken returns a token of the user without its secret.
+func (s *Service) GetToken(tokenID, userID string) (*accessTokenModel.AccessToken, error) {
+	token, err := s.repo.Get(tokenID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("accessTokenService.GetToken: %w", ErrTokenNotFound)
+		}
+		return nil, fmt.Errorf("accessTokenService.GetToken: %w", err)
+	}
+
+	return token, nil
+}
+
+func (s *Service) RevokeToken(tokenID string) error {
+	err := s.repo.Delete(tokenID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("accessTokenService.RevokeToken: %w", ErrTokenNotFound)
+		}
+		return fmt.Errorf("accessTokenService.RevokeToken: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetUserByToken(tokenID string) (*string, error) {
+	userID, err := s.repo.GetUserByToken(tokenID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("accessTokenService.GetUserByToken: %w", ErrTokenNotFound)
+		}
+		return nil, fmt.Errorf("accessTokenService.GetUserByToken: %w", err)
+	}
+
+	return userID, nil
+}
+
+func (s *Service) HasBoardAccess(boardID, userID string) (bool, error) {
+	hasAccess, err := s.repo.HasBoardAccess(boardID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, fmt.Errorf("accessTokenService.HasBoardAccess: %w", ErrBoardNotFound)
+		}
+		return false, fmt.Errorf("accessTokenService.HasBoardAccess: %w", err)
+	}
+
+	return hasAccess, nil
+}
@@ -0,0 +1,25 @@
+package account
+
+import (
+	"database/sql"
+	accountHandler "kanban/internal/account/handler"
+	accountProxy "kanban/internal/account/proxy"
+	accountRepo "kanban/internal/account/repo"
+	accountService "kanban/internal/account/service"
+	authMiddleware "kanban/internal/auth/middleware"
+	"kanban/internal/mailer"
+	_ "time/tzdata"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Init(db *sql.DB, grp *gin.RouterGroup) {
+	repo := accountRepo.NewRepository(db)
+	service := accountService.NewService(repo, mailer.New())
+	proxy := accountProxy.NewProxy(service)
+	handler := accountHandler.NewHandler(proxy)
+
+	grp.GET("/me", handler.GetProfileHandler())
+	grp.PATCH("/me", authMiddleware.SessionOnly(), handler.UpdateProfileHandler())
+	grp.DELETE("/me", authMiddleware.SessionOnly(), handler.DeleteAccountHandler())
+}
@@ -0,0 +1,142 @@
+package accountHandler
+
+import (
+	"errors"
+	accountModel "kanban/internal/account/model"
+	accountService "kanban/internal/account/service"
+	authctx "kanban/internal/auth/context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Proxy interface {
+	GetProfile(userID string) (*accountModel.Profile, error)
+	UpdateProfile(userID string, req accountModel.UpdateRequest) error
+	DeleteAccount(userID string, req accountModel.DeleteRequest, loggedInAt time.Time) error
+}
+
+type Handler struct {
+	proxy Proxy
+}
+
+func NewHandler(proxy Proxy) *Handler {
+	return &Handler{proxy: proxy}
+}
+
+func (h *Handler) GetProfileHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		profile, err := h.proxy.GetProfile(userID)
+		if err != nil {
+			log.Printf("Failed to get profile: %v", err)
+			h.handleError(ctx, err, "Failed to get profile")
+			return
+		}
+
+		ctx.JSON(http.StatusOK, profile)
+	}
+}
+
+func (h *Handler) UpdateProfileHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req accountModel.UpdateRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid request body",
+			})
+			return
+		}
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		err := h.proxy.UpdateProfile(userID, req)
+		if err != nil {
+			log.Printf("Failed to update profile: %v", err)
+			h.handleError(ctx, err, "Failed to update profile")
+			return
+		}
+
+		ctx.Status(http.StatusOK)
+	}
+}
+
+func (h *Handler) DeleteAccountHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req accountModel.DeleteRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid request body",
+			})
+			return
+		}
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		// Without a session token the login time is unknown and too old.
+		loggedInAt, _ := authctx.GetIssuedAt(ctx)
+
+		err := h.proxy.DeleteAccount(userID, req, loggedInAt)
+		if err != nil {
+			log.Printf("Failed to delete account: %v", err)
+			h.handleError(ctx, err, "Failed to delete account")
+			return
+		}
+
+		ctx.Status(http.StatusOK)
+	}
+}
+
+func (h *Handler) handleError(ctx *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, accountService.ErrUserNotFound):
+		ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+			"detail": "User not found",
+		})
+	case errors.Is(err, accountService.ErrEmailTaken):
+		ctx.AbortWithStatusJSON(http.StatusConflict, gin.H{
+			"detail": "Email is already taken",
+		})
+	case errors.Is(err, accountService.ErrIncorrectPassword):
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"detail": "Password is incorrect",
+		})
+	case errors.Is(err, accountService.ErrReauthRequired):
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"detail": "Log in again to delete the account",
+		})
+	case errors.Is(err, accountService.ErrSoleWorkspaceOwner):
+		ctx.AbortWithStatusJSON(http.StatusConflict, gin.H{
+			"detail": "Transfer ownership of your shared workspaces first",
+		})
+	case errors.Is(err, accountService.ErrBoardsShared):
+		ctx.AbortWithStatusJSON(http.StatusConflict, gin.H{
+			"detail": "Remove other members from your personal boards first",
+		})
+	default:
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"detail": message,
+		})
+	}
+}
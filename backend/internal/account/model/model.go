@@ -0,0 +1,26 @@
+package accountModel
+
+import "time"
+
+type Profile struct {
+	ID              string     `json:"id"`
+	Email           string     `json:"email"`
+	Username        string     `json:"username"`
+	CreatedAt       time.Time  `json:"created_at"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	Timezone        string     `json:"timezone"`
+	Locale          string     `json:"locale"`
+}
+
+type UpdateRequest struct {
+	Username *string `json:"username" binding:"omitempty,min=1"`
+	Email    *string `json:"email"    binding:"omitempty,email"`
+	Timezone *string `json:"timezone" binding:"omitempty,timezone"`
+	Locale   *string `json:"locale"   binding:"omitempty,bcp47_language_tag"`
+}
+
+// DeleteRequest confirms the deletion with the password. Accounts signed in
+// through OIDC or LDAP may send none after logging in again instead.
+type DeleteRequest struct {
+	Password string `json:"password"`
+}
@@ -0,0 +1,32 @@
+package accountProxy
+
+import (
+	accountModel "kanban/internal/account/model"
+	"time"
+)
+
+type Service interface {
+	GetProfile(userID string) (*accountModel.Profile, error)
+	UpdateProfile(userID string, req accountModel.UpdateRequest) error
+	DeleteAccount(userID string, req accountModel.DeleteRequest, loggedInAt time.Time) error
+}
+
+type Proxy struct {
+	service Service
+}
+
+func NewProxy(service Service) *Proxy {
+	return &Proxy{service: service}
+}
+
+func (p *Proxy) GetProfile(userID string) (*accountModel.Profile, error) {
+	return p.service.GetProfile(userID)
+}
+
+func (p *Proxy) UpdateProfile(userID string, req accountModel.UpdateRequest) error {
+	return p.service.UpdateProfile(userID, req)
+}
+
+func (p *Proxy) DeleteAccount(userID string, req accountModel.DeleteRequest, loggedInAt time.Time) error {
+	return p.service.DeleteAccount(userID, req, loggedInAt)
+}
@@ -0,0 +1,91 @@
+package accountRepo
+
+import (
+	"database/sql"
+	"fmt"
+	accountModel "kanban/internal/account/model"
+	"kanban/internal/postgres"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Get(userID string) (*accountModel.Profile, error) {
+	var profile accountModel.Profile
+	err := r.db.QueryRow(postgres.QueryGetProfile, userID).Scan(
+		&profile.ID,
+		&profile.Email,
+		&profile.Username,
+		&profile.CreatedAt,
+		&profile.EmailVerifiedAt,
+		&profile.Timezone,
+		&profile.Locale,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("accountRepo.Get: %w", err)
+	}
+	return &profile, nil
+}
+
+func (r *Repository) Update(userID string, req accountModel.UpdateRequest) error {
+	res, err := r.db.Exec(
+		postgres.QueryUpdateProfile,
+		req.Username,
+		req.Email,
+		req.Timezone,
+		req.Locale,
+		userID,
+	)
+	if err != nil {
+		return fmt.Errorf("accountRepo.Update: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("accountRepo.Update: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("accountRepo.Update: %w", sql.ErrNoRows)
+	}
+	return nil
+}
+
+func (r *Repository) GetPasswordHash(userID string) (*string, error) {
+	var hash string
+	err := r.db.QueryRow(postgres.QueryGetPasswordHash, userID).Scan(&hash)
+	if err != nil {
+		return nil, fmt.Errorf("accountRepo.GetPasswordHash: %w", err)
+	}
+	return &hash, nil
+}
+
+func (r *Repository) HasIdentity(userID string) (bool, error) {
+	var hasIdentity bool
+	err := r.db.QueryRow(postgres.QueryHasIdentity, userID).Scan(&hasIdentity)
+	if err != nil {
+		return false, fmt.Errorf("accountRepo.HasIdentity: %w", err)
+	}
+	return hasIdentity, nil
+}
+
+func (r *Repository) GetDeletionConflicts(userID string) (bool, bool, error) {
+	var soleOwner, sharedBoards bool
+	err := r.db.QueryRow(postgres.QueryGetDeletionConflicts, userID).Scan(&soleOwner, &sharedBoards)
+	if err != nil {
+		return false, false, fmt.Errorf("accountRepo.GetDeletionConflicts: %w", err)
+	}
+	return soleOwner, sharedBoards, nil
+}
+
+func (r *Repository) Delete(userID string) error {
+	_, err := r.db.Exec(postgres.QueryDeleteUser, userID)
+	if err != nil {
+		return fmt.Errorf("accountRepo.Delete: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package accountService
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	accountModel "kanban/internal/account/model"
+	authModel "kanban/internal/auth/model"
+	authService "kanban/internal/auth/service"
+	"kanban/internal/mailer"
+	"log"
+	"time"
+
+	"github.com/lib/pq"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const uniqueViolation pq.ErrorCode = "23505"
+
+// ReauthWindow is how recent a login has to be to delete an account
+// without the password.
+const ReauthWindow = 5 * time.Minute
+
+var ErrUserNotFound = errors.New("user not found")
+var ErrEmailTaken = errors.New("email is already taken")
+var ErrIncorrectPassword = errors.New("incorrect password")
+var ErrReauthRequired = errors.New("recent login is required")
+var ErrSoleWorkspaceOwner = errors.New("user is the only owner of a shared workspace")
+var ErrBoardsShared = errors.New("personal boards are shared with other users")
+
+type Repository interface {
+	Get(userID string) (*accountModel.Profile, error)
+	Update(userID string, req accountModel.UpdateRequest) error
+	GetPasswordHash(userID string) (*string, error)
+	HasIdentity(userID string) (bool, error)
+	GetDeletionConflicts(userID string) (bool, bool, error)
+	Delete(userID string) error
+}
+
+type Service struct {
+	repo   Repository
+	mailer mailer.Mailer
+}
+
+func NewService(repo Repository, mailer mailer.Mailer) *Service {
+	return &Service{repo: repo, mailer: mailer}
+}
+
+func (s *Service) GetProfile(userID string) (*accountModel.Profile, error) {
+	profile, err := s.repo.Get(userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("accountService.GetProfile: %w", ErrUserNotFound)
+		}
+		return nil, fmt.Errorf("accountService.GetProfile: %w", err)
+	}
+
+	return profile, nil
+}
+
+// UpdateProfile applies the given fields. Changing the email resets its
+// verification and sends a new verification link to the new address.
+func (s *Service) UpdateProfile(userID string, req accountModel.UpdateRequest) error {
+	before, err := s.GetProfile(userID)
+	if err != nil {
+		return fmt.Errorf("accountService.UpdateProfile: %w", err)
+	}
+
+	err = s.repo.Update(userID, req)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return fmt.Errorf("accountService.UpdateProfile: %w", ErrEmailTaken)
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("accountService.UpdateProfile: %w", ErrUserNotFound)
+		}
+		return fmt.Errorf("accountService.UpdateProfile: %w", err)
+	}
+
+	if req.Email != nil && *req.Email != before.Email {
+		user := authModel.User{ID: userID, Email: *req.Email, Username: before.Username}
+		if req.Username != nil {
+			user.Username = *req.Username
+		}
+		if err = authService.SendVerification(s.mailer, user); err != nil {
+			log.Printf("Failed to send verification email to user %s: %v", userID, err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteAccount deletes the user after checking the password. Users of an
+// identity provider or directory have no local password, they confirm by
+// logging in again, so their session must be at most ReauthWindow old.
+func (s *Service) DeleteAccount(userID string, req accountModel.DeleteRequest, loggedInAt time.Time) error {
+	if req.Password != "" {
+		if err := s.checkPassword(userID, req.Password); err != nil {
+			return fmt.Errorf("accountService.DeleteAccount: %w", err)
+		}
+	} else {
+		hasIdentity, err := s.repo.HasIdentity(userID)
+		if err != nil {
+			return fmt.Errorf("accountService.DeleteAccount: %w", err)
+		}
+		if !hasIdentity {
+			return fmt.Errorf("accountService.DeleteAccount: %w", ErrIncorrectPassword)
+		}
+		if time.Since(loggedInAt) > ReauthWindow {
+			return fmt.Errorf("accountService.DeleteAccount: %w", ErrReauthRequired)
+		}
+	}
+
+	// The personal workspace goes away with the user, shared workspaces stay
+	// and need another owner first.
+	soleOwner, sharedBoards, err := s.repo.GetDeletionConflicts(userID)
+	if err != nil {
+		return fmt.Errorf("accountService.DeleteAccount: %w", err)
+	}
+	if soleOwner {
+		return fmt.Errorf("accountService.DeleteAccount: %w", ErrSoleWorkspaceOwner)
+	}
+	if sharedBoards {
+		return fmt.Errorf("accountService.DeleteAccount: %w", ErrBoardsShared)
+	}
+
+	err = s.repo.Delete(userID)
+	if err != nil {
+		return fmt.Errorf("accountService.DeleteAccount: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) checkPassword(userID, password string) error {
+	hash, err := s.repo.GetPasswordHash(userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(*hash), []byte(password)) != nil {
+		return ErrIncorrectPassword
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package accountService
+
+import (
+	"errors"
+	accountModel "kanban/internal/account/model"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// stubRepo holds a single user, the rest of Repository is left nil.
+type stubRepo struct {
+	Repository
+	hash         string
+	hasIdentity  bool
+	soleOwner    bool
+	sharedBoards bool
+	deleted      bool
+}
+
+func (r *stubRepo) GetPasswordHash(userID string) (*string, error) {
+	return &r.hash, nil
+}
+
+func (r *stubRepo) HasIdentity(userID string) (bool, error) {
+	return r.hasIdentity, nil
+}
+
+func (r *stubRepo) GetDeletionConflicts(userID string) (bool, bool, error) {
+	return r.soleOwner, r.sharedBoards, nil
+}
+
+func (r *stubRepo) Delete(userID string) error {
+	r.deleted = true
+	return nil
+}
+
+func TestDeleteAccount(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("alice"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		repo       stubRepo
+		password   string
+		loggedInAt time.Time
+		wantErr    error
+	}{
+		{
+			name:       "password",
+			password:   "alice",
+			loggedInAt: now.Add(-time.Hour),
+		},
+		{
+			name:       "wrong password",
+			password:   "bob",
+			loggedInAt: now,
+			wantErr:    ErrIncorrectPassword,
+		},
+		{
+			name:       "local account without password",
+			loggedInAt: now,
+			wantErr:    ErrIncorrectPassword,
+		},
+		{
+			name:       "external account after a new login",
+			repo:       stubRepo{hasIdentity: true},
+			loggedInAt: now.Add(-time.Minute),
+		},
+		{
+			name:       "external account with an old session",
+			repo:       stubRepo{hasIdentity: true},
+			loggedInAt: now.Add(-ReauthWindow - time.Minute),
+			wantErr:    ErrReauthRequired,
+		},
+		{
+			name:    "external account without a session",
+			repo:    stubRepo{hasIdentity: true},
+			wantErr: ErrReauthRequired,
+		},
+		{
+			name:       "only owner of a shared workspace",
+			repo:       stubRepo{soleOwner: true},
+			password:   "alice",
+			loggedInAt: now,
+			wantErr:    ErrSoleWorkspaceOwner,
+		},
+		{
+			name:       "personal boards shared",
+			repo:       stubRepo{sharedBoards: true},
+			password:   "alice",
+			loggedInAt: now,
+			wantErr:    ErrBoardsShared,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := tt.repo
+			repo.hash = string(hash)
+			s := NewService(&repo, nil)
+
+			err := s.DeleteAccount("alice", accountModel.DeleteRequest{Password: tt.password}, tt.loggedInAt)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error: got %v, want %v", err, tt.wantErr)
+			}
+			if repo.deleted != (tt.wantErr == nil) {
+				t.Errorf("deleted: got %v", repo.deleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package activity
+
+import (
+	"database/sql"
+	activityHandler "kanban/internal/activity/handler"
+	activityProxy "kanban/internal/activity/proxy"
+	activityRepo "kanban/internal/activity/repo"
+	activityService "kanban/internal/activity/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Init(db *sql.DB, grp *gin.RouterGroup) {
+	repo := activityRepo.NewRepository(db)
+	service := activityService.NewService(repo)
+	proxy := activityProxy.NewProxy(service)
+	handler := activityHandler.NewHandler(proxy)
+
+	grp.GET("/boards/:id/activity", handler.GetBoardActivityHandler())
+	grp.POST("/activity/:eventID/revert", handler.RevertEventHandler())
+	grp.POST("/undo", handler.UndoHandler())
+}
@@ -0,0 +1,149 @@
+package activityHandler
+
+import (
+	"errors"
+	activityModel "kanban/internal/activity/model"
+	activityProxy "kanban/internal/activity/proxy"
+	activityRepo "kanban/internal/activity/repo"
+	activityService "kanban/internal/activity/service"
+	authctx "kanban/internal/auth/context"
+	columnRepo "kanban/internal/column/repo"
+	taskRepo "kanban/internal/task/repo"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Proxy interface {
+	GetBoardActivity(boardID, userID string, query activityModel.ListQuery) (*activityModel.Page, error)
+	RevertEvent(eventID, userID string) (*activityModel.Event, error)
+	Undo(userID string, query activityModel.UndoQuery) (*activityModel.UndoResult, error)
+}
+
+type Handler struct {
+	proxy Proxy
+}
+
+func NewHandler(proxy Proxy) *Handler {
+	return &Handler{proxy: proxy}
+}
+
+func (h *Handler) GetBoardActivityHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var query activityModel.ListQuery
+		if err := ctx.ShouldBindQuery(&query); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid query parameters",
+			})
+			return
+		}
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		page, err := h.proxy.GetBoardActivity(ctx.Param("id"), userID, query)
+		if err != nil {
+			log.Printf("Failed to get board activity: %v", err)
+			h.handleError(ctx, err, "Failed to get board activity")
+			return
+		}
+
+		ctx.JSON(http.StatusOK, page)
+	}
+}
+
+func (h *Handler) RevertEventHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		event, err := h.proxy.RevertEvent(ctx.Param("eventID"), userID)
+		if err != nil {
+			log.Printf("Failed to revert event: %v", err)
+			h.handleError(ctx, err, "Failed to revert event")
+			return
+		}
+
+		ctx.JSON(http.StatusOK, event)
+	}
+}
+
+func (h *Handler) UndoHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var query activityModel.UndoQuery
+		if err := ctx.ShouldBindQuery(&query); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid query parameters",
+			})
+			return
+		}
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		result, err := h.proxy.Undo(userID, query)
+		if err != nil {
+			log.Printf("Failed to undo: %v", err)
+			h.handleError(ctx, err, "Failed to undo")
+			return
+		}
+
+		ctx.JSON(http.StatusOK, result)
+	}
+}
+
+// handleError names the event that failed an undo next to the reason,
+// none of the changes is undone in that case.
+func (h *Handler) handleError(ctx *gin.Context, err error, message string) {
+	status := http.StatusInternalServerError
+	detail := message
+	switch {
+	case errors.Is(err, activityProxy.ErrForbidden):
+		status, detail = http.StatusForbidden, "Access denied"
+	case errors.Is(err, activityService.ErrEventNotFound):
+		status, detail = http.StatusNotFound, "Event not found"
+	case errors.Is(err, activityService.ErrBoardNotFound):
+		status, detail = http.StatusNotFound, "Board not found"
+	case errors.Is(err, activityService.ErrNothingToUndo):
+		status, detail = http.StatusNotFound, "Nothing to undo"
+	case errors.Is(err, activityRepo.ErrAlreadyReverted):
+		status, detail = http.StatusConflict, "Event is already reverted"
+	case errors.Is(err, activityRepo.ErrConflict):
+		status, detail = http.StatusConflict, "Entity changed since the event"
+	case errors.Is(err, columnRepo.ErrColumnLimitReached):
+		status, detail = http.StatusForbidden, "Column limit reached"
+	case errors.Is(err, taskRepo.ErrTaskLimitReached):
+		status, detail = http.StatusForbidden, "Task limit reached"
+	case errors.Is(err, taskRepo.ErrTaskBlocked):
+		status, detail = http.StatusConflict, "Task is blocked by unfinished tasks"
+	}
+
+	var revertErr *activityModel.RevertError
+	if errors.As(err, &revertErr) {
+		ctx.AbortWithStatusJSON(status, gin.H{
+			"detail":   detail,
+			"event_id": revertErr.EventID,
+		})
+		return
+	}
+
+	ctx.AbortWithStatusJSON(status, gin.H{
+		"detail": detail,
+	})
+}
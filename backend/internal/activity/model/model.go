@@ -0,0 +1,62 @@
+package activityModel
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Event is a change of a task or column. Before and After hold the entity
+// as returned by its GET endpoint, null for creation and deletion.
+type Event struct {
+	ID         string          `json:"id"`
+	UserID     *string         `json:"user_id"`
+	BoardID    string          `json:"board_id"`
+	Entity     string          `json:"entity"`
+	EntityID   string          `json:"entity_id"`
+	Action     string          `json:"action"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	RevertsID  *string         `json:"reverts_id"`
+	CreatedAt  time.Time       `json:"created_at"`
+	RevertedAt *time.Time      `json:"reverted_at"`
+}
+
+// Untracked lists the changes the history leaves out: boards themselves,
+// whose history is deleted with them, and links between tasks.
+var Untracked = []string{"board", "task_dependency"}
+
+type Page struct {
+	Events    []Event  `json:"events"`
+	Total     int      `json:"total"`
+	Limit     int      `json:"limit"`
+	Offset    int      `json:"offset"`
+	Untracked []string `json:"untracked"`
+}
+
+type ListQuery struct {
+	Limit  int `form:"limit"  binding:"omitempty,min=1,max=100"`
+	Offset int `form:"offset" binding:"omitempty,min=0"`
+}
+
+type UndoQuery struct {
+	Count int `form:"count" binding:"omitempty,min=1,max=20"`
+}
+
+type UndoResult struct {
+	Reverted []Event `json:"reverted"`
+}
+
+// RevertError names the event that could not be reverted.
+type RevertError struct {
+	EventID string
+	Err     error
+}
+
+func (e *RevertError) Error() string {
+	return fmt.Sprintf("event %s: %v", e.EventID, e.Err)
+}
+
+func (e *RevertError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,71 @@
+package activityProxy
+
+import (
+	"errors"
+	"fmt"
+	activityModel "kanban/internal/activity/model"
+)
+
+var ErrForbidden = errors.New("access denied")
+
+type Service interface {
+	GetBoardActivity(boardID string, query activityModel.ListQuery) (*activityModel.Page, error)
+	RevertEvent(eventID, userID string) (*activityModel.Event, error)
+	Undo(userID string, query activityModel.UndoQuery) (*activityModel.UndoResult, error)
+	GetEventBoard(eventID string) (*string, error)
+	HasBoardAccess(boardID, userID string) (bool, error)
+}
+
+type Proxy struct {
+	service Service
+}
+
+func NewProxy(service Service) *Proxy {
+	return &Proxy{service: service}
+}
+
+func (p *Proxy) GetBoardActivity(boardID, userID string, query activityModel.ListQuery) (*activityModel.Page, error) {
+	hasAccess, err := p.checkBoardAccess(boardID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("activityProxy.GetBoardActivity: %w", err)
+	}
+
+	if hasAccess {
+		return p.service.GetBoardActivity(boardID, query)
+	} else {
+		return nil, fmt.Errorf("activityProxy.GetBoardActivity: %w", ErrForbidden)
+	}
+}
+
+// RevertEvent lets every user with access to the board revert its changes.
+func (p *Proxy) RevertEvent(eventID, userID string) (*activityModel.Event, error) {
+	boardID, err := p.service.GetEventBoard(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("activityProxy.RevertEvent: %w", err)
+	}
+
+	hasAccess, err := p.checkBoardAccess(*boardID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("activityProxy.RevertEvent: %w", err)
+	}
+
+	if hasAccess {
+		return p.service.RevertEvent(eventID, userID)
+	} else {
+		return nil, fmt.Errorf("activityProxy.RevertEvent: %w", ErrForbidden)
+	}
+}
+
+// Undo is checked by the repository: it only picks changes on boards the
+// user can still reach, with the same rules as HasBoardAccess.
+func (p *Proxy) Undo(userID string, query activityModel.UndoQuery) (*activityModel.UndoResult, error) {
+	return p.service.Undo(userID, query)
+}
+
+func (p *Proxy) checkBoardAccess(boardID, userID string) (bool, error) {
+	hasAccess, err := p.service.HasBoardAccess(boardID, userID)
+	if err != nil {
+		return false, fmt.Errorf("activityProxy.checkBoardAccess: %w", err)
+	}
+	return hasAccess, nil
+}
@@ -0,0 +1,516 @@
+package activityRepo
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	activityModel "kanban/internal/activity/model"
+	columnModel "kanban/internal/column/model"
+	columnRepo "kanban/internal/column/repo"
+	"kanban/internal/history"
+	"kanban/internal/postgres"
+	taskModel "kanban/internal/task/model"
+	taskRepo "kanban/internal/task/repo"
+	"kanban/internal/utils"
+	"time"
+)
+
+var ErrAlreadyReverted = errors.New("event is already reverted")
+var ErrConflict = errors.New("entity changed since the event")
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) GetAll(boardID string, query activityModel.ListQuery) ([]activityModel.Event, error) {
+	rows, err := r.db.Query(postgres.QueryGetBoardActivity, boardID, query.Limit, query.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("activityRepo.GetAll: %w", err)
+	}
+	defer rows.Close()
+
+	events, err := scanEvents(rows)
+	if err != nil {
+		return nil, fmt.Errorf("activityRepo.GetAll: %w", err)
+	}
+	return events, nil
+}
+
+func (r *Repository) Count(boardID string) (int, error) {
+	var count int
+	err := r.db.QueryRow(postgres.QueryGetBoardActivityCount, boardID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("activityRepo.Count: %w", err)
+	}
+	return count, nil
+}
+
+// Revert restores the state from before the event in one transaction.
+func (r *Repository) Revert(eventID, userID string) (*activityModel.Event, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("activityRepo.Revert: %w", err)
+	}
+	defer tx.Rollback()
+
+	event, err := scanEvent(tx.QueryRow(postgres.QueryGetActivityForUpdate, eventID))
+	if err != nil {
+		return nil, fmt.Errorf("activityRepo.Revert: %w", err)
+	}
+
+	reverted, err := revert(tx, userID, event, utils.GenerateTimestamp())
+	if err != nil {
+		return nil, fmt.Errorf("activityRepo.Revert: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("activityRepo.Revert: %w", err)
+	}
+	return reverted, nil
+}
+
+// Undo reverts the last changes of the user, newest first. Reverts are not
+// undone again, and the first one that fails rolls back all of them.
+func (r *Repository) Undo(userID string, count int) ([]activityModel.Event, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("activityRepo.Undo: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(postgres.QueryGetUndoableActivity, userID, count, postgres.OwnBoardsOnly())
+	if err != nil {
+		return nil, fmt.Errorf("activityRepo.Undo: %w", err)
+	}
+	events, err := scanEvents(rows)
+	rows.Close()
+	if err != nil {
+		return nil, fmt.Errorf("activityRepo.Undo: %w", err)
+	}
+
+	now := utils.GenerateTimestamp()
+	reverted := make([]activityModel.Event, 0, len(events))
+	for _, event := range events {
+		revertEvent, err := revert(tx, userID, &event, now)
+		if err != nil {
+			return nil, fmt.Errorf("activityRepo.Undo: %w", &activityModel.RevertError{EventID: event.ID, Err: err})
+		}
+		reverted = append(reverted, *revertEvent)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("activityRepo.Undo: %w", err)
+	}
+	return reverted, nil
+}
+
+func (r *Repository) GetBoardID(eventID string) (*string, error) {
+	var boardID string
+	err := r.db.QueryRow(postgres.QueryGetActivityBoardID, eventID).Scan(&boardID)
+	if err != nil {
+		return nil, fmt.Errorf("activityRepo.GetBoardID: %w", err)
+	}
+	return &boardID, nil
+}
+
+func (r *Repository) HasBoardAccess(boardID, userID string) (bool, error) {
+	var hasAccess bool
+	err := r.db.QueryRow(postgres.QueryHasBoardAccess, boardID, userID, postgres.OwnBoardsOnly()).Scan(&hasAccess)
+	if err != nil {
+		return false, fmt.Errorf("activityRepo.HasBoardAccess: %w", err)
+	}
+	return hasAccess, nil
+}
+
+// revert brings the entity back to the Before state of the event and
+// records that as a new event, which can be reverted in turn. The entity
+// has to be as the event left it: gone after a deletion, otherwise not
+// updated since. The restored entity gets its old updated_at back, so
+// earlier events of it stay revertible.
+func revert(tx *sql.Tx, userID string, event *activityModel.Event, now time.Time) (*activityModel.Event, error) {
+	if event.RevertedAt != nil {
+		return nil, ErrAlreadyReverted
+	}
+
+	var before, after any
+	var err error
+	switch event.Entity {
+	case history.EntityTask:
+		before, after, err = revertTask(tx, event)
+	case history.EntityColumn:
+		before, after, err = revertColumn(tx, event)
+	default:
+		err = fmt.Errorf("unknown entity %q", event.Entity)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	id := utils.NewUUID()
+	err = history.Record(tx, history.Event{
+		ID:        id,
+		UserID:    userID,
+		BoardID:   event.BoardID,
+		Entity:    event.Entity,
+		EntityID:  event.EntityID,
+		Action:    history.ActionRevert,
+		Before:    before,
+		After:     after,
+		RevertsID: &event.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(postgres.QueryMarkActivityReverted, now, event.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanEvent(tx.QueryRow(postgres.QueryGetActivity, id))
+}
+
+func revertTask(tx *sql.Tx, event *activityModel.Event) (*taskModel.Task, *taskModel.Task, error) {
+	var before, after *taskModel.Task
+	if err := decode(event.Before, &before); err != nil {
+		return nil, nil, err
+	}
+	if err := decode(event.After, &after); err != nil {
+		return nil, nil, err
+	}
+
+	current, err := taskRepo.LockTask(tx, event.EntityID)
+	if errors.Is(err, sql.ErrNoRows) {
+		current, err = nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if (current == nil) != (after == nil) || current != nil && !current.UpdatedAt.Equal(after.UpdatedAt) {
+		return nil, nil, ErrConflict
+	}
+
+	var restored *taskModel.Task
+	switch {
+	case before == nil:
+		err = taskRepo.DeleteTask(tx, current)
+	case current == nil:
+		restored, err = restoreTask(tx, before)
+	default:
+		restored, err = resetTask(tx, current, before)
+	}
+	return current, restored, err
+}
+
+// restoreTask inserts the deleted task where it was, or at the end of the
+// column if it has fewer tasks now, and links it again with the tasks that
+// are still there.
+func restoreTask(tx *sql.Tx, task *taskModel.Task) (*taskModel.Task, error) {
+	var boardID string
+	err := tx.QueryRow(postgres.QueryGetBoardIDByColumnID, task.ColumnID).Scan(&boardID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pos, err := insertTaskPosition(tx, task.ColumnID, task.Position)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(postgres.QueryMoveTasksForInsert, task.ColumnID, pos)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(
+		postgres.QueryRestoreTask,
+		task.ID,
+		task.ColumnID,
+		task.CreatedAt,
+		task.UpdatedAt,
+		task.Name,
+		task.Description,
+		pos,
+		task.Done,
+		task.Deadline,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = restoreDependencies(tx, task); err != nil {
+		return nil, err
+	}
+
+	return taskRepo.LockTask(tx, task.ID)
+}
+
+// restoreDependencies skips tasks that are gone and links that would now
+// close a cycle.
+func restoreDependencies(tx *sql.Tx, task *taskModel.Task) error {
+	if len(task.BlockedBy) == 0 && len(task.Blocks) == 0 {
+		return nil
+	}
+
+	_, err := tx.Exec(postgres.QueryLockTaskDependencies)
+	if err != nil {
+		return err
+	}
+
+	now := utils.GenerateTimestamp()
+	for _, blockerID := range task.BlockedBy {
+		if err = linkTasks(tx, blockerID, task.ID, blockerID, now); err != nil {
+			return err
+		}
+	}
+	for _, blockedID := range task.Blocks {
+		if err = linkTasks(tx, task.ID, blockedID, blockedID, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func linkTasks(tx *sql.Tx, blockerID, blockedID, otherID string, now time.Time) error {
+	var columnID string
+	var pos int
+	err := tx.QueryRow(postgres.QueryGetColumnIDAndPosition, otherID).Scan(&columnID, &pos)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var cycle bool
+	err = tx.QueryRow(postgres.QueryCheckDependencyCycle, blockedID, blockerID).Scan(&cycle)
+	if err != nil || cycle {
+		return err
+	}
+
+	_, err = tx.Exec(postgres.QueryCreateTaskDependency, blockerID, blockedID, now)
+	return err
+}
+
+// resetTask moves the task back and restores its content. Dependencies are
+// not part of the change and are left alone.
+func resetTask(tx *sql.Tx, current, before *taskModel.Task) (*taskModel.Task, error) {
+	var err error
+	switch {
+	case before.ColumnID != current.ColumnID:
+		var pos int
+		pos, err = insertTaskPosition(tx, before.ColumnID, before.Position)
+		if err == nil {
+			_, err = taskRepo.MoveTask(tx, current, before.ColumnID, &pos, before.UpdatedAt)
+		}
+	case before.Position != current.Position:
+		var maxPos int
+		maxPos, err = taskRepo.NextPosition(tx, current.ColumnID)
+		if err == nil {
+			_, err = taskRepo.RepositionTask(tx, current, min(before.Position, maxPos-1), before.UpdatedAt)
+		}
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(
+		postgres.QueryRestoreTaskContent,
+		before.Name,
+		before.Description,
+		before.Done,
+		before.Deadline,
+		before.UpdatedAt,
+		current.ID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return taskRepo.LockTask(tx, current.ID)
+}
+
+// insertTaskPosition keeps the position if the column still has that many
+// tasks and appends to the end otherwise.
+func insertTaskPosition(tx *sql.Tx, columnID string, position int) (int, error) {
+	var count int
+	err := tx.QueryRow(postgres.QueryGetTasksCount, columnID).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	if count >= taskRepo.MaxTasks {
+		return 0, taskRepo.ErrTaskLimitReached
+	}
+
+	maxPos, err := taskRepo.NextPosition(tx, columnID)
+	if err != nil {
+		return 0, err
+	}
+	return min(position, maxPos), nil
+}
+
+func revertColumn(tx *sql.Tx, event *activityModel.Event) (*columnModel.Column, *columnModel.Column, error) {
+	var before, after *columnModel.Column
+	if err := decode(event.Before, &before); err != nil {
+		return nil, nil, err
+	}
+	if err := decode(event.After, &after); err != nil {
+		return nil, nil, err
+	}
+
+	current, err := columnRepo.LockColumn(tx, event.EntityID)
+	if errors.Is(err, sql.ErrNoRows) {
+		current, err = nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if (current == nil) != (after == nil) || current != nil && !current.UpdatedAt.Equal(after.UpdatedAt) {
+		return nil, nil, ErrConflict
+	}
+
+	var restored *columnModel.Column
+	switch {
+	case before == nil:
+		err = deleteColumn(tx, current)
+	case current == nil:
+		restored, err = restoreColumn(tx, before)
+	default:
+		restored, err = resetColumn(tx, current, before)
+	}
+	return current, restored, err
+}
+
+// deleteColumn only removes columns that are still empty, tasks added
+// since count as a change.
+func deleteColumn(tx *sql.Tx, column *columnModel.Column) error {
+	var count int
+	err := tx.QueryRow(postgres.QueryGetTasksCount, column.ID).Scan(&count)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrConflict
+	}
+
+	return columnRepo.DeleteColumn(tx, column)
+}
+
+func restoreColumn(tx *sql.Tx, column *columnModel.Column) (*columnModel.Column, error) {
+	var count int
+	err := tx.QueryRow(postgres.QueryGetColumnsCount, column.BoardID).Scan(&count)
+	if err != nil {
+		return nil, err
+	}
+	if count >= columnRepo.MaxColumns {
+		return nil, columnRepo.ErrColumnLimitReached
+	}
+
+	maxPos, err := columnRepo.NextPosition(tx, column.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	pos := min(column.Position, maxPos)
+
+	_, err = tx.Exec(postgres.QueryMoveColumnsRight, column.BoardID, pos, maxPos)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(
+		postgres.QueryCreateColumn,
+		column.ID,
+		column.BoardID,
+		column.CreatedAt,
+		column.UpdatedAt,
+		column.Name,
+		pos,
+		column.Flag,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return columnRepo.LockColumn(tx, column.ID)
+}
+
+func resetColumn(tx *sql.Tx, current, before *columnModel.Column) (*columnModel.Column, error) {
+	maxPos, err := columnRepo.NextPosition(tx, current.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	pos := min(before.Position, maxPos-1)
+
+	flag := flagValue(before.Flag)
+	return columnRepo.UpdateColumn(tx, current, &before.Name, &pos, &flag, before.UpdatedAt)
+}
+
+// flagValue turns a missing flag into the empty string the column queries
+// use to clear it.
+func flagValue(flag *string) string {
+	if flag == nil {
+		return ""
+	}
+	return *flag
+}
+
+func decode(data json.RawMessage, v any) error {
+	if data == nil {
+		return nil
+	}
+	return json.Unmarshal(data, v)
+}
+
+func scanEvents(rows *sql.Rows) ([]activityModel.Event, error) {
+	events := []activityModel.Event{}
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, *event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanEvent(row scanner) (*activityModel.Event, error) {
+	var event activityModel.Event
+	var before, after []byte
+	err := row.Scan(
+		&event.ID,
+		&event.UserID,
+		&event.BoardID,
+		&event.Entity,
+		&event.EntityID,
+		&event.Action,
+		&before,
+		&after,
+		&event.RevertsID,
+		&event.CreatedAt,
+		&event.RevertedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	event.Before, event.After = before, after
+	return &event, nil
+}
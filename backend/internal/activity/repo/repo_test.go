@@ -0,0 +1,323 @@
+package activityRepo
+
+import (
+	"database/sql"
+	"errors"
+	activityModel "kanban/internal/activity/model"
+	columnModel "kanban/internal/column/model"
+	columnRepo "kanban/internal/column/repo"
+	"kanban/internal/postgres"
+	taskModel "kanban/internal/task/model"
+	taskRepo "kanban/internal/task/repo"
+	"kanban/internal/utils"
+	"os"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4"
+	migratePostgres "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+)
+
+// The tests run against the database in KANBAN_TEST_DATABASE_URL, which
+// is migrated to the latest version first. Without it they are skipped.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	uri := os.Getenv("KANBAN_TEST_DATABASE_URL")
+	if uri == "" {
+		t.Skip("KANBAN_TEST_DATABASE_URL is not set")
+	}
+
+	db, err := sql.Open("postgres", uri)
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+
+	driver, err := migratePostgres.WithInstance(db, &migratePostgres.Config{})
+	if err != nil {
+		t.Fatalf("migration driver: %v", err)
+	}
+	m, err := migrate.NewWithDatabaseInstance("file://../../../db/migrations", "postgres", driver)
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if err = m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		t.Fatalf("migrate up: %v", err)
+	}
+
+	return db
+}
+
+type fixture struct {
+	t        *testing.T
+	userID   string
+	boardID  string
+	columns  *columnRepo.Repository
+	tasks    *taskRepo.Repository
+	activity *Repository
+}
+
+// newFixture creates a user with a board of its own, both removed again
+// with all of their history when the test ends.
+func newFixture(t *testing.T) *fixture {
+	db := openTestDB(t)
+	now := utils.GenerateTimestamp()
+	userID, workspaceID, boardID := utils.NewUUID(), utils.NewUUID(), utils.NewUUID()
+
+	for _, stmt := range []struct {
+		query string
+		args  []any
+	}{
+		{`INSERT INTO "user" (id, created_at, email, username, hashed_password) VALUES ($1, $2, $3, 'test', '')`,
+			[]any{userID, now, userID + "@example.org"}},
+		{`INSERT INTO workspace (id, created_at, updated_at, name) VALUES ($1, $2, $2, 'test')`,
+			[]any{workspaceID, now}},
+		{`INSERT INTO workspace_member (workspace_id, user_id, role, created_at) VALUES ($1, $2, 'owner', $3)`,
+			[]any{workspaceID, userID, now}},
+		{postgres.QueryCreateBoard, []any{boardID, userID, workspaceID, now, now, "test", false}},
+	} {
+		if _, err := db.Exec(stmt.query, stmt.args...); err != nil {
+			t.Fatalf("create fixture: %v", err)
+		}
+	}
+
+	t.Cleanup(func() {
+		db.Exec(`DELETE FROM workspace WHERE id = $1`, workspaceID)
+		db.Exec(`DELETE FROM "user" WHERE id = $1`, userID)
+		db.Close()
+	})
+
+	return &fixture{
+		t:        t,
+		userID:   userID,
+		boardID:  boardID,
+		columns:  columnRepo.NewRepository(db),
+		tasks:    taskRepo.NewRepository(db),
+		activity: NewRepository(db),
+	}
+}
+
+func (f *fixture) createColumn(name string) string {
+	f.t.Helper()
+	column, err := f.columns.Create(columnModel.Column{ID: utils.NewUUID(), BoardID: f.boardID, Name: name}, f.userID)
+	if err != nil {
+		f.t.Fatalf("create column %s: %v", name, err)
+	}
+	return column.ID
+}
+
+func (f *fixture) createTasks(columnID string, names ...string) map[string]string {
+	f.t.Helper()
+	ids := map[string]string{}
+	for _, name := range names {
+		task, err := f.tasks.Create(taskModel.Task{ID: utils.NewUUID(), ColumnID: columnID, Name: name}, f.userID)
+		if err != nil {
+			f.t.Fatalf("create task %s: %v", name, err)
+		}
+		ids[name] = task.ID
+	}
+	return ids
+}
+
+// lastEvent returns the newest event of the board.
+func (f *fixture) lastEvent() activityModel.Event {
+	f.t.Helper()
+	events, err := f.activity.GetAll(f.boardID, activityModel.ListQuery{Limit: 1})
+	if err != nil || len(events) == 0 {
+		f.t.Fatalf("get last event: %v", err)
+	}
+	return events[0]
+}
+
+// order returns the task names of the column by position and checks that
+// the positions have no gaps.
+func (f *fixture) order(columnID string) []string {
+	f.t.Helper()
+	tasks, err := f.tasks.GetAll(columnID)
+	if err != nil {
+		f.t.Fatalf("get tasks: %v", err)
+	}
+
+	names := []string{}
+	for i, task := range tasks {
+		if task.Position != i+1 {
+			f.t.Fatalf("task %s at position %d, want %d", task.Name, task.Position, i+1)
+		}
+		names = append(names, task.Name)
+	}
+	return names
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRevertConflict(t *testing.T) {
+	f := newFixture(t)
+	columnID := f.createColumn("todo")
+	taskID := f.createTasks(columnID, "a")["a"]
+
+	rename := func(name string) activityModel.Event {
+		_, err := f.tasks.UpdateContent(taskID, f.userID, taskModel.UpdateRequest{Name: &name})
+		if err != nil {
+			t.Fatalf("rename task: %v", err)
+		}
+		return f.lastEvent()
+	}
+	first := rename("b")
+	second := rename("c")
+
+	if _, err := f.activity.Revert(first.ID, f.userID); !errors.Is(err, ErrConflict) {
+		t.Fatalf("revert of an overwritten change: got %v, want %v", err, ErrConflict)
+	}
+
+	if _, err := f.activity.Revert(second.ID, f.userID); err != nil {
+		t.Fatalf("revert of the last change: %v", err)
+	}
+	if _, err := f.activity.Revert(second.ID, f.userID); !errors.Is(err, ErrAlreadyReverted) {
+		t.Fatalf("second revert: got %v, want %v", err, ErrAlreadyReverted)
+	}
+
+	// The revert restored updated_at, so the older change is its latest
+	// one again.
+	if _, err := f.activity.Revert(first.ID, f.userID); err != nil {
+		t.Fatalf("revert of the earlier change: %v", err)
+	}
+	task, err := f.tasks.Get(taskID)
+	if err != nil {
+		t.Fatalf("get task: %v", err)
+	}
+	if task.Name != "a" {
+		t.Errorf("name after both reverts: got %q, want %q", task.Name, "a")
+	}
+
+	// A deleted task can not get an update reverted.
+	restore := f.lastEvent()
+	if err = f.tasks.Delete(taskID, f.userID); err != nil {
+		t.Fatalf("delete task: %v", err)
+	}
+	if _, err = f.activity.Revert(restore.ID, f.userID); !errors.Is(err, ErrConflict) {
+		t.Fatalf("revert on a deleted task: got %v, want %v", err, ErrConflict)
+	}
+}
+
+func TestRevertRestoresPosition(t *testing.T) {
+	tests := []struct {
+		name    string
+		deleted []string
+		restore string
+		want    []string
+	}{
+		{"gap is still there", []string{"b"}, "b", []string{"a", "b", "c"}},
+		{"first task", []string{"a"}, "a", []string{"a", "b", "c"}},
+		{"column got shorter", []string{"b", "c"}, "b", []string{"a", "b"}},
+		{"column got empty", []string{"c", "a", "b"}, "c", []string{"c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			columnID := f.createColumn("todo")
+			ids := f.createTasks(columnID, "a", "b", "c")
+
+			var restore string
+			for _, name := range tt.deleted {
+				if err := f.tasks.Delete(ids[name], f.userID); err != nil {
+					t.Fatalf("delete %s: %v", name, err)
+				}
+				if name == tt.restore {
+					restore = f.lastEvent().ID
+				}
+			}
+
+			if _, err := f.activity.Revert(restore, f.userID); err != nil {
+				t.Fatalf("revert: %v", err)
+			}
+			if got := f.order(columnID); !equal(got, tt.want) {
+				t.Errorf("order: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRevertMove(t *testing.T) {
+	f := newFixture(t)
+	todo := f.createColumn("todo")
+	done := f.createColumn("done")
+	ids := f.createTasks(todo, "a", "b", "c")
+
+	position := 1
+	_, err := f.tasks.UpdateColumn(ids["b"], f.userID, taskModel.UpdateRequest{ColumnID: &done, Position: &position})
+	if err != nil {
+		t.Fatalf("move task: %v", err)
+	}
+	if _, err = f.activity.Revert(f.lastEvent().ID, f.userID); err != nil {
+		t.Fatalf("revert: %v", err)
+	}
+
+	if got, want := f.order(todo), []string{"a", "b", "c"}; !equal(got, want) {
+		t.Errorf("order of todo: got %v, want %v", got, want)
+	}
+	if got := f.order(done); len(got) != 0 {
+		t.Errorf("order of done: got %v, want none", got)
+	}
+}
+
+// Deleting a column records the deletion of its tasks before its own, so
+// undo brings back the column first and then the tasks in order.
+func TestUndoColumnDelete(t *testing.T) {
+	f := newFixture(t)
+	columnID := f.createColumn("todo")
+	f.createTasks(columnID, "a", "b", "c")
+
+	if err := f.columns.Delete(columnID, f.userID); err != nil {
+		t.Fatalf("delete column: %v", err)
+	}
+
+	reverted, err := f.activity.Undo(f.userID, 4)
+	if err != nil {
+		t.Fatalf("undo: %v", err)
+	}
+
+	var entities []string
+	for _, event := range reverted {
+		entities = append(entities, event.Entity)
+	}
+	if want := []string{"column", "task", "task", "task"}; !equal(entities, want) {
+		t.Errorf("reverted entities: got %v, want %v", entities, want)
+	}
+	if got, want := f.order(columnID), []string{"a", "b", "c"}; !equal(got, want) {
+		t.Errorf("order: got %v, want %v", got, want)
+	}
+}
+
+// Undo takes the newest changes of the user and never the reverts it
+// made itself, so a second undo goes further back.
+func TestUndoOrder(t *testing.T) {
+	f := newFixture(t)
+	columnID := f.createColumn("todo")
+	ids := f.createTasks(columnID, "a", "b", "c")
+
+	for _, name := range []string{"c", "b"} {
+		if err := f.tasks.Delete(ids[name], f.userID); err != nil {
+			t.Fatalf("delete %s: %v", name, err)
+		}
+	}
+
+	for _, want := range [][]string{{"a", "b"}, {"a", "b", "c"}, {"a", "b"}} {
+		if _, err := f.activity.Undo(f.userID, 1); err != nil {
+			t.Fatalf("undo: %v", err)
+		}
+		if got := f.order(columnID); !equal(got, want) {
+			t.Fatalf("order: got %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package activityService
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	activityModel "kanban/internal/activity/model"
+)
+
+const defaultPageSize int = 20
+
+const defaultUndoCount int = 1
+
+var ErrEventNotFound = errors.New("event not found")
+var ErrBoardNotFound = errors.New("board not found")
+var ErrNothingToUndo = errors.New("nothing to undo")
+
+type Repository interface {
+	GetAll(boardID string, query activityModel.ListQuery) ([]activityModel.Event, error)
+	Count(boardID string) (int, error)
+	Revert(eventID, userID string) (*activityModel.Event, error)
+	Undo(userID string, count int) ([]activityModel.Event, error)
+	GetBoardID(eventID string) (*string, error)
+	HasBoardAccess(boardID, userID string) (bool, error)
+}
+
+type Service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+func (s *Service) GetBoardActivity(boardID string, query activityModel.ListQuery) (*activityModel.Page, error) {
+	if query.Limit == 0 {
+		query.Limit = defaultPageSize
+	}
+
+	events, err := s.repo.GetAll(boardID, query)
+	if err != nil {
+		return nil, fmt.Errorf("activityService.GetBoardActivity: %w", err)
+	}
+
+	total, err := s.repo.Count(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("activityService.GetBoardActivity: %w", err)
+	}
+
+	return &activityModel.Page{
+		Events:    events,
+		Total:     total,
+		Limit:     query.Limit,
+		Offset:    query.Offset,
+		Untracked: activityModel.Untracked,
+	}, nil
+}
+
+// RevertEvent undoes a single change, also one made by another user or a
+// revert itself.
+func (s *Service) RevertEvent(eventID, userID string) (*activityModel.Event, error) {
+	event, err := s.repo.Revert(eventID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("activityService.RevertEvent: %w", ErrEventNotFound)
+		}
+		return nil, fmt.Errorf("activityService.RevertEvent: %w", err)
+	}
+
+	return event, nil
+}
+
+func (s *Service) Undo(userID string, query activityModel.UndoQuery) (*activityModel.UndoResult, error) {
+	if query.Count == 0 {
+		query.Count = defaultUndoCount
+	}
+
+	reverted, err := s.repo.Undo(userID, query.Count)
+	if err != nil {
+		return nil, fmt.Errorf("activityService.Undo: %w", err)
+	}
+	if len(reverted) == 0 {
+		return nil, fmt.Errorf("activityService.Undo: %w", ErrNothingToUndo)
+	}
+
+	return &activityModel.UndoResult{Reverted: reverted}, nil
+}
+
+func (s *Service) GetEventBoard(eventID string) (*string, error) {
+	boardID, err := s.repo.GetBoardID(eventID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("activityService.GetEventBoard: %w", ErrEventNotFound)
+		}
+		return nil, fmt.Errorf("activityService.GetEventBoard: %w", err)
+	}
+
+	return boardID, nil
+}
+
+func (s *Service) HasBoardAccess(boardID, userID string) (bool, error) {
+	hasAccess, err := s.repo.HasBoardAccess(boardID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, fmt.Errorf("activityService.HasBoardAccess: %w", ErrBoardNotFound)
+		}
+		return false, fmt.Errorf("activityService.HasBoardAccess: %w", err)
+	}
+
+	return hasAccess, nil
+}
@@ -3,18 +3,116 @@ package auth
 import (
 	"database/sql"
 	authHandler "kanban/internal/auth/handler"
+	authMiddleware "kanban/internal/auth/middleware"
 	authRepo "kanban/internal/auth/repo"
 	authService "kanban/internal/auth/service"
+	"kanban/internal/config"
+	invitationRepo "kanban/internal/invitation/repo"
+	invitationService "kanban/internal/invitation/service"
+	"kanban/internal/ldap"
+	"kanban/internal/mailer"
+	"kanban/internal/notify"
+	"kanban/internal/oidc"
+	"kanban/internal/ratelimit"
+	"kanban/internal/scheduler"
+	"kanban/internal/signing"
+	workspaceRepo "kanban/internal/workspace/repo"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
-func Init(db *sql.DB, grp *gin.RouterGroup) {
+// Login attempts are limited per client IP and per account. The IP limit is
+// looser since several users may share an address.
+var (
+	loginIPPolicy = ratelimit.Policy{
+		MaxAttempts: 30,
+		Window:      time.Minute,
+		MaxFailures: 20,
+		BaseLockout: time.Minute,
+		MaxLockout:  time.Hour,
+		IdleTTL:     24 * time.Hour,
+	}
+	loginAccountPolicy = ratelimit.Policy{
+		MaxAttempts: 10,
+		Window:      time.Minute,
+		MaxFailures: 5,
+		BaseLockout: 30 * time.Second,
+		MaxLockout:  time.Hour,
+		IdleTTL:     24 * time.Hour,
+	}
+)
+
+func Init(db *sql.DB, grp *gin.RouterGroup, wellKnown *gin.RouterGroup) {
+	cfg := config.Get()
+
+	var store ratelimit.Store = ratelimit.NewMemoryStore()
+	if cfg.RateLimitStore == config.RateLimitStorePostgres {
+		store = ratelimit.NewPostgresStore(db)
+	}
+	ipLimiter := ratelimit.New(store, "login_ip", loginIPPolicy)
+	accountLimiter := ratelimit.New(store, "login_account", loginAccountPolicy)
+
+	scheduler.New("Login IP limiter cleanup", cfg.SchedulerInterval, ipLimiter.Cleanup).Start()
+	scheduler.New("Login account limiter cleanup", cfg.SchedulerInterval, accountLimiter.Cleanup).Start()
+	scheduler.New("Signing key rotation", cfg.SchedulerInterval, signing.Get().Rotate).Start()
+
+	var provider authService.OIDCProvider
+	if cfg.OIDCIssuer != "" {
+		provider = oidc.NewProvider(oidc.Config{
+			Issuer:       cfg.OIDCIssuer,
+			ClientID:     cfg.OIDCClientID,
+			ClientSecret: cfg.OIDCClientSecret,
+			RedirectURL:  cfg.OIDCRedirectURL,
+			Scopes:       cfg.OIDCScopes,
+		}, nil)
+	}
+
 	repo := authRepo.NewRepository(db)
-	service := authService.NewService(repo)
+
+	// The directory is asked first, local passwords still work for users
+	// that are not in it.
+	var authenticators []authService.Authenticator
+	if cfg.LDAPURL != "" {
+		directory := ldap.NewDirectory(ldap.Config{
+			URL:          cfg.LDAPURL,
+			StartTLS:     cfg.LDAPStartTLS,
+			BindDN:       cfg.LDAPBindDN,
+			BindPassword: cfg.LDAPBindPassword,
+			BaseDN:       cfg.LDAPBaseDN,
+			UserFilter:   cfg.LDAPUserFilter,
+			IDAttribute:  cfg.LDAPIDAttribute,
+			GroupBaseDN:  cfg.LDAPGroupBaseDN,
+		})
+		authenticators = append(authenticators, authService.NewLDAPAuthenticator(repo, directory, cfg.LDAPWorkspaceID, cfg.LDAPGroupRoles, cfg.LDAPRequireGroup))
+	}
+	authenticators = append(authenticators, authService.NewPasswordAuthenticator(repo))
+
+	// Signing up through an invitation link joins the invited board.
+	invitations := invitationService.NewService(invitationRepo.NewRepository(db), mailer.New(), notify.New(db), workspaceRepo.NewRepository(db))
+
+	service := authService.NewService(repo, mailer.New(), ipLimiter, accountLimiter, provider, authenticators, invitations)
 	handler := authHandler.NewHandler(service)
 
 	grp.POST("/register", handler.RegisterHandler())
 	grp.POST("/login", handler.LoginHandler())
+	grp.POST("/login/mfa", handler.LoginMFAHandler())
+	if provider != nil {
+		grp.GET("/oidc/login", handler.OIDCLoginHandler())
+		grp.GET("/oidc/callback", handler.OIDCCallbackHandler())
+	}
+	grp.POST("/password/forgot", handler.ForgotPasswordHandler())
+	grp.POST("/password/reset", handler.ResetPasswordHandler())
+	grp.POST("/password/change", Middleware(db), authMiddleware.SessionOnly(), handler.ChangePasswordHandler())
+	grp.POST("/verify", handler.VerifyEmailHandler())
+	grp.POST("/verify/resend", handler.ResendVerificationHandler())
+	grp.POST("/mfa/enroll", Middleware(db), authMiddleware.SessionOnly(), handler.EnrollMFAHandler())
+	grp.POST("/mfa/confirm", Middleware(db), authMiddleware.SessionOnly(), handler.ConfirmMFAHandler())
+	grp.POST("/mfa/disable", Middleware(db), authMiddleware.SessionOnly(), handler.DisableMFAHandler())
+
+	wellKnown.GET("/jwks.json", handler.JWKSHandler())
+}
 
-}
\ No newline at end of file
+func Middleware(db *sql.DB) gin.HandlerFunc {
+	return authMiddleware.Middleware(authRepo.NewRepository(db))
+}
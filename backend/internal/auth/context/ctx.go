@@ -1,8 +1,15 @@
 package authctx
 
-import "github.com/gin-gonic/gin"
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
 
 const userIDContextKey string = "userID"
+const emailVerifiedContextKey string = "emailVerified"
+const accessTokenIDContextKey string = "accessTokenID"
+const issuedAtContextKey string = "issuedAt"
 
 func SetUserID(ctx *gin.Context, userID string) {
 	ctx.Set(userIDContextKey, userID)
@@ -15,4 +22,40 @@ func GetUserID(ctx *gin.Context) (string, bool) {
 		return "", false
 	}
 	return userID.(string), true
-}
\ No newline at end of file
+}
+
+func SetEmailVerified(ctx *gin.Context, verified bool) {
+	ctx.Set(emailVerifiedContextKey, verified)
+}
+
+func IsEmailVerified(ctx *gin.Context) bool {
+	return ctx.GetBool(emailVerifiedContextKey)
+}
+
+// SetAccessTokenID marks the request as authenticated by a personal access
+// token rather than a session.
+func SetAccessTokenID(ctx *gin.Context, tokenID string) {
+	ctx.Set(accessTokenIDContextKey, tokenID)
+}
+
+func GetAccessTokenID(ctx *gin.Context) (string, bool) {
+	tokenID, ok := ctx.Get(accessTokenIDContextKey)
+	if !ok {
+		return "", false
+	}
+	return tokenID.(string), true
+}
+
+// SetIssuedAt records when the session token of the request was issued,
+// that is when the user last logged in.
+func SetIssuedAt(ctx *gin.Context, issuedAt time.Time) {
+	ctx.Set(issuedAtContextKey, issuedAt)
+}
+
+func GetIssuedAt(ctx *gin.Context) (time.Time, bool) {
+	issuedAt, ok := ctx.Get(issuedAtContextKey)
+	if !ok {
+		return time.Time{}, false
+	}
+	return issuedAt.(time.Time), true
+}
@@ -2,17 +2,33 @@ package authHandler
 
 import (
 	"errors"
+	authctx "kanban/internal/auth/context"
 	authModel "kanban/internal/auth/model"
 	authService "kanban/internal/auth/service"
+	"kanban/internal/jwk"
+	"kanban/internal/ratelimit"
 	"log"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
 type Service interface {
 	CreateUser(req authModel.RegisterRequest) (*string, error)
-	LoginUser(req authModel.LoginRequest) (*string, error)
+	LoginUser(req authModel.LoginRequest, clientIP string) (*authModel.LoginResponse, error)
+	RequestPasswordReset(req authModel.ForgotPasswordRequest) error
+	ResetPassword(req authModel.ResetPasswordRequest) (*authModel.LoginResponse, error)
+	ChangePassword(userID string, req authModel.ChangePasswordRequest) (*string, error)
+	VerifyEmail(req authModel.VerifyEmailRequest) error
+	ResendVerification(req authModel.ResendVerificationRequest) error
+	EnrollMFA(userID string) (*authModel.MFAEnrollResponse, error)
+	ConfirmMFA(userID string, req authModel.MFAConfirmRequest) (*authModel.MFAConfirmResponse, error)
+	DisableMFA(userID string, req authModel.MFADisableRequest) error
+	LoginMFA(req authModel.MFALoginRequest, clientIP string) (*authModel.LoginResponse, error)
+	StartOIDCLogin() (*authModel.OIDCStart, error)
+	FinishOIDCLogin(req authModel.OIDCCallback) (*authModel.LoginResponse, error)
+	JWKS() (jwk.Set, error)
 }
 
 type Handler struct {
@@ -36,10 +52,23 @@ func (h *Handler) RegisterHandler() gin.HandlerFunc {
 		token, err := h.service.CreateUser(req)
 		if err != nil {
 			log.Printf("Failed to create user: %v\n", err)
-			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-				"detail": "Failed to create user",
-			})
-			return
+			switch {
+			case errors.Is(err, authService.ErrInvalidInvitation):
+				ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+					"detail": "Invalid or expired invitation",
+				})
+				return
+			case errors.Is(err, authService.ErrInvitationEmailMismatch):
+				ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+					"detail": "Invitation was sent to another email",
+				})
+				return
+			default:
+				ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+					"detail": "Failed to create user",
+				})
+				return
+			}
 		}
 
 		ctx.JSON(http.StatusCreated, gin.H{"token": token})
@@ -56,28 +85,199 @@ func (h *Handler) LoginHandler() gin.HandlerFunc {
 			return
 		}
 
-		token, err := h.service.LoginUser(req)
+		resp, err := h.service.LoginUser(req, ctx.ClientIP())
 		if err != nil {
 			log.Printf("Failed to login: %v", err)
+			var limited *ratelimit.LimitedError
+			switch {
+			case errors.As(err, &limited):
+				abortLimited(ctx, limited)
+				return
+			case errors.Is(err, authService.ErrInvalidCredentials):
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+					"detail": "Invalid email or password",
+				})
+				return
+			case errors.Is(err, authService.ErrLDAPGroupNotAllowed):
+				ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+					"detail": "Account is not allowed to use the tracker",
+				})
+				return
+			case errors.Is(err, authService.ErrLDAPAccountNotLinkable):
+				ctx.AbortWithStatusJSON(http.StatusConflict, gin.H{
+					"detail": "Account with this email is not verified",
+				})
+				return
+			case errors.Is(err, authService.ErrAuthUnavailable):
+				ctx.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+					"detail": "Login is temporarily unavailable",
+				})
+				return
+			default:
+				ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+					"detail": "Failed to login",
+				})
+				return
+			}
+		}
+
+		ctx.JSON(http.StatusOK, resp)
+	}
+}
+
+func (h *Handler) ForgotPasswordHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req authModel.ForgotPasswordRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid request body",
+			})
+			return
+		}
+
+		if err := h.service.RequestPasswordReset(req); err != nil {
+			log.Printf("Failed to request password reset: %v", err)
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"detail": "Failed to request password reset",
+			})
+			return
+		}
+
+		ctx.Status(http.StatusAccepted)
+	}
+}
+
+func (h *Handler) ResetPasswordHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req authModel.ResetPasswordRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid request body",
+			})
+			return
+		}
+
+		resp, err := h.service.ResetPassword(req)
+		if err != nil {
+			log.Printf("Failed to reset password: %v", err)
 			switch {
+			case errors.Is(err, authService.ErrInvalidResetToken):
+				ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+					"detail": "Invalid or expired reset token",
+				})
+				return
+			default:
+				ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+					"detail": "Failed to reset password",
+				})
+				return
+			}
+		}
+
+		ctx.JSON(http.StatusOK, resp)
+	}
+}
+
+func (h *Handler) ChangePasswordHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req authModel.ChangePasswordRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid request body",
+			})
+			return
+		}
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		token, err := h.service.ChangePassword(userID, req)
+		if err != nil {
+			log.Printf("Failed to change password: %v", err)
+			switch {
+			case errors.Is(err, authService.ErrIncorrectPassword):
+				ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+					"detail": "Current password is incorrect",
+				})
+				return
 			case errors.Is(err, authService.ErrUserNotFound):
 				ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{
 					"detail": "User not found",
 				})
 				return
-			case errors.Is(err, authService.ErrIncorrectPassword):
-				ctx.AbortWithStatusJSON(http.StatusTeapot, gin.H{
-					"detail": "Check your password, bro",
+			default:
+				ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+					"detail": "Failed to change password",
+				})
+				return
+			}
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"token": token})
+	}
+}
+
+func (h *Handler) VerifyEmailHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req authModel.VerifyEmailRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid request body",
+			})
+			return
+		}
+
+		err := h.service.VerifyEmail(req)
+		if err != nil {
+			log.Printf("Failed to verify email: %v", err)
+			switch {
+			case errors.Is(err, authService.ErrInvalidVerificationToken):
+				ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+					"detail": "Invalid or expired verification token",
 				})
 				return
 			default:
 				ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-					"detail": "Failed to login",
+					"detail": "Failed to verify email",
 				})
 				return
 			}
 		}
 
-		ctx.JSON(http.StatusOK, gin.H{"token": token})
+		ctx.Status(http.StatusOK)
+	}
+}
+
+func (h *Handler) ResendVerificationHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req authModel.ResendVerificationRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid request body",
+			})
+			return
+		}
+
+		if err := h.service.ResendVerification(req); err != nil {
+			log.Printf("Failed to resend verification: %v", err)
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"detail": "Failed to resend verification",
+			})
+			return
+		}
+
+		ctx.Status(http.StatusAccepted)
 	}
-}
\ No newline at end of file
+}
+
+func abortLimited(ctx *gin.Context, limited *ratelimit.LimitedError) {
+	ctx.Header("Retry-After", strconv.Itoa(limited.RetryAfterSeconds()))
+	ctx.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+		"detail": "Too many login attempts",
+	})
+}
@@ -0,0 +1,26 @@
+package authHandler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JWKSHandler publishes the public keys our tokens are signed with. Keys are
+// published well before they sign anything, so a short cache is safe.
+func (h *Handler) JWKSHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		set, err := h.service.JWKS()
+		if err != nil {
+			log.Printf("Failed to get JWKS: %v", err)
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"detail": "Failed to get signing keys",
+			})
+			return
+		}
+
+		ctx.Header("Cache-Control", "public, max-age=300")
+		ctx.JSON(http.StatusOK, set)
+	}
+}
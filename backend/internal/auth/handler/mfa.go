@@ -0,0 +1,162 @@
+package authHandler
+
+import (
+	"errors"
+	authctx "kanban/internal/auth/context"
+	authModel "kanban/internal/auth/model"
+	authService "kanban/internal/auth/service"
+	"kanban/internal/ratelimit"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) EnrollMFAHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		resp, err := h.service.EnrollMFA(userID)
+		if err != nil {
+			log.Printf("Failed to enroll 2FA: %v", err)
+			h.handleMFAError(ctx, err, "Failed to enroll 2FA")
+			return
+		}
+
+		ctx.JSON(http.StatusOK, resp)
+	}
+}
+
+func (h *Handler) ConfirmMFAHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req authModel.MFAConfirmRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid request body",
+			})
+			return
+		}
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		resp, err := h.service.ConfirmMFA(userID, req)
+		if err != nil {
+			log.Printf("Failed to confirm 2FA: %v", err)
+			h.handleMFAError(ctx, err, "Failed to confirm 2FA")
+			return
+		}
+
+		ctx.JSON(http.StatusOK, resp)
+	}
+}
+
+func (h *Handler) DisableMFAHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req authModel.MFADisableRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid request body",
+			})
+			return
+		}
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		err := h.service.DisableMFA(userID, req)
+		if err != nil {
+			log.Printf("Failed to disable 2FA: %v", err)
+			h.handleMFAError(ctx, err, "Failed to disable 2FA")
+			return
+		}
+
+		ctx.Status(http.StatusOK)
+	}
+}
+
+func (h *Handler) LoginMFAHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req authModel.MFALoginRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid request body",
+			})
+			return
+		}
+
+		resp, err := h.service.LoginMFA(req, ctx.ClientIP())
+		if err != nil {
+			log.Printf("Failed to login with 2FA: %v", err)
+			var limited *ratelimit.LimitedError
+			switch {
+			case errors.As(err, &limited):
+				abortLimited(ctx, limited)
+			case errors.Is(err, authService.ErrInvalidMFAToken):
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+					"detail": "Invalid or expired MFA token",
+				})
+			case errors.Is(err, authService.ErrInvalidMFACode):
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+					"detail": "Invalid code",
+				})
+			default:
+				ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+					"detail": "Failed to login",
+				})
+			}
+			return
+		}
+
+		ctx.JSON(http.StatusOK, resp)
+	}
+}
+
+func (h *Handler) handleMFAError(ctx *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, authService.ErrUserNotFound):
+		ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+			"detail": "User not found",
+		})
+	case errors.Is(err, authService.ErrIncorrectPassword):
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"detail": "Password is incorrect",
+		})
+	case errors.Is(err, authService.ErrInvalidMFACode):
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"detail": "Invalid code",
+		})
+	case errors.Is(err, authService.ErrMFAAlreadyEnabled):
+		ctx.AbortWithStatusJSON(http.StatusConflict, gin.H{
+			"detail": "2FA is already enabled",
+		})
+	case errors.Is(err, authService.ErrMFANotEnabled):
+		ctx.AbortWithStatusJSON(http.StatusConflict, gin.H{
+			"detail": "2FA is not enabled",
+		})
+	case errors.Is(err, authService.ErrMFANotEnrolled):
+		ctx.AbortWithStatusJSON(http.StatusConflict, gin.H{
+			"detail": "2FA enrolment is not started",
+		})
+	default:
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"detail": message,
+		})
+	}
+}
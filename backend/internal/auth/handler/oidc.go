@@ -0,0 +1,90 @@
+package authHandler
+
+import (
+	"errors"
+	authModel "kanban/internal/auth/model"
+	authService "kanban/internal/auth/service"
+	"kanban/internal/config"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const oidcStateCookie = "oidc_state"
+
+func (h *Handler) OIDCLoginHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start, err := h.service.StartOIDCLogin()
+		if err != nil {
+			log.Printf("Failed to start OIDC login: %v", err)
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"detail": "Failed to start login",
+			})
+			return
+		}
+
+		setOIDCStateCookie(ctx, start.StateToken, int(authService.OIDCStateTTL.Seconds()))
+		ctx.Redirect(http.StatusFound, start.URL)
+	}
+}
+
+// OIDCCallbackHandler finishes the login and sends the browser back to the
+// frontend with the token, or an error code, in the URL fragment.
+func (h *Handler) OIDCCallbackHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		stateToken, _ := ctx.Cookie(oidcStateCookie)
+		setOIDCStateCookie(ctx, "", -1)
+
+		if providerErr := ctx.Query("error"); providerErr != "" {
+			log.Printf("OIDC login rejected by provider: %s", providerErr)
+			redirectToFrontend(ctx, url.Values{"error": {"access_denied"}})
+			return
+		}
+
+		resp, err := h.service.FinishOIDCLogin(authModel.OIDCCallback{
+			Code:       ctx.Query("code"),
+			State:      ctx.Query("state"),
+			StateToken: stateToken,
+		})
+		if err != nil {
+			log.Printf("Failed to finish OIDC login: %v", err)
+			var code string
+			switch {
+			case errors.Is(err, authService.ErrInvalidOIDCState):
+				code = "invalid_state"
+			case errors.Is(err, authService.ErrOIDCEmailNotVerified):
+				code = "email_not_verified"
+			case errors.Is(err, authService.ErrOIDCDomainNotAllowed):
+				code = "domain_not_allowed"
+			case errors.Is(err, authService.ErrOIDCAccountNotLinkable):
+				code = "account_not_linkable"
+			default:
+				code = "login_failed"
+			}
+			redirectToFrontend(ctx, url.Values{"error": {code}})
+			return
+		}
+
+		fragment := url.Values{}
+		if resp.MFARequired {
+			fragment.Set("mfa_token", resp.MFAToken)
+		} else {
+			fragment.Set("token", resp.Token)
+		}
+		redirectToFrontend(ctx, fragment)
+	}
+}
+
+func setOIDCStateCookie(ctx *gin.Context, value string, maxAge int) {
+	secure := strings.HasPrefix(config.Get().AppURL, "https://")
+	// Lax lets the cookie through on the top-level redirect back from the provider.
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	ctx.SetCookie(oidcStateCookie, value, maxAge, "/", "", secure, true)
+}
+
+func redirectToFrontend(ctx *gin.Context, fragment url.Values) {
+	ctx.Redirect(http.StatusFound, config.Get().AppURL+"/oidc/callback#"+fragment.Encode())
+}
@@ -2,14 +2,30 @@ package authMiddleware
 
 import (
 	authctx "kanban/internal/auth/context"
+	authModel "kanban/internal/auth/model"
 	authService "kanban/internal/auth/service"
+	"kanban/internal/config"
+	"kanban/internal/utils"
+	"log"
 	"net/http"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
-func Middleware() gin.HandlerFunc {
+type Store interface {
+	GetSession(userID string) (*authModel.Session, error)
+	GetAccessToken(tokenHash string) (*authModel.AccessToken, error)
+	TouchAccessToken(tokenID string, usedAt time.Time) error
+	GetBoardIDByColumn(columnID string) (*string, error)
+	GetBoardIDByTask(taskID string) (*string, error)
+}
+
+// Middleware authenticates requests carrying either a session JWT or a
+// personal access token, whose scopes are enforced here.
+func Middleware(store Store) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authHeader := ctx.GetHeader("Authorization")
 		if authHeader == "" {
@@ -26,17 +42,160 @@ func Middleware() gin.HandlerFunc {
 			})
 			return
 		}
-		
-		claims, err := authService.ValidateJWT(parts[1])
+
+		var userID string
+		var issuedAt *time.Time
+		if strings.HasPrefix(parts[1], authModel.AccessTokenPrefix) {
+			token, ok := checkAccessToken(ctx, store, parts[1])
+			if !ok {
+				return
+			}
+			userID = token.UserID
+			authctx.SetAccessTokenID(ctx, token.ID)
+		} else {
+			claims, err := authService.ValidateJWT(parts[1])
+			if err != nil {
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+					"detail": "Invalid or expire token",
+				})
+				return
+			}
+			userID = claims.UserID
+
+			// Tokens issued before "iat" was added are treated as issued at the epoch.
+			iat := time.Unix(0, 0)
+			if claims.IssuedAt != nil {
+				iat = claims.IssuedAt.Time
+			}
+			issuedAt = &iat
+		}
+
+		session, err := store.GetSession(userID)
 		if err != nil {
+			log.Printf("Failed to check token revocation: %v", err)
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 				"detail": "Invalid or expire token",
 			})
 			return
 		}
 
-		authctx.SetUserID(ctx, claims.UserID)
+		// Access tokens are revoked one by one, not with the user's sessions.
+		if issuedAt != nil && issuedAt.Before(session.TokensValidAfter) {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "Token has been revoked",
+			})
+			return
+		}
+
+		if !session.EmailVerified && config.Get().UnverifiedAccess == config.UnverifiedAccessNone {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"detail": "Email not verified",
+			})
+			return
+		}
+
+		authctx.SetUserID(ctx, userID)
+		authctx.SetEmailVerified(ctx, session.EmailVerified)
+		if issuedAt != nil {
+			authctx.SetIssuedAt(ctx, *issuedAt)
+		}
+
+		ctx.Next()
+	}
+}
+
+// SessionOnly rejects requests authenticated by a personal access token.
+// It guards account management, so a leaked token cannot take over the account.
+func SessionOnly() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if _, ok := authctx.GetAccessTokenID(ctx); ok {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"detail": "Not allowed with an access token",
+			})
+			return
+		}
 
 		ctx.Next()
 	}
 }
+
+// checkAccessToken looks the token up and checks its expiry, scopes and
+// board. It aborts the request and returns false when the token does not
+// allow it.
+func checkAccessToken(ctx *gin.Context, store Store, raw string) (*authModel.AccessToken, bool) {
+	token, err := store.GetAccessToken(utils.HashToken(raw))
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"detail": "Invalid or expire token",
+		})
+		return nil, false
+	}
+
+	now := utils.GenerateTimestamp()
+	if token.ExpiresAt != nil && !token.ExpiresAt.After(now) {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"detail": "Invalid or expire token",
+		})
+		return nil, false
+	}
+
+	if !allowsMethod(token.Scopes, ctx.Request.Method) {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"detail": "Token scope does not allow this request",
+		})
+		return nil, false
+	}
+
+	if token.BoardID != nil {
+		boardID, ok := requestBoard(ctx, store)
+		if !ok || boardID != *token.BoardID {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"detail": "Token is limited to a single board",
+			})
+			return nil, false
+		}
+	}
+
+	if err = store.TouchAccessToken(token.ID, now); err != nil {
+		log.Printf("Failed to record access token use: %v", err)
+	}
+
+	return token, true
+}
+
+func allowsMethod(scopes []string, method string) bool {
+	if slices.Contains(scopes, authModel.ScopeWrite) {
+		return true
+	}
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return slices.Contains(scopes, authModel.ScopeRead)
+	default:
+		return false
+	}
+}
+
+// requestBoard resolves the board a request addresses from its route.
+// Routes that are not about a single board resolve to nothing.
+func requestBoard(ctx *gin.Context, store Store) (string, bool) {
+	route := ctx.FullPath()
+	id := ctx.Param("id")
+
+	var boardID *string
+	var err error
+	switch {
+	case strings.HasPrefix(route, "/boards/:id"):
+		return id, true
+	case strings.HasPrefix(route, "/columns/:id"):
+		boardID, err = store.GetBoardIDByColumn(id)
+	case strings.HasPrefix(route, "/tasks/:id"):
+		boardID, err = store.GetBoardIDByTask(id)
+	default:
+		return "", false
+	}
+	if err != nil {
+		return "", false
+	}
+	return *boardID, true
+}
@@ -1,18 +1,50 @@
 package authModel
 
-import "github.com/golang-jwt/jwt/v5"
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
 
 type User struct {
 	ID       string `json:"id"`
 	Email    string `json:"email"`
 	Username string	`json:"username"`
-	Password string `json:"password"`
+	Password string `json:"-"`
+
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+}
+
+// AccessTokenPrefix marks personal access tokens, so the middleware can tell
+// them from JWTs without trying to parse them.
+const AccessTokenPrefix = "kb_pat_"
+
+// Scopes of personal access tokens. Write implies read.
+const (
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+)
+
+// AccessToken is a personal access token as seen by the middleware.
+// BoardID limits the token to a single board when set.
+type AccessToken struct {
+	ID        string
+	UserID    string
+	Scopes    []string
+	BoardID   *string
+	ExpiresAt *time.Time
+}
+
+type Session struct {
+	TokensValidAfter time.Time
+	EmailVerified    bool
 }
 
 type RegisterRequest struct {
 	Email    string `json:"email"    binding:"required,email"`
 	Username string	`json:"username" binding:"required"`
     Password string `json:"password" binding:"required"`
+	InvitationToken string `json:"invitation_token"`
 }
 
 type LoginRequest struct {
@@ -20,7 +52,108 @@ type LoginRequest struct {
     Password string `json:"password" binding:"required"`
 }
 
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token"    binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password"     binding:"required"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type LoginResponse struct {
+	Token       string `json:"token,omitempty"`
+	MFARequired bool   `json:"mfa_required,omitempty"`
+	MFAToken    string `json:"mfa_token,omitempty"`
+}
+
+// MFA is the TOTP enrolment of a user. EnabledAt stays nil until the
+// enrolment is confirmed with a first code.
+type MFA struct {
+	Secret       string
+	EnabledAt    *time.Time
+	LastUsedStep int64
+}
+
+type MFAEnrollResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
+type MFAConfirmRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type MFAConfirmResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// Code may be either a TOTP code or one of the recovery codes.
+type MFADisableRequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code"     binding:"required"`
+}
+
+type MFALoginRequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code"      binding:"required"`
+}
+
 type Claims struct {
-	UserID string `json:"user_id"`
+	UserID  string `json:"user_id"`
+	Purpose string `json:"purpose,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// VerificationClaims are carried by the signed link sent to confirm an
+// email address. Purpose keeps them from being accepted as access tokens.
+type VerificationClaims struct {
+	UserID  string `json:"user_id"`
+	Email   string `json:"email"`
+	Purpose string `json:"purpose"`
 	jwt.RegisteredClaims
-}
\ No newline at end of file
+}
+
+// InvitationClaims are carried by the link sent with a board invitation.
+// The invitation itself is kept in the database, so it can be revoked.
+type InvitationClaims struct {
+	InvitationID string `json:"invitation_id"`
+	Email        string `json:"email"`
+	Purpose      string `json:"purpose"`
+	jwt.RegisteredClaims
+}
+
+type OIDCStart struct {
+	URL        string
+	StateToken string
+}
+
+type OIDCCallback struct {
+	Code       string
+	State      string
+	StateToken string
+}
+
+// OIDCStateClaims are kept in a cookie between the redirect to the identity
+// provider and its callback. They bind the callback to the browser that
+// started the login.
+type OIDCStateClaims struct {
+	State        string `json:"state"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+	Purpose      string `json:"purpose"`
+	jwt.RegisteredClaims
+}
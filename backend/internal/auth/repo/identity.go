@@ -0,0 +1,106 @@
+package authRepo
+
+import (
+	"fmt"
+	authModel "kanban/internal/auth/model"
+	"kanban/internal/postgres"
+	"kanban/internal/utils"
+	workspaceModel "kanban/internal/workspace/model"
+)
+
+func (r *Repository) GetUserByIdentity(issuer, subject string) (*string, error) {
+	var userID string
+	err := r.db.QueryRow(postgres.QueryGetUserByIdentity, issuer, subject).Scan(&userID)
+	if err != nil {
+		return nil, fmt.Errorf("authRepo.GetUserByIdentity: %w", err)
+	}
+	return &userID, nil
+}
+
+func (r *Repository) CreateIdentity(userID, issuer, subject string) error {
+	_, err := r.db.Exec(postgres.QueryCreateIdentity, issuer, subject, userID, utils.GenerateTimestamp())
+	if err != nil {
+		return fmt.Errorf("authRepo.CreateIdentity: %w", err)
+	}
+	return nil
+}
+
+// CreateExternalUser provisions a user whose email was verified by an
+// external identity provider, with their personal workspace, and links the
+// identity to it.
+func (r *Repository) CreateExternalUser(user authModel.User, issuer, subject string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("authRepo.CreateExternalUser: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := utils.GenerateTimestamp()
+	_, err = tx.Exec(postgres.QueryCreateUser, user.ID, user.Username, user.Email, user.Password, now)
+	if err != nil {
+		return fmt.Errorf("authRepo.CreateExternalUser: %w", err)
+	}
+
+	_, err = tx.Exec(postgres.QueryVerifyUserEmail, now, user.ID, user.Email)
+	if err != nil {
+		return fmt.Errorf("authRepo.CreateExternalUser: %w", err)
+	}
+
+	_, err = tx.Exec(postgres.QueryCreatePersonalWorkspace, utils.NewUUID(), now, user.ID)
+	if err != nil {
+		return fmt.Errorf("authRepo.CreateExternalUser: %w", err)
+	}
+
+	_, err = tx.Exec(postgres.QueryCreateIdentity, issuer, subject, user.ID, now)
+	if err != nil {
+		return fmt.Errorf("authRepo.CreateExternalUser: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("authRepo.CreateExternalUser: %w", err)
+	}
+	return nil
+}
+
+// SetWorkspaceRole makes the user a member of the workspace with the given
+// role. The last owner keeps their role, a workspace is never left without
+// one.
+func (r *Repository) SetWorkspaceRole(workspaceID, userID, role string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("authRepo.SetWorkspaceRole: %w", err)
+	}
+	defer tx.Rollback()
+
+	var current string
+	err = tx.QueryRow(postgres.QueryGetWorkspaceRole, workspaceID, userID).Scan(&current)
+	if err != nil {
+		return fmt.Errorf("authRepo.SetWorkspaceRole: %w", err)
+	}
+
+	switch current {
+	case role:
+		return nil
+	case "":
+		_, err = tx.Exec(postgres.QueryAddWorkspaceMember, workspaceID, userID, role, utils.GenerateTimestamp())
+	case workspaceModel.RoleOwner:
+		var owners int
+		if err = tx.QueryRow(postgres.QueryCountWorkspaceOwners, workspaceID).Scan(&owners); err != nil {
+			return fmt.Errorf("authRepo.SetWorkspaceRole: %w", err)
+		}
+		if owners <= 1 {
+			return nil
+		}
+		_, err = tx.Exec(postgres.QueryUpdateWorkspaceMember, role, workspaceID, userID)
+	default:
+		_, err = tx.Exec(postgres.QueryUpdateWorkspaceMember, role, workspaceID, userID)
+	}
+	if err != nil {
+		return fmt.Errorf("authRepo.SetWorkspaceRole: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("authRepo.SetWorkspaceRole: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package authRepo
+
+import (
+	"database/sql"
+	"fmt"
+	authModel "kanban/internal/auth/model"
+	"kanban/internal/postgres"
+	"kanban/internal/utils"
+)
+
+func (r *Repository) GetMFA(userID string) (*authModel.MFA, error) {
+	var mfa authModel.MFA
+	err := r.db.QueryRow(postgres.QueryGetUserMFA, userID).Scan(
+		&mfa.Secret,
+		&mfa.EnabledAt,
+		&mfa.LastUsedStep,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("authRepo.GetMFA: %w", err)
+	}
+	return &mfa, nil
+}
+
+// CreatePendingMFA starts or restarts an enrolment. It returns
+// sql.ErrNoRows when 2FA is already enabled.
+func (r *Repository) CreatePendingMFA(userID, secret string) error {
+	res, err := r.db.Exec(postgres.QueryCreatePendingMFA, userID, secret, utils.GenerateTimestamp())
+	if err != nil {
+		return fmt.Errorf("authRepo.CreatePendingMFA: %w", err)
+	}
+	return checkAffected("authRepo.CreatePendingMFA", res)
+}
+
+// EnableMFA confirms the enrolment and replaces the user's recovery codes.
+func (r *Repository) EnableMFA(userID string, step int64, codeHashes []string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("authRepo.EnableMFA: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := utils.GenerateTimestamp()
+	res, err := tx.Exec(postgres.QueryEnableMFA, now, step, userID)
+	if err != nil {
+		return fmt.Errorf("authRepo.EnableMFA: %w", err)
+	}
+	if err = checkAffected("authRepo.EnableMFA", res); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(postgres.QueryDeleteRecoveryCodes, userID)
+	if err != nil {
+		return fmt.Errorf("authRepo.EnableMFA: %w", err)
+	}
+	for _, hash := range codeHashes {
+		_, err = tx.Exec(postgres.QueryCreateRecoveryCode, utils.NewUUID(), userID, hash, now)
+		if err != nil {
+			return fmt.Errorf("authRepo.EnableMFA: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("authRepo.EnableMFA: %w", err)
+	}
+	return nil
+}
+
+// UseMFAStep records the time step of an accepted code. It returns
+// sql.ErrNoRows when a code of this or a later step was already used.
+func (r *Repository) UseMFAStep(userID string, step int64) error {
+	res, err := r.db.Exec(postgres.QueryUseMFAStep, step, userID)
+	if err != nil {
+		return fmt.Errorf("authRepo.UseMFAStep: %w", err)
+	}
+	return checkAffected("authRepo.UseMFAStep", res)
+}
+
+// UseRecoveryCode consumes a recovery code. It returns sql.ErrNoRows when
+// the code is unknown or was already used.
+func (r *Repository) UseRecoveryCode(userID, codeHash string) error {
+	res, err := r.db.Exec(postgres.QueryUseRecoveryCode, utils.GenerateTimestamp(), userID, codeHash)
+	if err != nil {
+		return fmt.Errorf("authRepo.UseRecoveryCode: %w", err)
+	}
+	return checkAffected("authRepo.UseRecoveryCode", res)
+}
+
+func (r *Repository) DeleteMFA(userID string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("authRepo.DeleteMFA: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.Exec(postgres.QueryDeleteRecoveryCodes, userID); err != nil {
+		return fmt.Errorf("authRepo.DeleteMFA: %w", err)
+	}
+	if _, err = tx.Exec(postgres.QueryDeleteMFA, userID); err != nil {
+		return fmt.Errorf("authRepo.DeleteMFA: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("authRepo.DeleteMFA: %w", err)
+	}
+	return nil
+}
+
+func checkAffected(op string, res sql.Result) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, sql.ErrNoRows)
+	}
+	return nil
+}
@@ -6,6 +6,9 @@ import (
 	authModel "kanban/internal/auth/model"
 	"kanban/internal/postgres"
 	"kanban/internal/utils"
+	"time"
+
+	"github.com/lib/pq"
 )
 
 type Repository struct {
@@ -16,18 +19,35 @@ func NewRepository(db *sql.DB) *Repository {
 	return &Repository{db: db}
 }
 
+// Create stores the user together with their personal workspace.
 func (r *Repository) Create(user authModel.User) error {
-	_, err := r.db.Exec(
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("authRepo.Create: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := utils.GenerateTimestamp()
+	_, err = tx.Exec(
 		postgres.QueryCreateUser, 
 		user.ID, 
 		user.Username,
 		user.Email,
 		user.Password, 
-		utils.GenerateTimestamp(),
+		now,
 	)
 	if err != nil {
 		return fmt.Errorf("authRepo.Create: %w", err)
 	}
+
+	_, err = tx.Exec(postgres.QueryCreatePersonalWorkspace, utils.NewUUID(), now, user.ID)
+	if err != nil {
+		return fmt.Errorf("authRepo.Create: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("authRepo.Create: %w", err)
+	}
 	return nil
 }
 
@@ -41,9 +61,167 @@ func (r *Repository) GetByEmail(email string) (*authModel.User, error) {
 			&user.Email,
 			&user.Username, 
 			&user.Password,
+			&user.EmailVerifiedAt,
 		)
 	if err != nil {
 		return nil, fmt.Errorf("authRepo.GetByEmail: %w", err)
 	}
 	return &user, nil
-}
\ No newline at end of file
+}
+
+func (r *Repository) GetByID(userID string) (*authModel.User, error) {
+	var user authModel.User
+	err := r.db.QueryRow(postgres.QueryGetUserByID, userID).Scan(
+		&user.ID,
+		&user.Email,
+		&user.Username,
+		&user.Password,
+		&user.EmailVerifiedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("authRepo.GetByID: %w", err)
+	}
+	return &user, nil
+}
+
+func (r *Repository) GetSession(userID string) (*authModel.Session, error) {
+	var session authModel.Session
+	err := r.db.QueryRow(postgres.QueryGetSession, userID).Scan(
+		&session.TokensValidAfter,
+		&session.EmailVerified,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("authRepo.GetSession: %w", err)
+	}
+	return &session, nil
+}
+
+func (r *Repository) VerifyEmail(userID, email string) error {
+	res, err := r.db.Exec(postgres.QueryVerifyUserEmail, utils.GenerateTimestamp(), userID, email)
+	if err != nil {
+		return fmt.Errorf("authRepo.VerifyEmail: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("authRepo.VerifyEmail: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("authRepo.VerifyEmail: %w", sql.ErrNoRows)
+	}
+	return nil
+}
+
+func (r *Repository) CreatePasswordResetToken(userID, tokenHash string, expiresAt time.Time) error {
+	_, err := r.db.Exec(
+		postgres.QueryCreatePasswordResetToken,
+		utils.NewUUID(),
+		userID,
+		tokenHash,
+		utils.GenerateTimestamp(),
+		expiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("authRepo.CreatePasswordResetToken: %w", err)
+	}
+	return nil
+}
+
+// ResetPassword consumes a reset token and sets the new password in one
+// transaction. It returns sql.ErrNoRows when the token is unknown, used or expired.
+func (r *Repository) ResetPassword(tokenHash, passwordHash string, now time.Time) (*string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("authRepo.ResetPassword: %w", err)
+	}
+	defer tx.Rollback()
+
+	var userID string
+	err = tx.QueryRow(postgres.QueryConsumePasswordResetToken, now, tokenHash).Scan(&userID)
+	if err != nil {
+		return nil, fmt.Errorf("authRepo.ResetPassword: %w", err)
+	}
+
+	err = updatePassword(tx, userID, passwordHash, now)
+	if err != nil {
+		return nil, fmt.Errorf("authRepo.ResetPassword: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("authRepo.ResetPassword: %w", err)
+	}
+	return &userID, nil
+}
+
+func (r *Repository) UpdatePassword(userID, passwordHash string, validAfter time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("authRepo.UpdatePassword: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = updatePassword(tx, userID, passwordHash, validAfter)
+	if err != nil {
+		return fmt.Errorf("authRepo.UpdatePassword: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("authRepo.UpdatePassword: %w", err)
+	}
+	return nil
+}
+
+// updatePassword stores the new hash, revokes every token issued before
+// validAfter and drops the user's outstanding reset tokens.
+func updatePassword(tx *sql.Tx, userID, passwordHash string, validAfter time.Time) error {
+	_, err := tx.Exec(postgres.QueryUpdateUserPassword, passwordHash, validAfter, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(postgres.QueryDeletePasswordResetTokens, userID)
+	return err
+}
+
+func (r *Repository) GetAccessToken(tokenHash string) (*authModel.AccessToken, error) {
+	var token authModel.AccessToken
+	var scopes pq.StringArray
+	err := r.db.QueryRow(postgres.QueryGetAccessTokenByHash, tokenHash).Scan(
+		&token.ID,
+		&token.UserID,
+		&scopes,
+		&token.BoardID,
+		&token.ExpiresAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("authRepo.GetAccessToken: %w", err)
+	}
+	token.Scopes = scopes
+	return &token, nil
+}
+
+func (r *Repository) TouchAccessToken(tokenID string, usedAt time.Time) error {
+	_, err := r.db.Exec(postgres.QueryTouchAccessToken, usedAt, tokenID)
+	if err != nil {
+		return fmt.Errorf("authRepo.TouchAccessToken: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) GetBoardIDByColumn(columnID string) (*string, error) {
+	var boardID string
+	err := r.db.QueryRow(postgres.QueryGetBoardIDByColumnID, columnID).Scan(&boardID)
+	if err != nil {
+		return nil, fmt.Errorf("authRepo.GetBoardIDByColumn: %w", err)
+	}
+	return &boardID, nil
+}
+
+func (r *Repository) GetBoardIDByTask(taskID string) (*string, error) {
+	var boardID string
+	err := r.db.QueryRow(postgres.QueryGetBoardIDByTaskID, taskID).Scan(&boardID)
+	if err != nil {
+		return nil, fmt.Errorf("authRepo.GetBoardIDByTask: %w", err)
+	}
+	return &boardID, nil
+}
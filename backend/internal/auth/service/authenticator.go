@@ -0,0 +1,69 @@
+package authService
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+var ErrAuthUnavailable = errors.New("authentication backend is unavailable")
+
+// Authenticator checks the password of a login against a user store and
+// returns the ID of the local user it belongs to. Unknown logins and wrong
+// passwords are reported as ErrInvalidCredentials.
+type Authenticator interface {
+	Authenticate(email, password string) (*string, error)
+}
+
+// PasswordAuthenticator checks the bcrypt password hashes of local users.
+type PasswordAuthenticator struct {
+	repo Repository
+}
+
+func NewPasswordAuthenticator(repo Repository) *PasswordAuthenticator {
+	return &PasswordAuthenticator{repo: repo}
+}
+
+// Authenticate compares against a dummy hash for unknown emails, so they
+// take as long to check as wrong passwords.
+func (a *PasswordAuthenticator) Authenticate(email, password string) (*string, error) {
+	user, err := a.repo.GetByEmail(email)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("authService.PasswordAuthenticator.Authenticate: %w", err)
+	}
+
+	hash := dummyPasswordHash
+	if user != nil {
+		hash = user.Password
+	}
+	if checkPasswordHash(password, hash) != nil || user == nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	return &user.ID, nil
+}
+
+// authenticate returns the user accepted by the first authenticator that
+// knows the credentials. A directory user outside the allowed groups is
+// refused right away, a local password must not let them in. Other
+// failures, such as an unreachable directory, do not stop the later
+// authenticators but are reported as ErrAuthUnavailable if none accepts.
+func (s *Service) authenticate(email, password string) (*string, error) {
+	var errs []error
+	for _, authenticator := range s.authenticators {
+		userID, err := authenticator.Authenticate(email, password)
+		switch {
+		case err == nil:
+			return userID, nil
+		case errors.Is(err, ErrLDAPGroupNotAllowed):
+			return nil, err
+		case !errors.Is(err, ErrInvalidCredentials):
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("%w: %w", ErrAuthUnavailable, errors.Join(errs...))
+	}
+	return nil, ErrInvalidCredentials
+}
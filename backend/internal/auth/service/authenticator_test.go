@@ -0,0 +1,306 @@
+package authService
+
+import (
+	"database/sql"
+	"errors"
+	authModel "kanban/internal/auth/model"
+	"kanban/internal/ldap"
+	"testing"
+	"time"
+)
+
+var errDirectoryDown = errors.New("directory is down")
+
+type stubAuthenticator struct {
+	userID string
+	err    error
+	calls  int
+}
+
+func (a *stubAuthenticator) Authenticate(email, password string) (*string, error) {
+	a.calls++
+	if a.err != nil {
+		return nil, a.err
+	}
+	return &a.userID, nil
+}
+
+type stubLimiter struct {
+	fails int
+}
+
+func (l *stubLimiter) Allow(key string) error { return nil }
+func (l *stubLimiter) Fail(key string) error  { l.fails++; return nil }
+func (l *stubLimiter) Reset(key string) error { return nil }
+
+func TestAuthenticate(t *testing.T) {
+	accept := func(userID string) *stubAuthenticator { return &stubAuthenticator{userID: userID} }
+	reject := func(err error) *stubAuthenticator { return &stubAuthenticator{err: err} }
+
+	tests := []struct {
+		name           string
+		authenticators []*stubAuthenticator
+		wantUser       string
+		wantErr        error
+		wantCalls      []int
+	}{
+		{
+			name:           "first accepts",
+			authenticators: []*stubAuthenticator{accept("ldap"), accept("local")},
+			wantUser:       "ldap",
+			wantCalls:      []int{1, 0},
+		},
+		{
+			name:           "unknown to the directory",
+			authenticators: []*stubAuthenticator{reject(ErrInvalidCredentials), accept("local")},
+			wantUser:       "local",
+			wantCalls:      []int{1, 1},
+		},
+		{
+			name:           "directory down, local password matches",
+			authenticators: []*stubAuthenticator{reject(errDirectoryDown), accept("local")},
+			wantUser:       "local",
+			wantCalls:      []int{1, 1},
+		},
+		{
+			name:           "directory down, local password wrong",
+			authenticators: []*stubAuthenticator{reject(errDirectoryDown), reject(ErrInvalidCredentials)},
+			wantErr:        ErrAuthUnavailable,
+			wantCalls:      []int{1, 1},
+		},
+		{
+			name:           "nobody knows the credentials",
+			authenticators: []*stubAuthenticator{reject(ErrInvalidCredentials), reject(ErrInvalidCredentials)},
+			wantErr:        ErrInvalidCredentials,
+			wantCalls:      []int{1, 1},
+		},
+		{
+			name:           "group not allowed stops the chain",
+			authenticators: []*stubAuthenticator{reject(ErrLDAPGroupNotAllowed), accept("local")},
+			wantErr:        ErrLDAPGroupNotAllowed,
+			wantCalls:      []int{1, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{}
+			for _, a := range tt.authenticators {
+				s.authenticators = append(s.authenticators, a)
+			}
+
+			userID, err := s.authenticate("alice@example.org", "alice")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error: got %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil || *userID != tt.wantUser {
+				t.Fatalf("got %v, %v, want %s", userID, err, tt.wantUser)
+			}
+
+			for i, a := range tt.authenticators {
+				if a.calls != tt.wantCalls[i] {
+					t.Errorf("authenticator %d calls: got %d, want %d", i, a.calls, tt.wantCalls[i])
+				}
+			}
+		})
+	}
+}
+
+// Every failed login counts against both limits, whatever the reason.
+func TestLoginUserCountsFailures(t *testing.T) {
+	for _, err := range []error{ErrInvalidCredentials, errDirectoryDown, ErrLDAPGroupNotAllowed} {
+		t.Run(err.Error(), func(t *testing.T) {
+			ipLimiter, accountLimiter := &stubLimiter{}, &stubLimiter{}
+			s := &Service{
+				ipLimiter:      ipLimiter,
+				accountLimiter: accountLimiter,
+				authenticators: []Authenticator{&stubAuthenticator{err: err}},
+			}
+
+			_, loginErr := s.LoginUser(authModel.LoginRequest{Email: "alice@example.org", Password: "alice"}, "127.0.0.1")
+			if loginErr == nil {
+				t.Fatal("login succeeded")
+			}
+			if ipLimiter.fails != 1 || accountLimiter.fails != 1 {
+				t.Errorf("fails: got ip %d, account %d, want 1 each", ipLimiter.fails, accountLimiter.fails)
+			}
+		})
+	}
+}
+
+type stubDirectory struct {
+	entry *ldap.Entry
+	err   error
+}
+
+func (d *stubDirectory) URL() string { return "ldap://directory" }
+
+func (d *stubDirectory) Authenticate(login, password string) (*ldap.Entry, error) {
+	return d.entry, d.err
+}
+
+// stubRepo implements the lookups of directory users, the rest of
+// Repository is left nil.
+type stubRepo struct {
+	Repository
+	identities map[string]string
+	users      map[string]*authModel.User
+	created    []authModel.User
+	roles      map[string]string
+}
+
+func (r *stubRepo) GetUserByIdentity(issuer, subject string) (*string, error) {
+	userID, ok := r.identities[subject]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &userID, nil
+}
+
+func (r *stubRepo) GetByEmail(email string) (*authModel.User, error) {
+	user, ok := r.users[email]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return user, nil
+}
+
+func (r *stubRepo) CreateIdentity(userID, issuer, subject string) error {
+	r.identities[subject] = userID
+	return nil
+}
+
+func (r *stubRepo) CreateExternalUser(user authModel.User, issuer, subject string) error {
+	r.created = append(r.created, user)
+	r.identities[subject] = user.ID
+	return nil
+}
+
+func (r *stubRepo) SetWorkspaceRole(workspaceID, userID, role string) error {
+	r.roles[workspaceID+"/"+userID] = role
+	return nil
+}
+
+func TestLDAPAuthenticator(t *testing.T) {
+	groupRoles := map[string]string{
+		"cn=kanban-owners,dc=example,dc=org": "owner",
+		"cn=kanban-admins,dc=example,dc=org": "admin",
+		"cn=kanban-users,dc=example,dc=org":  "member",
+	}
+
+	verified := time.Now()
+	tests := []struct {
+		name         string
+		requireGroup bool
+		entry        *ldap.Entry
+		directoryErr error
+		users        map[string]*authModel.User
+		identities   map[string]string
+		wantUser     string
+		wantCreated  bool
+		wantRole     string
+		wantErr      error
+	}{
+		{
+			name:         "wrong password",
+			directoryErr: ldap.ErrInvalidCredentials,
+			wantErr:      ErrInvalidCredentials,
+		},
+		{
+			name:       "linked entry",
+			entry:      &ldap.Entry{ID: "a", Email: "alice@example.org"},
+			identities: map[string]string{"a": "alice"},
+			wantUser:   "alice",
+		},
+		{
+			name:     "verified local account is linked",
+			entry:    &ldap.Entry{ID: "a", Email: "alice@example.org"},
+			users:    map[string]*authModel.User{"alice@example.org": {ID: "alice", EmailVerifiedAt: &verified}},
+			wantUser: "alice",
+		},
+		{
+			name:    "unverified local account",
+			entry:   &ldap.Entry{ID: "a", Email: "alice@example.org"},
+			users:   map[string]*authModel.User{"alice@example.org": {ID: "alice"}},
+			wantErr: ErrLDAPAccountNotLinkable,
+		},
+		{
+			name:        "provisioned on first login",
+			entry:       &ldap.Entry{ID: "a", Email: "alice@example.org"},
+			wantCreated: true,
+		},
+		{
+			name:       "group mapped to a role",
+			entry:      &ldap.Entry{ID: "a", Email: "alice@example.org", Groups: []string{"CN=Kanban-Users,DC=example,DC=org"}},
+			identities: map[string]string{"a": "alice"},
+			wantUser:   "alice",
+			wantRole:   "member",
+		},
+		{
+			name: "most privileged group wins",
+			entry: &ldap.Entry{ID: "a", Email: "alice@example.org", Groups: []string{
+				"cn=kanban-users,dc=example,dc=org", "cn=kanban-admins,dc=example,dc=org", "cn=other,dc=example,dc=org",
+			}},
+			identities: map[string]string{"a": "alice"},
+			wantUser:   "alice",
+			wantRole:   "admin",
+		},
+		{
+			name:        "role granted on first login",
+			entry:       &ldap.Entry{ID: "a", Email: "alice@example.org", Groups: []string{"cn=kanban-owners,dc=example,dc=org"}},
+			wantCreated: true,
+			wantRole:    "owner",
+		},
+		{
+			name:       "unmapped groups keep the role",
+			entry:      &ldap.Entry{ID: "a", Email: "alice@example.org", Groups: []string{"cn=other,dc=example,dc=org"}},
+			identities: map[string]string{"a": "alice"},
+			wantUser:   "alice",
+		},
+		{
+			name:         "group required",
+			requireGroup: true,
+			entry:        &ldap.Entry{ID: "a", Email: "alice@example.org", Groups: []string{"cn=other,dc=example,dc=org"}},
+			identities:   map[string]string{"a": "alice"},
+			wantErr:      ErrLDAPGroupNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubRepo{identities: map[string]string{}, users: map[string]*authModel.User{}, roles: map[string]string{}}
+			for k, v := range tt.identities {
+				repo.identities[k] = v
+			}
+			for k, v := range tt.users {
+				repo.users[k] = v
+			}
+			a := NewLDAPAuthenticator(repo, &stubDirectory{entry: tt.entry, err: tt.directoryErr}, "team", groupRoles, tt.requireGroup)
+
+			userID, err := a.Authenticate("alice@example.org", "alice")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error: got %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("authenticate: %v", err)
+			}
+
+			if tt.wantCreated {
+				if len(repo.created) != 1 || repo.created[0].ID != *userID || repo.created[0].Username != "alice" {
+					t.Errorf("provisioned users: got %+v for %s", repo.created, *userID)
+				}
+			} else if *userID != tt.wantUser {
+				t.Errorf("user: got %s, want %s", *userID, tt.wantUser)
+			}
+
+			role, ok := repo.roles["team/"+*userID]
+			if role != tt.wantRole || ok != (tt.wantRole != "") {
+				t.Errorf("workspace role: got %q, want %q", role, tt.wantRole)
+			}
+		})
+	}
+}
@@ -4,29 +4,176 @@ import (
 	"errors"
 	authModel "kanban/internal/auth/model"
 	"kanban/internal/config"
+	"kanban/internal/jwk"
+	"kanban/internal/signing"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+const (
+	sessionTTL = 24 * time.Hour
+
+	purposeVerifyEmail = "verify_email"
+	verificationTTL    = 48 * time.Hour
+
+	purposeMFAPending = "mfa_pending"
+	mfaPendingTTL     = 5 * time.Minute
+
+	purposeOIDCState = "oidc_state"
+	OIDCStateTTL     = 10 * time.Minute
+
+	// Invitation links must not outlive the key that signed them.
+	purposeBoardInvitation = "board_invitation"
+	InvitationTTL          = signing.Retention
+)
+
+// leeway tolerates clock drift between us and services verifying our tokens.
+const leeway = 30 * time.Second
+
 func GenerateJWT(userID string) (string, error) {
 	claims := &authModel.Claims{
-		UserID: userID,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
-		},
+		UserID:           userID,
+		RegisteredClaims: registeredClaims(userID, sessionTTL),
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(config.Get().JWTSecret)
-} 
+	return signToken(claims)
+}
 
 func ValidateJWT(tokenStr string) (*authModel.Claims, error) {
 	claims := &authModel.Claims{}
-	token, err := jwt.ParseWithClaims(tokenStr, claims, func(t *jwt.Token) (interface{}, error) {
-		return config.Get().JWTSecret, nil
-	})
-	if err != nil || !token.Valid {
+	if err := parseToken(tokenStr, claims); err != nil || claims.Purpose != "" {
 		return nil, errors.New("invalid token")
 	}
 	return claims, nil
-}
\ No newline at end of file
+}
+
+func GenerateVerificationToken(userID, email string) (string, error) {
+	claims := &authModel.VerificationClaims{
+		UserID:           userID,
+		Email:            email,
+		Purpose:          purposeVerifyEmail,
+		RegisteredClaims: registeredClaims(userID, verificationTTL),
+	}
+	return signToken(claims)
+}
+
+func ValidateVerificationToken(tokenStr string) (*authModel.VerificationClaims, error) {
+	claims := &authModel.VerificationClaims{}
+	if err := parseToken(tokenStr, claims); err != nil || claims.Purpose != purposeVerifyEmail {
+		return nil, errors.New("invalid verification token")
+	}
+	return claims, nil
+}
+
+// GenerateMFAToken issues the short-lived token returned by a password login
+// of a user with 2FA. It is only accepted by the second login step.
+func GenerateMFAToken(userID string) (string, error) {
+	claims := &authModel.Claims{
+		UserID:           userID,
+		Purpose:          purposeMFAPending,
+		RegisteredClaims: registeredClaims(userID, mfaPendingTTL),
+	}
+	return signToken(claims)
+}
+
+func ValidateMFAToken(tokenStr string) (*authModel.Claims, error) {
+	claims := &authModel.Claims{}
+	if err := parseToken(tokenStr, claims); err != nil || claims.Purpose != purposeMFAPending {
+		return nil, errors.New("invalid mfa token")
+	}
+	return claims, nil
+}
+
+func GenerateOIDCStateToken(state, nonce, codeVerifier string) (string, error) {
+	claims := &authModel.OIDCStateClaims{
+		State:            state,
+		Nonce:            nonce,
+		CodeVerifier:     codeVerifier,
+		Purpose:          purposeOIDCState,
+		RegisteredClaims: registeredClaims("", OIDCStateTTL),
+	}
+	return signToken(claims)
+}
+
+func ValidateOIDCStateToken(tokenStr string) (*authModel.OIDCStateClaims, error) {
+	claims := &authModel.OIDCStateClaims{}
+	if err := parseToken(tokenStr, claims); err != nil || claims.Purpose != purposeOIDCState {
+		return nil, errors.New("invalid oidc state token")
+	}
+	return claims, nil
+}
+
+func GenerateInvitationToken(invitationID, email string) (string, error) {
+	claims := &authModel.InvitationClaims{
+		InvitationID:     invitationID,
+		Email:            email,
+		Purpose:          purposeBoardInvitation,
+		RegisteredClaims: registeredClaims("", InvitationTTL),
+	}
+	return signToken(claims)
+}
+
+func ValidateInvitationToken(tokenStr string) (*authModel.InvitationClaims, error) {
+	claims := &authModel.InvitationClaims{}
+	if err := parseToken(tokenStr, claims); err != nil || claims.Purpose != purposeBoardInvitation {
+		return nil, errors.New("invalid invitation token")
+	}
+	return claims, nil
+}
+
+func registeredClaims(subject string, ttl time.Duration) jwt.RegisteredClaims {
+	cfg := config.Get()
+	now := time.Now()
+	return jwt.RegisteredClaims{
+		Issuer:    cfg.JWTIssuer,
+		Subject:   subject,
+		Audience:  jwt.ClaimStrings{cfg.JWTAudience},
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+	}
+}
+
+// signToken signs the claims with the current key of the keyring and names
+// the key in the "kid" header.
+func signToken(claims jwt.Claims) (string, error) {
+	key, err := signing.Get().Current()
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(key.Algorithm), claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.Private)
+}
+
+// parseToken verifies the signature with the key named by "kid" and
+// requires our issuer and audience along with exp, iat and nbf.
+func parseToken(tokenStr string, claims jwt.Claims) error {
+	cfg := config.Get()
+	token, err := jwt.ParseWithClaims(
+		tokenStr,
+		claims,
+		signing.Get().Keyfunc,
+		jwt.WithValidMethods(cfg.JWTAllowedAlgorithms),
+		jwt.WithIssuer(cfg.JWTIssuer),
+		jwt.WithAudience(cfg.JWTAudience),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(leeway),
+	)
+	if err != nil || !token.Valid {
+		return errors.New("invalid token")
+	}
+
+	nbf, err := claims.GetNotBefore()
+	if err != nil || nbf == nil {
+		return errors.New("token has no nbf claim")
+	}
+	return nil
+}
+
+// JWKS returns the public keys other services verify our tokens with.
+func (s *Service) JWKS() (jwk.Set, error) {
+	return signing.Get().JWKS()
+}
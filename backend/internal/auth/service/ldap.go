@@ -0,0 +1,144 @@
+package authService
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	authModel "kanban/internal/auth/model"
+	"kanban/internal/ldap"
+	"kanban/internal/utils"
+	workspaceModel "kanban/internal/workspace/model"
+	"strings"
+)
+
+var ErrLDAPGroupNotAllowed = errors.New("directory user is not in an allowed group")
+var ErrLDAPAccountNotLinkable = errors.New("account with this email is not verified")
+
+type Directory interface {
+	URL() string
+	Authenticate(login, password string) (*ldap.Entry, error)
+}
+
+// LDAPAuthenticator logs users in with their directory password. Directory
+// users are provisioned on their first login and get the workspace role of
+// their groups on every login.
+type LDAPAuthenticator struct {
+	repo         Repository
+	directory    Directory
+	workspaceID  string
+	groupRoles   map[string]string
+	requireGroup bool
+}
+
+// NewLDAPAuthenticator takes workspace roles keyed by lowercased group DN,
+// granted in the given workspace. With requireGroup only members of those
+// groups may log in, others keep whatever role they had.
+func NewLDAPAuthenticator(repo Repository, directory Directory, workspaceID string, groupRoles map[string]string, requireGroup bool) *LDAPAuthenticator {
+	return &LDAPAuthenticator{
+		repo:         repo,
+		directory:    directory,
+		workspaceID:  workspaceID,
+		groupRoles:   groupRoles,
+		requireGroup: requireGroup,
+	}
+}
+
+func (a *LDAPAuthenticator) Authenticate(email, password string) (*string, error) {
+	entry, err := a.directory.Authenticate(email, password)
+	if errors.Is(err, ldap.ErrInvalidCredentials) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, fmt.Errorf("authService.LDAPAuthenticator.Authenticate: %w", err)
+	}
+
+	role := a.role(entry.Groups)
+	if role == "" && a.requireGroup {
+		return nil, fmt.Errorf("authService.LDAPAuthenticator.Authenticate: %w", ErrLDAPGroupNotAllowed)
+	}
+
+	if entry.Email == "" {
+		entry.Email = email
+	}
+	userID, err := a.resolveUser(entry)
+	if err != nil {
+		return nil, fmt.Errorf("authService.LDAPAuthenticator.Authenticate: %w", err)
+	}
+
+	if role != "" {
+		if err = a.repo.SetWorkspaceRole(a.workspaceID, *userID, role); err != nil {
+			return nil, fmt.Errorf("authService.LDAPAuthenticator.Authenticate: %w", err)
+		}
+	}
+
+	return userID, nil
+}
+
+// role picks the most privileged workspace role granted by the user's
+// groups, empty if none of them is mapped.
+func (a *LDAPAuthenticator) role(groups []string) string {
+	rank := map[string]int{
+		workspaceModel.RoleMember: 1,
+		workspaceModel.RoleAdmin:  2,
+		workspaceModel.RoleOwner:  3,
+	}
+
+	role := ""
+	for _, group := range groups {
+		if granted := a.groupRoles[strings.ToLower(group)]; rank[granted] > rank[role] {
+			role = granted
+		}
+	}
+	return role
+}
+
+// resolveUser finds the user linked to the directory entry. New entries are
+// linked to the user with the same verified email, or provisioned.
+func (a *LDAPAuthenticator) resolveUser(entry *ldap.Entry) (*string, error) {
+	issuer := a.directory.URL()
+
+	userID, err := a.repo.GetUserByIdentity(issuer, entry.ID)
+	if err == nil {
+		return userID, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	user, err := a.repo.GetByEmail(entry.Email)
+	switch {
+	case err == nil:
+		if user.EmailVerifiedAt == nil {
+			return nil, ErrLDAPAccountNotLinkable
+		}
+		if err = a.repo.CreateIdentity(user.ID, issuer, entry.ID); err != nil {
+			return nil, err
+		}
+		return &user.ID, nil
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, err
+	}
+
+	// Directory users log in with the directory password, the local one is
+	// unusable until they reset it.
+	hash, err := hashPassword(utils.NewUUID())
+	if err != nil {
+		return nil, err
+	}
+
+	username := entry.Name
+	if username == "" {
+		username, _, _ = strings.Cut(entry.Email, "@")
+	}
+
+	user = &authModel.User{
+		ID:       utils.NewUUID(),
+		Email:    entry.Email,
+		Username: username,
+		Password: hash,
+	}
+	if err = a.repo.CreateExternalUser(*user, issuer, entry.ID); err != nil {
+		return nil, err
+	}
+	return &user.ID, nil
+}
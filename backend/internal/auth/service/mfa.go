@@ -0,0 +1,267 @@
+package authService
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	authModel "kanban/internal/auth/model"
+	"kanban/internal/totp"
+	"kanban/internal/utils"
+	"strings"
+)
+
+const (
+	mfaIssuer          = "Kanban"
+	recoveryCodeCount  = 10
+	recoveryCodeLength = 10
+)
+
+var ErrMFAAlreadyEnabled = errors.New("2fa is already enabled")
+var ErrMFANotEnabled = errors.New("2fa is not enabled")
+var ErrMFANotEnrolled = errors.New("2fa enrolment is not started")
+var ErrInvalidMFACode = errors.New("invalid 2fa code")
+var ErrInvalidMFAToken = errors.New("invalid or expired mfa token")
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// EnrollMFA generates a new TOTP secret. 2FA is enabled only once the user
+// confirms the enrolment with a code from their authenticator.
+func (s *Service) EnrollMFA(userID string) (*authModel.MFAEnrollResponse, error) {
+	user, err := s.repo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("authService.EnrollMFA: %w", ErrUserNotFound)
+		}
+		return nil, fmt.Errorf("authService.EnrollMFA: %w", err)
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("authService.EnrollMFA: %w", err)
+	}
+
+	err = s.repo.CreatePendingMFA(userID, secret)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("authService.EnrollMFA: %w", ErrMFAAlreadyEnabled)
+		}
+		return nil, fmt.Errorf("authService.EnrollMFA: %w", err)
+	}
+
+	return &authModel.MFAEnrollResponse{
+		Secret: secret,
+		URI:    totp.URI(mfaIssuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmMFA enables 2FA and returns the recovery codes. They are stored
+// hashed, so this is the only time the user sees them.
+func (s *Service) ConfirmMFA(userID string, req authModel.MFAConfirmRequest) (*authModel.MFAConfirmResponse, error) {
+	mfa, err := s.repo.GetMFA(userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("authService.ConfirmMFA: %w", ErrMFANotEnrolled)
+		}
+		return nil, fmt.Errorf("authService.ConfirmMFA: %w", err)
+	}
+	if mfa.EnabledAt != nil {
+		return nil, fmt.Errorf("authService.ConfirmMFA: %w", ErrMFAAlreadyEnabled)
+	}
+
+	step, ok := totp.Validate(mfa.Secret, normalizeCode(req.Code), utils.GenerateTimestamp())
+	if !ok {
+		return nil, fmt.Errorf("authService.ConfirmMFA: %w", ErrInvalidMFACode)
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, fmt.Errorf("authService.ConfirmMFA: %w", err)
+		}
+		codes[i] = code
+		hashes[i] = utils.HashToken(normalizeCode(code))
+	}
+
+	err = s.repo.EnableMFA(userID, step, hashes)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("authService.ConfirmMFA: %w", ErrMFAAlreadyEnabled)
+		}
+		return nil, fmt.Errorf("authService.ConfirmMFA: %w", err)
+	}
+
+	return &authModel.MFAConfirmResponse{RecoveryCodes: codes}, nil
+}
+
+func (s *Service) DisableMFA(userID string, req authModel.MFADisableRequest) error {
+	user, err := s.repo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("authService.DisableMFA: %w", ErrUserNotFound)
+		}
+		return fmt.Errorf("authService.DisableMFA: %w", err)
+	}
+
+	err = checkPasswordHash(req.Password, user.Password)
+	if err != nil {
+		return fmt.Errorf("authService.DisableMFA: %w", ErrIncorrectPassword)
+	}
+
+	mfa, err := s.getEnabledMFA(userID)
+	if err != nil {
+		return fmt.Errorf("authService.DisableMFA: %w", err)
+	}
+
+	err = s.checkMFACode(userID, mfa, req.Code)
+	if err != nil {
+		return fmt.Errorf("authService.DisableMFA: %w", err)
+	}
+
+	err = s.repo.DeleteMFA(userID)
+	if err != nil {
+		return fmt.Errorf("authService.DisableMFA: %w", err)
+	}
+
+	return nil
+}
+
+// LoginMFA exchanges the MFA token of a password login and a TOTP or
+// recovery code for a session token. Attempts are throttled like logins.
+func (s *Service) LoginMFA(req authModel.MFALoginRequest, clientIP string) (*authModel.LoginResponse, error) {
+	claims, err := ValidateMFAToken(req.MFAToken)
+	if err != nil {
+		return nil, fmt.Errorf("authService.LoginMFA: %w", ErrInvalidMFAToken)
+	}
+
+	// A password change after the first step invalidates the MFA token too.
+	session, err := s.repo.GetSession(claims.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("authService.LoginMFA: %w", ErrInvalidMFAToken)
+		}
+		return nil, fmt.Errorf("authService.LoginMFA: %w", err)
+	}
+	if claims.IssuedAt == nil || claims.IssuedAt.Before(session.TokensValidAfter) {
+		return nil, fmt.Errorf("authService.LoginMFA: %w", ErrInvalidMFAToken)
+	}
+
+	if err = s.ipLimiter.Allow(clientIP); err != nil {
+		return nil, fmt.Errorf("authService.LoginMFA: %w", err)
+	}
+	if err = s.accountLimiter.Allow(claims.UserID); err != nil {
+		return nil, fmt.Errorf("authService.LoginMFA: %w", err)
+	}
+
+	mfa, err := s.getEnabledMFA(claims.UserID)
+	if err != nil {
+		if errors.Is(err, ErrMFANotEnabled) {
+			return nil, fmt.Errorf("authService.LoginMFA: %w", ErrInvalidMFAToken)
+		}
+		return nil, fmt.Errorf("authService.LoginMFA: %w", err)
+	}
+
+	err = s.checkMFACode(claims.UserID, mfa, req.Code)
+	if err != nil {
+		if errors.Is(err, ErrInvalidMFACode) {
+			if err := s.ipLimiter.Fail(clientIP); err != nil {
+				return nil, fmt.Errorf("authService.LoginMFA: %w", err)
+			}
+			if err := s.accountLimiter.Fail(claims.UserID); err != nil {
+				return nil, fmt.Errorf("authService.LoginMFA: %w", err)
+			}
+		}
+		return nil, fmt.Errorf("authService.LoginMFA: %w", err)
+	}
+
+	if err = s.accountLimiter.Reset(claims.UserID); err != nil {
+		return nil, fmt.Errorf("authService.LoginMFA: %w", err)
+	}
+
+	token, err := GenerateJWT(claims.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("authService.LoginMFA: %w", err)
+	}
+
+	return &authModel.LoginResponse{Token: token}, nil
+}
+
+// completeLogin issues a session token, or an MFA token when the user has
+// 2FA enabled.
+func (s *Service) completeLogin(userID string) (*authModel.LoginResponse, error) {
+	_, err := s.getEnabledMFA(userID)
+	switch {
+	case err == nil:
+		token, err := GenerateMFAToken(userID)
+		if err != nil {
+			return nil, err
+		}
+		return &authModel.LoginResponse{MFARequired: true, MFAToken: token}, nil
+	case errors.Is(err, ErrMFANotEnabled):
+		token, err := GenerateJWT(userID)
+		if err != nil {
+			return nil, err
+		}
+		return &authModel.LoginResponse{Token: token}, nil
+	default:
+		return nil, err
+	}
+}
+
+func (s *Service) getEnabledMFA(userID string) (*authModel.MFA, error) {
+	mfa, err := s.repo.GetMFA(userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrMFANotEnabled
+		}
+		return nil, err
+	}
+	if mfa.EnabledAt == nil {
+		return nil, ErrMFANotEnabled
+	}
+	return mfa, nil
+}
+
+// checkMFACode accepts a TOTP code that was not used before or an unused
+// recovery code, and consumes it.
+func (s *Service) checkMFACode(userID string, mfa *authModel.MFA, code string) error {
+	code = normalizeCode(code)
+
+	if len(code) == totp.Digits {
+		step, ok := totp.Validate(mfa.Secret, code, utils.GenerateTimestamp())
+		if !ok || step <= mfa.LastUsedStep {
+			return ErrInvalidMFACode
+		}
+		err := s.repo.UseMFAStep(userID, step)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidMFACode
+		}
+		return err
+	}
+
+	err := s.repo.UseRecoveryCode(userID, utils.HashToken(code))
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidMFACode
+	}
+	return err
+}
+
+// generateRecoveryCode returns a code like "abcde-fghij".
+func generateRecoveryCode() (string, error) {
+	b := make([]byte, recoveryCodeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	code := strings.ToLower(recoveryEncoding.EncodeToString(b))[:recoveryCodeLength]
+	return code[:recoveryCodeLength/2] + "-" + code[recoveryCodeLength/2:], nil
+}
+
+// normalizeCode drops the separators users tend to type along with codes.
+func normalizeCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer(" ", "", "-", "").Replace(code)
+}
@@ -0,0 +1,154 @@
+package authService
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"fmt"
+	authModel "kanban/internal/auth/model"
+	"kanban/internal/config"
+	"kanban/internal/oidc"
+	"kanban/internal/utils"
+	"slices"
+	"strings"
+)
+
+var ErrOIDCDisabled = errors.New("oidc login is not configured")
+var ErrInvalidOIDCState = errors.New("invalid or expired oidc state")
+var ErrOIDCEmailNotVerified = errors.New("identity provider did not verify the email")
+var ErrOIDCDomainNotAllowed = errors.New("email domain is not allowed to sign up")
+var ErrOIDCAccountNotLinkable = errors.New("account with this email is not verified")
+
+type OIDCProvider interface {
+	Issuer() string
+	AuthCodeURL(state, nonce, codeChallenge string) (string, error)
+	Exchange(code, codeVerifier, nonce string) (*oidc.Claims, error)
+}
+
+// StartOIDCLogin prepares the redirect to the identity provider. The state
+// token has to come back with the callback, the handler keeps it in a cookie.
+func (s *Service) StartOIDCLogin() (*authModel.OIDCStart, error) {
+	if s.oidc == nil {
+		return nil, fmt.Errorf("authService.StartOIDCLogin: %w", ErrOIDCDisabled)
+	}
+
+	state, err := utils.GenerateToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("authService.StartOIDCLogin: %w", err)
+	}
+	nonce, err := utils.GenerateToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("authService.StartOIDCLogin: %w", err)
+	}
+	verifier, challenge, err := oidc.NewPKCE()
+	if err != nil {
+		return nil, fmt.Errorf("authService.StartOIDCLogin: %w", err)
+	}
+
+	url, err := s.oidc.AuthCodeURL(state, nonce, challenge)
+	if err != nil {
+		return nil, fmt.Errorf("authService.StartOIDCLogin: %w", err)
+	}
+
+	stateToken, err := GenerateOIDCStateToken(state, nonce, verifier)
+	if err != nil {
+		return nil, fmt.Errorf("authService.StartOIDCLogin: %w", err)
+	}
+
+	return &authModel.OIDCStart{URL: url, StateToken: stateToken}, nil
+}
+
+// FinishOIDCLogin redeems the authorization code and logs the identity in.
+// Known identities log into their user. New ones are linked to the user with
+// the same verified email, or provisioned when the email domain is allowed.
+func (s *Service) FinishOIDCLogin(req authModel.OIDCCallback) (*authModel.LoginResponse, error) {
+	if s.oidc == nil {
+		return nil, fmt.Errorf("authService.FinishOIDCLogin: %w", ErrOIDCDisabled)
+	}
+
+	state, err := ValidateOIDCStateToken(req.StateToken)
+	if err != nil || subtle.ConstantTimeCompare([]byte(state.State), []byte(req.State)) != 1 {
+		return nil, fmt.Errorf("authService.FinishOIDCLogin: %w", ErrInvalidOIDCState)
+	}
+
+	claims, err := s.oidc.Exchange(req.Code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("authService.FinishOIDCLogin: %w", err)
+	}
+
+	userID, err := s.resolveIdentity(s.oidc.Issuer(), claims)
+	if err != nil {
+		return nil, fmt.Errorf("authService.FinishOIDCLogin: %w", err)
+	}
+
+	resp, err := s.completeLogin(*userID)
+	if err != nil {
+		return nil, fmt.Errorf("authService.FinishOIDCLogin: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (s *Service) resolveIdentity(issuer string, claims *oidc.Claims) (*string, error) {
+	userID, err := s.repo.GetUserByIdentity(issuer, claims.Subject)
+	if err == nil {
+		return userID, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, ErrOIDCEmailNotVerified
+	}
+
+	user, err := s.repo.GetByEmail(claims.Email)
+	switch {
+	case err == nil:
+		// Linking to an unverified account would hand it to whoever
+		// registered the address first.
+		if user.EmailVerifiedAt == nil {
+			return nil, ErrOIDCAccountNotLinkable
+		}
+		if err = s.repo.CreateIdentity(user.ID, issuer, claims.Subject); err != nil {
+			return nil, err
+		}
+		return &user.ID, nil
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, err
+	}
+
+	_, domain, _ := strings.Cut(strings.ToLower(claims.Email), "@")
+	if !slices.Contains(config.Get().OIDCAllowedDomains, domain) {
+		return nil, ErrOIDCDomainNotAllowed
+	}
+
+	// External users have no usable password until they reset one.
+	hash, err := hashPassword(utils.NewUUID())
+	if err != nil {
+		return nil, err
+	}
+
+	user = &authModel.User{
+		ID:       utils.NewUUID(),
+		Email:    claims.Email,
+		Username: externalUsername(claims),
+		Password: hash,
+	}
+	if err = s.repo.CreateExternalUser(*user, issuer, claims.Subject); err != nil {
+		return nil, err
+	}
+	return &user.ID, nil
+}
+
+func externalUsername(claims *oidc.Claims) string {
+	switch {
+	case claims.Name != "":
+		return claims.Name
+	case claims.PreferredUsername != "":
+		return claims.PreferredUsername
+	default:
+		name, _, _ := strings.Cut(claims.Email, "@")
+		return name
+	}
+}
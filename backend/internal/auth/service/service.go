@@ -5,28 +5,97 @@ import (
 	"errors"
 	"fmt"
 	authModel "kanban/internal/auth/model"
+	"kanban/internal/config"
+	"kanban/internal/mailer"
 	"kanban/internal/utils"
+	"log"
+	"strings"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
+const passwordResetTTL = time.Hour
+
 var ErrUserNotFound = errors.New("user not found")
 var ErrIncorrectPassword = errors.New("incorrect password")
+var ErrInvalidCredentials = errors.New("invalid email or password")
+var ErrInvalidResetToken = errors.New("invalid or expired reset token")
+var ErrInvalidVerificationToken = errors.New("invalid or expired verification token")
+var ErrInvalidInvitation = errors.New("invalid or expired invitation")
+var ErrInvitationEmailMismatch = errors.New("invitation was sent to another email")
 
 type Repository interface {
 	Create(user authModel.User) error
 	GetByEmail(email string) (*authModel.User, error)
+	GetByID(userID string) (*authModel.User, error)
+	CreatePasswordResetToken(userID, tokenHash string, expiresAt time.Time) error
+	ResetPassword(tokenHash, passwordHash string, now time.Time) (*string, error)
+	UpdatePassword(userID, passwordHash string, validAfter time.Time) error
+	VerifyEmail(userID, email string) error
+	GetSession(userID string) (*authModel.Session, error)
+	GetMFA(userID string) (*authModel.MFA, error)
+	CreatePendingMFA(userID, secret string) error
+	EnableMFA(userID string, step int64, codeHashes []string) error
+	UseMFAStep(userID string, step int64) error
+	UseRecoveryCode(userID, codeHash string) error
+	DeleteMFA(userID string) error
+	GetUserByIdentity(issuer, subject string) (*string, error)
+	CreateIdentity(userID, issuer, subject string) error
+	CreateExternalUser(user authModel.User, issuer, subject string) error
+	SetWorkspaceRole(workspaceID, userID, role string) error
+}
+
+// Limiter throttles login attempts per key. Allow returns a
+// *ratelimit.LimitedError while the key has to wait.
+type Limiter interface {
+	Allow(key string) error
+	Fail(key string) error
+	Reset(key string) error
+}
+
+// Invitations accepts the board invitation a user signed up through.
+type Invitations interface {
+	AcceptInvitation(token, userID string) error
 }
 
 type Service struct {
-	repo Repository
+	repo           Repository
+	mailer         mailer.Mailer
+	ipLimiter      Limiter
+	accountLimiter Limiter
+	oidc           OIDCProvider
+	authenticators []Authenticator
+	invitations    Invitations
 }
 
-func NewService(repo Repository) *Service {
-	return &Service{repo: repo}
+// NewService takes a nil OIDCProvider when OIDC login is not configured.
+// Password logins are checked by the authenticators in the given order.
+func NewService(repo Repository, mailer mailer.Mailer, ipLimiter, accountLimiter Limiter, oidc OIDCProvider, authenticators []Authenticator, invitations Invitations) *Service {
+	return &Service{
+		repo:           repo,
+		mailer:         mailer,
+		ipLimiter:      ipLimiter,
+		accountLimiter: accountLimiter,
+		oidc:           oidc,
+		authenticators: authenticators,
+		invitations:    invitations,
+	}
 }
 
+// CreateUser registers a user. Signing up through an invitation link also
+// accepts the invitation, which confirms the email the link was sent to.
 func (s *Service) CreateUser(req authModel.RegisterRequest) (*string, error) {
+	if req.InvitationToken != "" {
+		claims, err := ValidateInvitationToken(req.InvitationToken)
+		if err != nil {
+			return nil, fmt.Errorf("authService.CreateUser: %w", ErrInvalidInvitation)
+		}
+		if !strings.EqualFold(claims.Email, req.Email) {
+			return nil, fmt.Errorf("authService.CreateUser: %w", ErrInvitationEmailMismatch)
+		}
+	}
+
 	hash, err := hashPassword(req.Password)
 	if err != nil {
 		return nil, fmt.Errorf("authService.CreateUser: %w", err)
@@ -44,6 +113,21 @@ func (s *Service) CreateUser(req authModel.RegisterRequest) (*string, error) {
 		return nil, fmt.Errorf("authService.CreateUser: %w", err)
 	}
 
+	verified := false
+	if req.InvitationToken != "" {
+		if err = s.invitations.AcceptInvitation(req.InvitationToken, user.ID); err != nil {
+			log.Printf("Failed to accept invitation for user %s: %v", user.ID, err)
+		} else {
+			verified = true
+		}
+	}
+
+	if !verified {
+		if err = SendVerification(s.mailer, user); err != nil {
+			log.Printf("Failed to send verification email to user %s: %v", user.ID, err)
+		}
+	}
+
 	token, err := GenerateJWT(user.ID)
 	if err != nil {
 		return nil, fmt.Errorf("authService.CreateUser: %w", err)
@@ -52,28 +136,210 @@ func (s *Service) CreateUser(req authModel.RegisterRequest) (*string, error) {
 	return &token, nil
 }
 
-func (s *Service) LoginUser(req authModel.LoginRequest) (*string, error) {
+// LoginUser checks the credentials of a client, throttled both by its IP and
+// by the account it tries, with each authenticator in turn. Unknown emails
+// and wrong passwords are reported as the same ErrInvalidCredentials. Every
+// failed attempt counts against the limits, including those that failed
+// because a backend was unavailable.
+// Users with 2FA get an MFA token to pass to LoginMFA instead of a session.
+func (s *Service) LoginUser(req authModel.LoginRequest, clientIP string) (*authModel.LoginResponse, error) {
+	account := strings.ToLower(req.Email)
+
+	if err := s.ipLimiter.Allow(clientIP); err != nil {
+		return nil, fmt.Errorf("authService.LoginUser: %w", err)
+	}
+	if err := s.accountLimiter.Allow(account); err != nil {
+		return nil, fmt.Errorf("authService.LoginUser: %w", err)
+	}
+
+	userID, err := s.authenticate(req.Email, req.Password)
+	if err != nil {
+		if failErr := s.ipLimiter.Fail(clientIP); failErr != nil {
+			return nil, fmt.Errorf("authService.LoginUser: %w", failErr)
+		}
+		if failErr := s.accountLimiter.Fail(account); failErr != nil {
+			return nil, fmt.Errorf("authService.LoginUser: %w", failErr)
+		}
+		return nil, fmt.Errorf("authService.LoginUser: %w", err)
+	}
+
+	if err = s.accountLimiter.Reset(account); err != nil {
+		return nil, fmt.Errorf("authService.LoginUser: %w", err)
+	}
+
+	resp, err := s.completeLogin(*userID)
+	if err != nil {
+		return nil, fmt.Errorf("authService.LoginUser: %w", err)
+	}
+
+	return resp, nil
+}
+
+// RequestPasswordReset mails a single-use reset token to the user. Unknown
+// emails are ignored so the endpoint does not reveal which accounts exist.
+func (s *Service) RequestPasswordReset(req authModel.ForgotPasswordRequest) error {
 	user, err := s.repo.GetByEmail(req.Email)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("authService.LoginUser: %w", ErrUserNotFound)
+			return nil
 		}
-		return nil, fmt.Errorf("authService.LoginUser: %w", err)
-	}  
+		return fmt.Errorf("authService.RequestPasswordReset: %w", err)
+	}
+
+	token, err := utils.GenerateToken(32)
+	if err != nil {
+		return fmt.Errorf("authService.RequestPasswordReset: %w", err)
+	}
 
-	err = checkPasswordHash(req.Password, user.Password)
+	expiresAt := utils.GenerateTimestamp().Add(passwordResetTTL)
+	err = s.repo.CreatePasswordResetToken(user.ID, utils.HashToken(token), expiresAt)
 	if err != nil {
-		return nil, fmt.Errorf("authService.LoginUser: %w", ErrIncorrectPassword)
+		return fmt.Errorf("authService.RequestPasswordReset: %w", err)
+	}
+
+	body := fmt.Sprintf(
+		"Hi %s,\n\nuse the link below to set a new password. It expires in %s.\n\n%s/reset-password?token=%s\n\n"+
+			"If you did not ask for a password reset, ignore this email.",
+		user.Username, passwordResetTTL, config.Get().AppURL, token,
+	)
+	// A failed send is only logged, an error here would tell registered
+	// emails apart from unknown ones.
+	err = s.mailer.Send(user.Email, "Reset your password", body)
+	if err != nil {
+		log.Printf("Failed to send password reset email to user %s: %v", user.ID, err)
+	}
+
+	return nil
+}
+
+// ResetPassword sets a new password. Like a password login it only returns
+// an MFA token for users with 2FA, since the reset link alone is not enough.
+func (s *Service) ResetPassword(req authModel.ResetPasswordRequest) (*authModel.LoginResponse, error) {
+	hash, err := hashPassword(req.Password)
+	if err != nil {
+		return nil, fmt.Errorf("authService.ResetPassword: %w", err)
+	}
+
+	userID, err := s.repo.ResetPassword(utils.HashToken(req.Token), hash, revocationTimestamp())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("authService.ResetPassword: %w", ErrInvalidResetToken)
+		}
+		return nil, fmt.Errorf("authService.ResetPassword: %w", err)
+	}
+
+	resp, err := s.completeLogin(*userID)
+	if err != nil {
+		return nil, fmt.Errorf("authService.ResetPassword: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (s *Service) ChangePassword(userID string, req authModel.ChangePasswordRequest) (*string, error) {
+	user, err := s.repo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("authService.ChangePassword: %w", ErrUserNotFound)
+		}
+		return nil, fmt.Errorf("authService.ChangePassword: %w", err)
+	}
+
+	err = checkPasswordHash(req.CurrentPassword, user.Password)
+	if err != nil {
+		return nil, fmt.Errorf("authService.ChangePassword: %w", ErrIncorrectPassword)
+	}
+
+	hash, err := hashPassword(req.NewPassword)
+	if err != nil {
+		return nil, fmt.Errorf("authService.ChangePassword: %w", err)
+	}
+
+	err = s.repo.UpdatePassword(user.ID, hash, revocationTimestamp())
+	if err != nil {
+		return nil, fmt.Errorf("authService.ChangePassword: %w", err)
 	}
 
 	token, err := GenerateJWT(user.ID)
 	if err != nil {
-		return nil, fmt.Errorf("authService.LoginUser: %w", err)
+		return nil, fmt.Errorf("authService.ChangePassword: %w", err)
 	}
 
 	return &token, nil
 }
 
+func (s *Service) VerifyEmail(req authModel.VerifyEmailRequest) error {
+	claims, err := ValidateVerificationToken(req.Token)
+	if err != nil {
+		return fmt.Errorf("authService.VerifyEmail: %w", ErrInvalidVerificationToken)
+	}
+
+	// The token is bound to the address it was sent to, so it stops working
+	// once the user changes their email.
+	err = s.repo.VerifyEmail(claims.UserID, claims.Email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("authService.VerifyEmail: %w", ErrInvalidVerificationToken)
+		}
+		return fmt.Errorf("authService.VerifyEmail: %w", err)
+	}
+
+	return nil
+}
+
+// ResendVerification sends a fresh verification link. Like password reset
+// it does not reveal whether the email belongs to an account.
+func (s *Service) ResendVerification(req authModel.ResendVerificationRequest) error {
+	user, err := s.repo.GetByEmail(req.Email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("authService.ResendVerification: %w", err)
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	err = SendVerification(s.mailer, *user)
+	if err != nil {
+		return fmt.Errorf("authService.ResendVerification: %w", err)
+	}
+
+	return nil
+}
+
+// SendVerification mails a signed link confirming the user's current email.
+func SendVerification(mailer mailer.Mailer, user authModel.User) error {
+	token, err := GenerateVerificationToken(user.ID, user.Email)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(
+		"Hi %s,\n\nconfirm your email address by opening the link below. It expires in %s.\n\n%s/verify-email?token=%s",
+		user.Username, verificationTTL, config.Get().AppURL, token,
+	)
+	return mailer.Send(user.Email, "Confirm your email", body)
+}
+
+// revocationTimestamp is truncated to whole seconds like the JWT "iat"
+// claim, so a token issued right after the revocation stays valid.
+func revocationTimestamp() time.Time {
+	return utils.GenerateTimestamp().Truncate(time.Second)
+}
+
+// dummyPasswordHash is compared against when the email is unknown, so the
+// response time does not reveal whether an account exists.
+var dummyPasswordHash = func() string {
+	hash, err := hashPassword(utils.NewUUID())
+	if err != nil {
+		panic(err)
+	}
+	return hash
+}()
+
 func hashPassword(password string) (string, error) {
 	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	return string(bytes), err
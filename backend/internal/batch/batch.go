@@ -0,0 +1,21 @@
+package batch
+
+import (
+	"database/sql"
+	batchHandler "kanban/internal/batch/handler"
+	batchProxy "kanban/internal/batch/proxy"
+	batchRepo "kanban/internal/batch/repo"
+	batchService "kanban/internal/batch/service"
+	workspaceRepo "kanban/internal/workspace/repo"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Init(db *sql.DB, grp *gin.RouterGroup) {
+	repo := batchRepo.NewRepository(db)
+	service := batchService.NewService(repo, workspaceRepo.NewRepository(db))
+	proxy := batchProxy.NewProxy(service)
+	handler := batchHandler.NewHandler(proxy)
+
+	grp.POST("/batch", handler.BatchHandler())
+}
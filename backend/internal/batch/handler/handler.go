@@ -0,0 +1,96 @@
+package batchHandler
+
+import (
+	"errors"
+	authctx "kanban/internal/auth/context"
+	batchModel "kanban/internal/batch/model"
+	batchProxy "kanban/internal/batch/proxy"
+	batchService "kanban/internal/batch/service"
+	columnRepo "kanban/internal/column/repo"
+	taskRepo "kanban/internal/task/repo"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Proxy interface {
+	Batch(userID string, req batchModel.Request) (*batchModel.Response, error)
+}
+
+type Handler struct {
+	proxy Proxy
+}
+
+func NewHandler(proxy Proxy) *Handler {
+	return &Handler{proxy: proxy}
+}
+
+func (h *Handler) BatchHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req batchModel.Request
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid request body",
+			})
+			return
+		}
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		response, err := h.proxy.Batch(userID, req)
+		if err != nil {
+			log.Printf("Failed to run batch: %v", err)
+			h.handleError(ctx, err, "Failed to run batch")
+			return
+		}
+
+		ctx.JSON(http.StatusOK, response)
+	}
+}
+
+// handleError reports which operation failed the batch next to the
+// reason, nothing of the batch is applied in either case.
+func (h *Handler) handleError(ctx *gin.Context, err error, message string) {
+	var opErr *batchModel.OperationError
+	if !errors.As(err, &opErr) {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"detail": message,
+		})
+		return
+	}
+
+	status := http.StatusInternalServerError
+	detail := message
+	switch {
+	case errors.Is(err, batchProxy.ErrForbidden):
+		status, detail = http.StatusForbidden, "Access denied"
+	case errors.Is(err, batchService.ErrNotFound):
+		status, detail = http.StatusNotFound, "Entity not found"
+	case errors.Is(err, batchService.ErrInvalidOperation),
+		errors.Is(err, batchService.ErrUnknownReference),
+		errors.Is(err, batchService.ErrDuplicateClientID):
+		status, detail = http.StatusBadRequest, opErr.Err.Error()
+	case errors.Is(err, columnRepo.ErrColumnLimitReached):
+		status, detail = http.StatusForbidden, "Column limit reached"
+	case errors.Is(err, taskRepo.ErrTaskLimitReached):
+		status, detail = http.StatusForbidden, "Task limit reached"
+	case errors.Is(err, columnRepo.ErrIncorrectPosition):
+		status, detail = http.StatusUnprocessableEntity, "Column position is greater than possible or not positive"
+	case errors.Is(err, taskRepo.ErrIncorrectPosition):
+		status, detail = http.StatusUnprocessableEntity, "Task position is greater than possible or not positive"
+	case errors.Is(err, taskRepo.ErrTaskBlocked):
+		status, detail = http.StatusConflict, "Task is blocked by unfinished tasks"
+	}
+
+	ctx.AbortWithStatusJSON(status, gin.H{
+		"detail": detail,
+		"index":  opErr.Index,
+	})
+}
@@ -0,0 +1,88 @@
+package batchModel
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+const (
+	EntityWorkspace = "workspace"
+	EntityBoard     = "board"
+	EntityColumn    = "column"
+	EntityTask      = "task"
+)
+
+// Operation is one step of a batch. ID names the entity to update or
+// delete, ParentID the board of a new column or the column of a new task.
+// Both take a UUID or the client_id of an entity created earlier in the
+// batch. Data is the body the single-entity endpoint accepts.
+type Operation struct {
+	Action   string          `json:"action" binding:"required,oneof=create update delete"`
+	Entity   string          `json:"entity" binding:"required,oneof=board column task"`
+	ID       string          `json:"id"`
+	ParentID string          `json:"parent_id"`
+	ClientID string          `json:"client_id" binding:"max=64"`
+	Data     json.RawMessage `json:"data"`
+}
+
+type Request struct {
+	Operations []Operation `json:"operations" binding:"required,min=1,max=100,dive"`
+}
+
+// Step is an operation with every reference resolved. Data holds the
+// entity to create or the update request of its package.
+type Step struct {
+	Index  int
+	Action string
+	Entity string
+	ID     string
+	Data   any
+}
+
+// Reference is an entity that existed before the batch and has to be
+// accessible to the caller. Manage is set for boards the batch updates or
+// deletes, which only their owners may do.
+type Reference struct {
+	Index  int
+	Entity string
+	ID     string
+	Manage bool
+}
+
+type Plan struct {
+	Steps      []Step
+	References []Reference
+	ClientIDs  map[int]string
+}
+
+type Result struct {
+	Index    int     `json:"index"`
+	Action   string  `json:"action"`
+	Entity   string  `json:"entity"`
+	ID       string  `json:"id"`
+	ClientID *string `json:"client_id"`
+}
+
+type Response struct {
+	Results []Result `json:"results"`
+}
+
+// OperationError names the operation that failed the batch.
+type OperationError struct {
+	Index int
+	Err   error
+}
+
+func (e *OperationError) Error() string {
+	return fmt.Sprintf("operation %d: %v", e.Index, e.Err)
+}
+
+func (e *OperationError) Unwrap() error {
+	return e.Err
+}
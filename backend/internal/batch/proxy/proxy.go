@@ -0,0 +1,71 @@
+package batchProxy
+
+import (
+	"errors"
+	"fmt"
+	batchModel "kanban/internal/batch/model"
+)
+
+var ErrForbidden = errors.New("access denied")
+
+type Service interface {
+	Plan(userID string, req batchModel.Request) (*batchModel.Plan, error)
+	Apply(userID string, plan *batchModel.Plan) (*batchModel.Response, error)
+	GetWorkspaceRole(workspaceID, userID string) (*string, error)
+	HasAccess(ref batchModel.Reference, userID string) (bool, error)
+	CanManageBoard(boardID, userID string) (bool, error)
+}
+
+type Proxy struct {
+	service Service
+}
+
+func NewProxy(service Service) *Proxy {
+	return &Proxy{service: service}
+}
+
+// Batch checks access to every entity the batch touches that existed
+// before it. Entities created by the batch live under those, so they need
+// no checks of their own.
+func (p *Proxy) Batch(userID string, req batchModel.Request) (*batchModel.Response, error) {
+	plan, err := p.service.Plan(userID, req)
+	if err != nil {
+		return nil, fmt.Errorf("batchProxy.Batch: %w", err)
+	}
+
+	for _, ref := range plan.References {
+		hasAccess, err := p.checkAccess(ref, userID)
+		if err != nil {
+			return nil, fmt.Errorf("batchProxy.Batch: %w", &batchModel.OperationError{Index: ref.Index, Err: err})
+		}
+		if !hasAccess {
+			return nil, fmt.Errorf("batchProxy.Batch: %w", &batchModel.OperationError{Index: ref.Index, Err: ErrForbidden})
+		}
+	}
+
+	return p.service.Apply(userID, plan)
+}
+
+func (p *Proxy) checkAccess(ref batchModel.Reference, userID string) (bool, error) {
+	if ref.Entity == batchModel.EntityWorkspace {
+		role, err := p.service.GetWorkspaceRole(ref.ID, userID)
+		if err != nil {
+			return false, fmt.Errorf("batchProxy.checkAccess: %w", err)
+		}
+		return *role != "", nil
+	}
+
+	if ref.Manage {
+		canManage, err := p.service.CanManageBoard(ref.ID, userID)
+		if err != nil {
+			return false, fmt.Errorf("batchProxy.checkAccess: %w", err)
+		}
+		return canManage, nil
+	}
+
+	hasAccess, err := p.service.HasAccess(ref, userID)
+	if err != nil {
+		return false, fmt.Errorf("batchProxy.checkAccess: %w", err)
+	}
+	return hasAccess, nil
+}
@@ -0,0 +1,200 @@
+package batchRepo
+
+import (
+	"database/sql"
+	"fmt"
+	batchModel "kanban/internal/batch/model"
+	boardModel "kanban/internal/board/model"
+	columnModel "kanban/internal/column/model"
+	columnRepo "kanban/internal/column/repo"
+	"kanban/internal/history"
+	"kanban/internal/postgres"
+	taskModel "kanban/internal/task/model"
+	taskRepo "kanban/internal/task/repo"
+	"kanban/internal/utils"
+	"time"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Apply runs the steps in order in one transaction. The first failing
+// step rolls back the whole batch and is returned as an OperationError.
+func (r *Repository) Apply(userID string, steps []batchModel.Step) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("batchRepo.Apply: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := utils.GenerateTimestamp()
+	for _, step := range steps {
+		if err = applyStep(tx, userID, step, now); err != nil {
+			return &batchModel.OperationError{Index: step.Index, Err: err}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("batchRepo.Apply: %w", err)
+	}
+	return nil
+}
+
+func applyStep(tx *sql.Tx, userID string, step batchModel.Step, now time.Time) error {
+	switch data := step.Data.(type) {
+	case boardModel.Board:
+		_, err := tx.Exec(
+			postgres.QueryCreateBoard,
+			data.ID,
+			userID,
+			data.WorkspaceID,
+			now,
+			now,
+			data.Name,
+			data.EnforceDependencies,
+		)
+		return err
+	case boardModel.Request:
+		res, err := tx.Exec(postgres.QueryUpdateBoard, now, data.Name, data.EnforceDependencies, step.ID)
+		if err != nil {
+			return err
+		}
+		return checkAffected(res)
+	case columnModel.Column:
+		column, err := columnRepo.CreateColumn(tx, data, now)
+		if err != nil {
+			return err
+		}
+		return history.RecordColumn(tx, userID, history.ActionCreate, nil, column)
+	case columnModel.UpdateRequest:
+		before, err := columnRepo.LockColumn(tx, step.ID)
+		if err != nil {
+			return err
+		}
+		column, err := columnRepo.UpdateColumn(tx, before, data.Name, data.Position, data.Flag, now)
+		if err != nil {
+			return err
+		}
+		return history.RecordColumn(tx, userID, history.ActionUpdate, before, column)
+	case taskModel.Task:
+		task, err := taskRepo.CreateTask(tx, data, now)
+		if err != nil {
+			return err
+		}
+		return history.RecordTask(tx, userID, history.ActionCreate, nil, task)
+	case taskModel.UpdateRequest:
+		before, err := taskRepo.LockTask(tx, step.ID)
+		if err != nil {
+			return err
+		}
+		task, err := updateTask(tx, before, data, now)
+		if err != nil {
+			return err
+		}
+		action := history.ActionUpdate
+		if task.ColumnID != before.ColumnID || task.Position != before.Position {
+			action = history.ActionMove
+		}
+		return history.RecordTask(tx, userID, action, before, task)
+	}
+
+	// Board deletions are not recorded, the history of a board goes with it.
+	switch step.Entity {
+	case batchModel.EntityBoard:
+		res, err := tx.Exec(postgres.QueryDeleteBoard, step.ID)
+		if err != nil {
+			return err
+		}
+		return checkAffected(res)
+	case batchModel.EntityColumn:
+		column, err := columnRepo.LockColumn(tx, step.ID)
+		if err != nil {
+			return err
+		}
+		if err = columnRepo.RecordDelete(tx, userID, column); err != nil {
+			return err
+		}
+		return columnRepo.DeleteColumn(tx, column)
+	case batchModel.EntityTask:
+		task, err := taskRepo.LockTask(tx, step.ID)
+		if err != nil {
+			return err
+		}
+		if err = taskRepo.DeleteTask(tx, task); err != nil {
+			return err
+		}
+		return history.RecordTask(tx, userID, history.ActionDelete, task, nil)
+	}
+	return fmt.Errorf("batchRepo.applyStep: unexpected %s %s", step.Action, step.Entity)
+}
+
+// updateTask applies the content fields first and then moves the task,
+// to the end of the target column when no position is given.
+func updateTask(tx *sql.Tx, task *taskModel.Task, req taskModel.UpdateRequest, now time.Time) (*taskModel.Task, error) {
+	var err error
+	if req.Name != nil || req.Description != nil || req.Done != nil || req.Deadline != nil {
+		task, err = taskRepo.UpdateTaskContent(tx, task.ID, req, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case req.ColumnID != nil && *req.ColumnID != task.ColumnID:
+		return taskRepo.MoveTask(tx, task, *req.ColumnID, req.Position, now)
+	case req.Position != nil && *req.Position != task.Position:
+		return taskRepo.RepositionTask(tx, task, *req.Position, now)
+	}
+	return task, nil
+}
+
+func checkAffected(res sql.Result) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *Repository) GetPersonalWorkspace(userID string) (*string, error) {
+	var workspaceID string
+	err := r.db.QueryRow(postgres.QueryGetPersonalWorkspace, userID).Scan(&workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("batchRepo.GetPersonalWorkspace: %w", err)
+	}
+	return &workspaceID, nil
+}
+
+func (r *Repository) GetWorkspaceRole(workspaceID, userID string) (*string, error) {
+	var role string
+	err := r.db.QueryRow(postgres.QueryGetWorkspaceRole, workspaceID, userID).Scan(&role)
+	if err != nil {
+		return nil, fmt.Errorf("batchRepo.GetWorkspaceRole: %w", err)
+	}
+	return &role, nil
+}
+
+func (r *Repository) HasAccess(ref batchModel.Reference, userID string) (bool, error) {
+	query := postgres.QueryHasBoardAccess
+	switch ref.Entity {
+	case batchModel.EntityColumn:
+		query = postgres.QueryHasColumnAccess
+	case batchModel.EntityTask:
+		query = postgres.QueryHasTaskAccess
+	}
+
+	var hasAccess bool
+	err := r.db.QueryRow(query, ref.ID, userID, postgres.OwnBoardsOnly()).Scan(&hasAccess)
+	if err != nil {
+		return false, fmt.Errorf("batchRepo.HasAccess: %w", err)
+	}
+	return hasAccess, nil
+}
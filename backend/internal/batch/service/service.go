@@ -0,0 +1,330 @@
+package batchService
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	batchModel "kanban/internal/batch/model"
+	boardModel "kanban/internal/board/model"
+	columnModel "kanban/internal/column/model"
+	taskModel "kanban/internal/task/model"
+	"kanban/internal/utils"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+var ErrInvalidOperation = errors.New("invalid operation")
+var ErrUnknownReference = errors.New("reference is neither a uuid nor an earlier client_id")
+var ErrDuplicateClientID = errors.New("client_id is already used in the batch")
+var ErrNotFound = errors.New("entity not found")
+
+type Repository interface {
+	Apply(userID string, steps []batchModel.Step) error
+	GetPersonalWorkspace(userID string) (*string, error)
+	GetWorkspaceRole(workspaceID, userID string) (*string, error)
+	HasAccess(ref batchModel.Reference, userID string) (bool, error)
+}
+
+// Access is the lookup of board permissions shared with other packages,
+// implemented by the workspace repository.
+type Access interface {
+	CanManageBoard(boardID, userID string) (bool, error)
+}
+
+type Service struct {
+	repo   Repository
+	access Access
+}
+
+func NewService(repo Repository, access Access) *Service {
+	return &Service{repo: repo, access: access}
+}
+
+// Plan validates the operations and gives every created entity its ID up
+// front, so later operations can refer to it by client_id. Entities that
+// existed before the batch are listed in the plan's references.
+func (s *Service) Plan(userID string, req batchModel.Request) (*batchModel.Plan, error) {
+	p := planner{
+		plan:    &batchModel.Plan{ClientIDs: map[int]string{}},
+		created: map[string]created{},
+		checked: map[string]bool{},
+	}
+
+	for i, op := range req.Operations {
+		step, err := p.step(i, op)
+		if err != nil {
+			return nil, fmt.Errorf("batchService.Plan: %w", &batchModel.OperationError{Index: i, Err: err})
+		}
+
+		if board, ok := step.Data.(boardModel.Board); ok && board.WorkspaceID == "" {
+			workspaceID, err := s.repo.GetPersonalWorkspace(userID)
+			if err != nil {
+				return nil, fmt.Errorf("batchService.Plan: %w", err)
+			}
+			board.WorkspaceID = *workspaceID
+			step.Data = board
+		}
+
+		p.plan.Steps = append(p.plan.Steps, *step)
+	}
+
+	return p.plan, nil
+}
+
+func (s *Service) Apply(userID string, plan *batchModel.Plan) (*batchModel.Response, error) {
+	err := s.repo.Apply(userID, plan.Steps)
+	if err != nil {
+		var opErr *batchModel.OperationError
+		if errors.As(err, &opErr) && errors.Is(opErr.Err, sql.ErrNoRows) {
+			opErr.Err = ErrNotFound
+		}
+		return nil, fmt.Errorf("batchService.Apply: %w", err)
+	}
+
+	response := &batchModel.Response{Results: make([]batchModel.Result, 0, len(plan.Steps))}
+	for _, step := range plan.Steps {
+		result := batchModel.Result{
+			Index:  step.Index,
+			Action: step.Action,
+			Entity: step.Entity,
+			ID:     step.ID,
+		}
+		if clientID, ok := plan.ClientIDs[step.Index]; ok {
+			result.ClientID = &clientID
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	return response, nil
+}
+
+func (s *Service) GetWorkspaceRole(workspaceID, userID string) (*string, error) {
+	role, err := s.repo.GetWorkspaceRole(workspaceID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("batchService.GetWorkspaceRole: %w", ErrNotFound)
+		}
+		return nil, fmt.Errorf("batchService.GetWorkspaceRole: %w", err)
+	}
+	return role, nil
+}
+
+func (s *Service) HasAccess(ref batchModel.Reference, userID string) (bool, error) {
+	hasAccess, err := s.repo.HasAccess(ref, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, fmt.Errorf("batchService.HasAccess: %w", ErrNotFound)
+		}
+		return false, fmt.Errorf("batchService.HasAccess: %w", err)
+	}
+	return hasAccess, nil
+}
+
+func (s *Service) CanManageBoard(boardID, userID string) (bool, error) {
+	canManage, err := s.access.CanManageBoard(boardID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, fmt.Errorf("batchService.CanManageBoard: %w", ErrNotFound)
+		}
+		return false, fmt.Errorf("batchService.CanManageBoard: %w", err)
+	}
+	return canManage, nil
+}
+
+type created struct {
+	entity string
+	id     string
+}
+
+type planner struct {
+	plan    *batchModel.Plan
+	created map[string]created
+	checked map[string]bool
+}
+
+func (p *planner) step(index int, op batchModel.Operation) (*batchModel.Step, error) {
+	step := &batchModel.Step{Index: index, Action: op.Action, Entity: op.Entity}
+
+	if op.Action != batchModel.ActionCreate {
+		if op.ClientID != "" {
+			return nil, fmt.Errorf("%w: client_id is only allowed on create", ErrInvalidOperation)
+		}
+
+		id, err := p.resolve(index, op.Entity, op.ID)
+		if err != nil {
+			return nil, err
+		}
+		step.ID = id
+		if op.Entity == batchModel.EntityBoard {
+			p.manage(index, id)
+		}
+
+		if op.Action == batchModel.ActionUpdate {
+			step.Data, err = p.update(index, op)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return step, nil
+	}
+
+	if op.ClientID != "" {
+		if _, ok := p.created[op.ClientID]; ok {
+			return nil, ErrDuplicateClientID
+		}
+		p.plan.ClientIDs[index] = op.ClientID
+	}
+	step.ID = utils.NewUUID()
+
+	var err error
+	step.Data, err = p.create(index, step.ID, op)
+	if err != nil {
+		return nil, err
+	}
+
+	if op.ClientID != "" {
+		p.created[op.ClientID] = created{entity: op.Entity, id: step.ID}
+	}
+	return step, nil
+}
+
+func (p *planner) create(index int, id string, op batchModel.Operation) (any, error) {
+	switch op.Entity {
+	case batchModel.EntityBoard:
+		var req boardModel.Request
+		if err := decode(op.Data, &req); err != nil {
+			return nil, err
+		}
+
+		board := boardModel.Board{ID: id, Name: req.Name}
+		if req.EnforceDependencies != nil {
+			board.EnforceDependencies = *req.EnforceDependencies
+		}
+		if req.WorkspaceID != nil {
+			if err := uuid.Validate(*req.WorkspaceID); err != nil {
+				return nil, fmt.Errorf("%w: workspace_id is not a uuid", ErrInvalidOperation)
+			}
+			board.WorkspaceID = *req.WorkspaceID
+			p.reference(index, batchModel.EntityWorkspace, board.WorkspaceID)
+		}
+		return board, nil
+
+	case batchModel.EntityColumn:
+		boardID, err := p.resolve(index, batchModel.EntityBoard, op.ParentID)
+		if err != nil {
+			return nil, err
+		}
+
+		var req columnModel.CreateRequest
+		if err = decode(op.Data, &req); err != nil {
+			return nil, err
+		}
+
+		column := columnModel.Column{ID: id, BoardID: boardID, Name: req.Name}
+		if req.Flag != "" {
+			column.Flag = &req.Flag
+		}
+		return column, nil
+
+	default:
+		columnID, err := p.resolve(index, batchModel.EntityColumn, op.ParentID)
+		if err != nil {
+			return nil, err
+		}
+
+		var req taskModel.CreateRequest
+		if err = decode(op.Data, &req); err != nil {
+			return nil, err
+		}
+
+		return taskModel.Task{ID: id, ColumnID: columnID, Name: req.Name}, nil
+	}
+}
+
+func (p *planner) update(index int, op batchModel.Operation) (any, error) {
+	switch op.Entity {
+	case batchModel.EntityBoard:
+		var req boardModel.Request
+		if err := decode(op.Data, &req); err != nil {
+			return nil, err
+		}
+		req.WorkspaceID = nil
+		return req, nil
+
+	case batchModel.EntityColumn:
+		var req columnModel.UpdateRequest
+		if err := decode(op.Data, &req); err != nil {
+			return nil, err
+		}
+		return req, nil
+
+	default:
+		var req taskModel.UpdateRequest
+		if err := decode(op.Data, &req); err != nil {
+			return nil, err
+		}
+		if req.ColumnID != nil {
+			columnID, err := p.resolve(index, batchModel.EntityColumn, *req.ColumnID)
+			if err != nil {
+				return nil, err
+			}
+			req.ColumnID = &columnID
+		}
+		return req, nil
+	}
+}
+
+// resolve turns a client_id into the ID of the entity created for it.
+// Anything else has to be a UUID of an entity that already exists.
+func (p *planner) resolve(index int, entity, ref string) (string, error) {
+	if ref == "" {
+		return "", fmt.Errorf("%w: missing %s reference", ErrInvalidOperation, entity)
+	}
+
+	if c, ok := p.created[ref]; ok {
+		if c.entity != entity {
+			return "", fmt.Errorf("%w: %s is a %s, not a %s", ErrInvalidOperation, ref, c.entity, entity)
+		}
+		return c.id, nil
+	}
+
+	if err := uuid.Validate(ref); err != nil {
+		return "", fmt.Errorf("%w: %s", ErrUnknownReference, ref)
+	}
+	p.reference(index, entity, ref)
+	return ref, nil
+}
+
+func (p *planner) reference(index int, entity, id string) {
+	if p.checked[entity+id] {
+		return
+	}
+	p.checked[entity+id] = true
+	p.plan.References = append(p.plan.References, batchModel.Reference{Index: index, Entity: entity, ID: id})
+}
+
+// manage marks a board that existed before the batch as changed by the
+// operation. Boards created by the batch are not among the references.
+func (p *planner) manage(index int, boardID string) {
+	for i, ref := range p.plan.References {
+		if ref.Entity == batchModel.EntityBoard && ref.ID == boardID && !ref.Manage {
+			p.plan.References[i].Index = index
+			p.plan.References[i].Manage = true
+		}
+	}
+}
+
+func decode(data json.RawMessage, v any) error {
+	if len(data) == 0 {
+		return fmt.Errorf("%w: missing data", ErrInvalidOperation)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidOperation, err)
+	}
+	if err := binding.Validator.ValidateStruct(v); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidOperation, err)
+	}
+	return nil
+}
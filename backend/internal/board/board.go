@@ -6,13 +6,14 @@ import (
 	boardProxy "kanban/internal/board/proxy"
 	boardRepo "kanban/internal/board/repo"
 	boardService "kanban/internal/board/service"
+	workspaceRepo "kanban/internal/workspace/repo"
 
 	"github.com/gin-gonic/gin"
 )
 
 func Init(db *sql.DB, grp *gin.RouterGroup) {
 	repo := boardRepo.NewRepository(db)
-	service := boardService.NewService(repo)
+	service := boardService.NewService(repo, workspaceRepo.NewRepository(db))
 	proxy := boardProxy.NewProxy(service)
 	handler := boardHandler.NewHandler(proxy)
 
@@ -21,5 +22,6 @@ func Init(db *sql.DB, grp *gin.RouterGroup) {
 	grp.GET("/boards/:id", handler.GetBoardHandler())
 	grp.PUT("/boards/:id", handler.UpdateBoardHandler())
 	grp.DELETE("/boards/:id", handler.DeleteBoardHandler())
+	grp.POST("/boards/:id/move", handler.MoveBoardHandler())
 
 }
@@ -6,6 +6,7 @@ import (
 	boardModel "kanban/internal/board/model"
 	boardProxy "kanban/internal/board/proxy"
 	boardService "kanban/internal/board/service"
+	"kanban/internal/config"
 	"log"
 	"net/http"
 
@@ -13,11 +14,12 @@ import (
 )
 
 type Proxy interface {
-	CreateBoard(userID string, req boardModel.Request) error
+	CreateBoard(userID string, req boardModel.Request) (*boardModel.Board, error)
 	GetAllBoards(userID string) ([]boardModel.Board, error)
 	GetBoard(boardID, userID string) (*boardModel.Board, error)
-	UpdateBoard(boardID, userID string, req boardModel.Request) error
+	UpdateBoard(boardID, userID string, req boardModel.Request) (*boardModel.Board, error)
 	DeleteBoard(boardID, userID string) error
+	MoveBoard(boardID, userID string, req boardModel.MoveRequest) (*boardModel.Board, error)
 }
 
 type Handler struct {
@@ -46,14 +48,15 @@ func (h *Handler) CreateBoardHandler() gin.HandlerFunc {
 			return
 		}
 
-		err := h.proxy.CreateBoard(userID, req)
+		board, err := h.proxy.CreateBoard(userID, req)
 		if err != nil {
 			log.Printf("Failed create board: %v\n", err)
 			h.handleError(ctx, err, "Failed to create board")
 			return
 		}
 
-		ctx.Status(http.StatusCreated)
+		ctx.Header("Location", config.Get().APIPrefix+"/boards/"+board.ID)
+		ctx.JSON(http.StatusCreated, board)
 	}
 }
 
@@ -121,13 +124,14 @@ func (h *Handler) UpdateBoardHandler() gin.HandlerFunc {
 			return
 		}
 
-		if err := h.proxy.UpdateBoard(boardID, userID, req); err != nil {
+		board, err := h.proxy.UpdateBoard(boardID, userID, req)
+		if err != nil {
 			log.Printf("Failed to update board: %v", err)
 			h.handleError(ctx, err, "Failed to update board")
 			return
 		}
 
-		ctx.Status(http.StatusOK)
+		ctx.JSON(http.StatusOK, board)
 	}
 }
 
@@ -153,6 +157,37 @@ func (h *Handler) DeleteBoardHandler() gin.HandlerFunc {
 	}
 }
 
+func (h *Handler) MoveBoardHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req boardModel.MoveRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid request body",
+			})
+			return
+		}
+
+		boardID := ctx.Param("id")
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		board, err := h.proxy.MoveBoard(boardID, userID, req)
+		if err != nil {
+			log.Printf("Failed to move board: %v", err)
+			h.handleError(ctx, err, "Failed to move board")
+			return
+		}
+
+		ctx.JSON(http.StatusOK, board)
+	}
+}
+
 func (h *Handler) handleError(ctx *gin.Context, err error, message string) {
 	switch {
 	case errors.Is(err, boardProxy.ErrForbidden):
@@ -163,6 +198,10 @@ func (h *Handler) handleError(ctx *gin.Context, err error, message string) {
 		ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{
 			"detail": "Board not found",
 		})
+	case errors.Is(err, boardService.ErrWorkspaceNotFound):
+		ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+			"detail": "Workspace not found",
+		})
 	default:
 		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
 			"detail": message,
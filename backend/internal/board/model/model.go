@@ -2,14 +2,29 @@ package boardModel
 
 import "time"
 
+// Board belongs to a workspace. UserID is the creator, nil once they
+// deleted their account.
 type Board struct {
-	ID        string    `json:"id"`
-	UserID    string    `json:"user_id"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	Name      string    `json:"name"`
+	ID          string    `json:"id"`
+	UserID      *string   `json:"user_id"`
+	WorkspaceID string    `json:"workspace_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Name        string    `json:"name"`
+
+	EnforceDependencies bool `json:"enforce_dependencies"`
 }
 
 type Request struct {
 	Name string `json:"name" binding:"required"`
+
+	EnforceDependencies *bool `json:"enforce_dependencies"`
+
+	// WorkspaceID is only read on creation, boards go to the personal
+	// workspace by default.
+	WorkspaceID *string `json:"workspace_id"`
+}
+
+type MoveRequest struct {
+	WorkspaceID string `json:"workspace_id" binding:"required"`
 }
@@ -4,17 +4,21 @@ import (
 	"errors"
 	"fmt"
 	boardModel "kanban/internal/board/model"
+	workspaceModel "kanban/internal/workspace/model"
 )
 
 var ErrForbidden = errors.New("access denied")
 
 type Service interface {
-	CreateBoard(userID string, req boardModel.Request) error
+	CreateBoard(userID string, req boardModel.Request) (*boardModel.Board, error)
 	GetAllBoards(userID string) ([]boardModel.Board, error)
 	GetBoard(boardID string) (*boardModel.Board, error)
-	UpdateBoard(boardID string, req boardModel.Request) error
+	UpdateBoard(boardID string, req boardModel.Request) (*boardModel.Board, error)
 	DeleteBoard(boardID string) error
-	GetUserByBoard(boardID string) (*string, error)
+	MoveBoard(boardID string, req boardModel.MoveRequest) (*boardModel.Board, error)
+	GetWorkspaceRole(workspaceID, userID string) (*string, error)
+	HasBoardAccess(boardID, userID string) (bool, error)
+	CanManageBoard(boardID, userID string) (bool, error)
 }
 
 type Proxy struct {
@@ -25,7 +29,17 @@ func NewProxy(service Service) *Proxy {
 	return &Proxy{service: service}
 }
 
-func (p *Proxy) CreateBoard(userID string, req boardModel.Request) error {
+func (p *Proxy) CreateBoard(userID string, req boardModel.Request) (*boardModel.Board, error) {
+	if req.WorkspaceID != nil {
+		role, err := p.service.GetWorkspaceRole(*req.WorkspaceID, userID)
+		if err != nil {
+			return nil, fmt.Errorf("boardProxy.CreateBoard: %w", err)
+		}
+		if *role == "" {
+			return nil, fmt.Errorf("boardProxy.CreateBoard: %w", ErrForbidden)
+		}
+	}
+
 	return p.service.CreateBoard(userID, req)
 }
 
@@ -34,49 +48,77 @@ func (p *Proxy) GetAllBoards(userID string) ([]boardModel.Board, error) {
 }
 
 func (p *Proxy) GetBoard(boardID, userID string) (*boardModel.Board, error) {
-	isOwner, err := p.checkBoardOwnership(boardID, userID)
+	hasAccess, err := p.checkBoardAccess(boardID, userID)
 	if err != nil {
 		return nil, fmt.Errorf("boardProxy.GetBoard: %w", err)
 	}
 
-	if isOwner {
+	if hasAccess {
 		return p.service.GetBoard(boardID)
 	} else {
 		return nil, fmt.Errorf("boardProxy.GetBoard: %w", ErrForbidden)
 	}
 }
 
-func (p *Proxy) UpdateBoard(boardID, userID string, req boardModel.Request) error {
-	isOwner, err := p.checkBoardOwnership(boardID, userID)
+// UpdateBoard and DeleteBoard are left to the owners of the board, see
+// CanManageBoard. Other members and invitees only work on its contents.
+func (p *Proxy) UpdateBoard(boardID, userID string, req boardModel.Request) (*boardModel.Board, error) {
+	canManage, err := p.service.CanManageBoard(boardID, userID)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("boardProxy.UpdateBoard: %w", err)
 	}
 
-	if isOwner {
+	if canManage {
 		return p.service.UpdateBoard(boardID, req)
 	} else {
-		return fmt.Errorf("boardProxy.UpdateBoard: %w", ErrForbidden)
+		return nil, fmt.Errorf("boardProxy.UpdateBoard: %w", ErrForbidden)
 	}
 }
 
 func (p *Proxy) DeleteBoard(boardID, userID string) error {
-	isOwner, err := p.checkBoardOwnership(boardID, userID)
+	canManage, err := p.service.CanManageBoard(boardID, userID)
 	if err != nil {
-		return err
+		return fmt.Errorf("boardProxy.DeleteBoard: %w", err)
 	}
 
-	if isOwner {
+	if canManage {
 		return p.service.DeleteBoard(boardID)
 	} else {
 		return fmt.Errorf("boardProxy.DeleteBoard: %w", ErrForbidden)
 	}
 }
 
-func (p *Proxy) checkBoardOwnership(boardID, userID string) (bool, error) {
-	realUserID, err := p.service.GetUserByBoard(boardID)
+// MoveBoard lets admins of the board's workspace move it to any workspace
+// the user is a member of.
+func (p *Proxy) MoveBoard(boardID, userID string, req boardModel.MoveRequest) (*boardModel.Board, error) {
+	board, err := p.service.GetBoard(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("boardProxy.MoveBoard: %w", err)
+	}
+	role, err := p.service.GetWorkspaceRole(board.WorkspaceID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("boardProxy.MoveBoard: %w", err)
+	}
+	if *role != workspaceModel.RoleOwner && *role != workspaceModel.RoleAdmin {
+		return nil, fmt.Errorf("boardProxy.MoveBoard: %w", ErrForbidden)
+	}
+
+	targetRole, err := p.service.GetWorkspaceRole(req.WorkspaceID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("boardProxy.MoveBoard: %w", err)
+	}
+	if *targetRole == "" {
+		return nil, fmt.Errorf("boardProxy.MoveBoard: %w", ErrForbidden)
+	}
+
+	return p.service.MoveBoard(boardID, req)
+}
+
+func (p *Proxy) checkBoardAccess(boardID, userID string) (bool, error) {
+	hasAccess, err := p.service.HasBoardAccess(boardID, userID)
 	if err != nil {
-		return false, fmt.Errorf("boardProxy.checkBoardOwnership: %w", err)
+		return false, fmt.Errorf("boardProxy.checkBoardAccess: %w", err)
 	}
 
-	return *realUserID == userID, nil
+	return hasAccess, nil
 }
\ No newline at end of file
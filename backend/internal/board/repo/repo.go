@@ -16,24 +16,26 @@ func NewRepository(db *sql.DB) *Repository {
 	return &Repository{db: db}
 }
 
-func (r *Repository) Create(board boardModel.Board) error {
-	_, err := r.db.Exec(
+func (r *Repository) Create(board boardModel.Board) (*boardModel.Board, error) {
+	created, err := scanBoard(r.db.QueryRow(
 		postgres.QueryCreateBoard,
 		board.ID,
 		board.UserID,
+		board.WorkspaceID,
 		utils.GenerateTimestamp(),
 		utils.GenerateTimestamp(),
 		board.Name,
-	)
+		board.EnforceDependencies,
+	))
 	if err != nil {
-		return fmt.Errorf("boardRepo.Create: %w", err)
+		return nil, fmt.Errorf("boardRepo.Create: %w", err)
 	}
 
-	return nil
+	return created, nil
 }
 
 func (r *Repository) GetAll(userID string) ([]boardModel.Board, error) {
-	rows, err := r.db.Query(postgres.QueryGetAllBoards, userID)
+	rows, err := r.db.Query(postgres.QueryGetAllBoards, userID, postgres.OwnBoardsOnly())
 	if err != nil {
 		return nil, fmt.Errorf("boardRepo.GetAll: %w", err)
 	}
@@ -45,9 +47,11 @@ func (r *Repository) GetAll(userID string) ([]boardModel.Board, error) {
 		if err := rows.Scan(
 			&board.ID,
 			&board.UserID,
+			&board.WorkspaceID,
 			&board.CreatedAt,
 			&board.UpdatedAt,
 			&board.Name,
+			&board.EnforceDependencies,
 		); err != nil {
 			return nil, fmt.Errorf("boardRepo.GetAll: %w", err)
 		}
@@ -62,34 +66,26 @@ func (r *Repository) GetAll(userID string) ([]boardModel.Board, error) {
 }
 
 func (r *Repository) Get(boardID string) (*boardModel.Board, error) {
-	var board boardModel.Board
-	err := r.db.QueryRow(postgres.QueryGetBoard, boardID).Scan(
-		&board.ID,
-		&board.UserID,
-		&board.CreatedAt,
-		&board.UpdatedAt,
-		&board.Name,
-	)
-
+	board, err := scanBoard(r.db.QueryRow(postgres.QueryGetBoard, boardID))
 	if err != nil {
 		return nil, fmt.Errorf("boardRepo.Get: %w", err)
 	}
 
-	return &board, nil
+	return board, nil
 }
 
-func (r *Repository) Update(boardID string, req boardModel.Request) error {
-	_, err := r.db.Exec(
+func (r *Repository) Update(boardID string, req boardModel.Request) (*boardModel.Board, error) {
+	board, err := scanBoard(r.db.QueryRow(
 		postgres.QueryUpdateBoard, 
 		utils.GenerateTimestamp(), 
 		req.Name, 
+		req.EnforceDependencies,
 		boardID, 
-	)
-	
+	))
 	if err != nil {
-		return fmt.Errorf("boardRepo.Update: %w", err)
+		return nil, fmt.Errorf("boardRepo.Update: %w", err)
 	}
-	return nil
+	return board, nil
 }
 
 func (r *Repository) Delete(boardID string) error {
@@ -100,11 +96,54 @@ func (r *Repository) Delete(boardID string) error {
 	return nil
 }
 
-func (r *Repository) GetUserByBoard(boardID string) (*string, error) {
-	var userID string
-	err := r.db.QueryRow(postgres.QueryGetUserByBoardID, boardID).Scan(&userID)
+func (r *Repository) Move(boardID, workspaceID string) (*boardModel.Board, error) {
+	board, err := scanBoard(r.db.QueryRow(postgres.QueryMoveBoard, utils.GenerateTimestamp(), workspaceID, boardID))
+	if err != nil {
+		return nil, fmt.Errorf("boardRepo.Move: %w", err)
+	}
+	return board, nil
+}
+
+func (r *Repository) GetPersonalWorkspace(userID string) (*string, error) {
+	var workspaceID string
+	err := r.db.QueryRow(postgres.QueryGetPersonalWorkspace, userID).Scan(&workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("boardRepo.GetPersonalWorkspace: %w", err)
+	}
+	return &workspaceID, nil
+}
+
+func (r *Repository) GetWorkspaceRole(workspaceID, userID string) (*string, error) {
+	var role string
+	err := r.db.QueryRow(postgres.QueryGetWorkspaceRole, workspaceID, userID).Scan(&role)
+	if err != nil {
+		return nil, fmt.Errorf("boardRepo.GetWorkspaceRole: %w", err)
+	}
+	return &role, nil
+}
+
+func (r *Repository) HasBoardAccess(boardID, userID string) (bool, error) {
+	var hasAccess bool
+	err := r.db.QueryRow(postgres.QueryHasBoardAccess, boardID, userID, postgres.OwnBoardsOnly()).Scan(&hasAccess)
 	if err != nil {
-		return nil, fmt.Errorf("boardRepo.GetUserByBoard: %w", err)
+		return false, fmt.Errorf("boardRepo.HasBoardAccess: %w", err)
 	}
-	return &userID, nil
-}
\ No newline at end of file
+	return hasAccess, nil
+}
+
+func scanBoard(row *sql.Row) (*boardModel.Board, error) {
+	var board boardModel.Board
+	err := row.Scan(
+		&board.ID,
+		&board.UserID,
+		&board.WorkspaceID,
+		&board.CreatedAt,
+		&board.UpdatedAt,
+		&board.Name,
+		&board.EnforceDependencies,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &board, nil
+}
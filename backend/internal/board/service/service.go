@@ -9,37 +9,61 @@ import (
 )
 
 var ErrBoardNotFound = errors.New("board not found")
+var ErrWorkspaceNotFound = errors.New("workspace not found")
 
 type Repository interface {
-	Create(board boardModel.Board) error
+	Create(board boardModel.Board) (*boardModel.Board, error)
 	GetAll(userID string) ([]boardModel.Board, error)
 	Get(boardID string) (*boardModel.Board, error)
-	Update(boardID string, req boardModel.Request) error
+	Update(boardID string, req boardModel.Request) (*boardModel.Board, error)
 	Delete(boardID string) error
-	GetUserByBoard(boardID string) (*string, error)
+	Move(boardID, workspaceID string) (*boardModel.Board, error)
+	GetPersonalWorkspace(userID string) (*string, error)
+	GetWorkspaceRole(workspaceID, userID string) (*string, error)
+	HasBoardAccess(boardID, userID string) (bool, error)
+}
+
+// Access is the lookup of board permissions shared with other packages,
+// implemented by the workspace repository.
+type Access interface {
+	CanManageBoard(boardID, userID string) (bool, error)
 }
 
 type Service struct {
-	repo Repository
+	repo   Repository
+	access Access
 }
 
-func NewService(repo Repository) *Service {
-	return &Service{repo: repo}
+func NewService(repo Repository, access Access) *Service {
+	return &Service{repo: repo, access: access}
 }
 
-func (s *Service) CreateBoard(userID string, req boardModel.Request) error {
+func (s *Service) CreateBoard(userID string, req boardModel.Request) (*boardModel.Board, error) {
+	workspaceID := req.WorkspaceID
+	if workspaceID == nil {
+		var err error
+		workspaceID, err = s.repo.GetPersonalWorkspace(userID)
+		if err != nil {
+			return nil, fmt.Errorf("boardService.CreateBoard: %w", err)
+		}
+	}
+
 	board := boardModel.Board{
-		ID:     utils.NewUUID(),
-		UserID: userID,
-		Name:   req.Name,
+		ID:          utils.NewUUID(),
+		UserID:      &userID,
+		WorkspaceID: *workspaceID,
+		Name:        req.Name,
+	}
+	if req.EnforceDependencies != nil {
+		board.EnforceDependencies = *req.EnforceDependencies
 	}
 
-	err := s.repo.Create(board)
+	created, err := s.repo.Create(board)
 	if err != nil {
-		return fmt.Errorf("boardService.CreateBoard: %w", err)
+		return nil, fmt.Errorf("boardService.CreateBoard: %w", err)
 	}
 
-	return nil
+	return created, nil
 }
 
 func (s *Service) GetAllBoards(userID string) ([]boardModel.Board, error) {
@@ -63,16 +87,16 @@ func (s *Service) GetBoard(boardID string) (*boardModel.Board, error) {
 	return board, nil
 }
 
-func (s *Service) UpdateBoard(boardID string, req boardModel.Request) error {
-
-	if err := s.repo.Update(boardID, req); err != nil {
+func (s *Service) UpdateBoard(boardID string, req boardModel.Request) (*boardModel.Board, error) {
+	board, err := s.repo.Update(boardID, req)
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return fmt.Errorf("boardService.UpdateBoard: %w", ErrBoardNotFound)
+			return nil, fmt.Errorf("boardService.UpdateBoard: %w", ErrBoardNotFound)
 		}
-		return fmt.Errorf("boardService.UpdateBoard: %w", err)
+		return nil, fmt.Errorf("boardService.UpdateBoard: %w", err)
 	}
 	
-	return nil
+	return board, nil
 }
 
 func (s *Service) DeleteBoard(boardID string) error {
@@ -86,13 +110,48 @@ func (s *Service) DeleteBoard(boardID string) error {
 	return nil
 }
 
-func (s *Service) GetUserByBoard(boardID string) (*string, error) {
-	userID, err := s.repo.GetUserByBoard(boardID)
+func (s *Service) MoveBoard(boardID string, req boardModel.MoveRequest) (*boardModel.Board, error) {
+	board, err := s.repo.Move(boardID, req.WorkspaceID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("boardService.MoveBoard: %w", ErrBoardNotFound)
+		}
+		return nil, fmt.Errorf("boardService.MoveBoard: %w", err)
+	}
+
+	return board, nil
+}
+
+func (s *Service) GetWorkspaceRole(workspaceID, userID string) (*string, error) {
+	role, err := s.repo.GetWorkspaceRole(workspaceID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("boardService.GetWorkspaceRole: %w", ErrWorkspaceNotFound)
+		}
+		return nil, fmt.Errorf("boardService.GetWorkspaceRole: %w", err)
+	}
+
+	return role, nil
+}
+
+func (s *Service) CanManageBoard(boardID, userID string) (bool, error) {
+	canManage, err := s.access.CanManageBoard(boardID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, fmt.Errorf("boardService.CanManageBoard: %w", ErrBoardNotFound)
+		}
+		return false, fmt.Errorf("boardService.CanManageBoard: %w", err)
+	}
+	return canManage, nil
+}
+
+func (s *Service) HasBoardAccess(boardID, userID string) (bool, error) {
+	hasAccess, err := s.repo.HasBoardAccess(boardID, userID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("boardService.GetUserByBoard: %w", ErrBoardNotFound)
+			return false, fmt.Errorf("boardService.HasBoardAccess: %w", ErrBoardNotFound)
 		}
-		return nil, fmt.Errorf("boardService.GetUserByBoard: %w", err)
+		return false, fmt.Errorf("boardService.HasBoardAccess: %w", err)
 	}
-	return userID, nil
+	return hasAccess, nil
 }
\ No newline at end of file
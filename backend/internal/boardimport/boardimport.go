@@ -0,0 +1,20 @@
+package boardimport
+
+import (
+	"database/sql"
+	boardImportHandler "kanban/internal/boardimport/handler"
+	boardImportProxy "kanban/internal/boardimport/proxy"
+	boardImportRepo "kanban/internal/boardimport/repo"
+	boardImportService "kanban/internal/boardimport/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Init(db *sql.DB, grp *gin.RouterGroup) {
+	repo := boardImportRepo.NewRepository(db)
+	service := boardImportService.NewService(repo)
+	proxy := boardImportProxy.NewProxy(service)
+	handler := boardImportHandler.NewHandler(proxy)
+
+	grp.POST("/boards/import", handler.ImportBoardHandler())
+}
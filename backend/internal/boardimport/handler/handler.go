@@ -0,0 +1,107 @@
+package boardImportHandler
+
+import (
+	"errors"
+	authctx "kanban/internal/auth/context"
+	boardImportModel "kanban/internal/boardimport/model"
+	boardImportProxy "kanban/internal/boardimport/proxy"
+	boardImportService "kanban/internal/boardimport/service"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportSize bounds the uploaded file, which is read into memory.
+const maxImportSize = 10 << 20
+
+type Proxy interface {
+	ImportBoard(userID string, query boardImportModel.Query, file boardImportModel.File) (*boardImportModel.Result, error)
+}
+
+type Handler struct {
+	proxy Proxy
+}
+
+func NewHandler(proxy Proxy) *Handler {
+	return &Handler{proxy: proxy}
+}
+
+func (h *Handler) ImportBoardHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var query boardImportModel.Query
+		if err := ctx.ShouldBindQuery(&query); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid query parameters",
+			})
+			return
+		}
+
+		ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImportSize)
+
+		var file boardImportModel.File
+		if err := ctx.ShouldBindJSON(&file); err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				ctx.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
+					"detail": "File is too large",
+				})
+				return
+			}
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid request body",
+			})
+			return
+		}
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		result, err := h.proxy.ImportBoard(userID, query, file)
+		if err != nil {
+			log.Printf("Failed to import board: %v", err)
+			h.handleError(ctx, err, "Failed to import board")
+			return
+		}
+
+		if result.DryRun {
+			ctx.JSON(http.StatusOK, result)
+			return
+		}
+		ctx.JSON(http.StatusCreated, result)
+	}
+}
+
+func (h *Handler) handleError(ctx *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, boardImportProxy.ErrForbidden):
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"detail": "Access denied",
+		})
+	case errors.Is(err, boardImportService.ErrWorkspaceNotFound):
+		ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+			"detail": "Workspace not found",
+		})
+	case errors.Is(err, boardImportService.ErrUnknownFormat):
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"detail": "File is neither a board export nor a Trello export",
+		})
+	case errors.Is(err, boardImportService.ErrUnsupportedVersion):
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"detail": "Unsupported export version",
+		})
+	case errors.Is(err, boardImportService.ErrDependencyCycle):
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"detail": "Task dependencies form a cycle",
+		})
+	default:
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"detail": message,
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package boardImportModel
+
+import (
+	exportModel "kanban/internal/export/model"
+	"time"
+)
+
+const (
+	FormatKanban = "kanban"
+	FormatTrello = "trello"
+)
+
+type Query struct {
+	DryRun      bool   `form:"dry_run"`
+	WorkspaceID string `form:"workspace_id" binding:"omitempty,uuid"`
+}
+
+// File holds the fields of both supported formats. Our export carries a
+// version, a Trello export has lists and cards instead.
+type File struct {
+	Version *int                 `json:"version"`
+	Board   *exportModel.Board   `json:"board"`
+	Columns []exportModel.Column `json:"columns"`
+	Tasks   []exportModel.Task   `json:"tasks"`
+
+	Name       string            `json:"name"`
+	Lists      []TrelloList      `json:"lists"`
+	Cards      []TrelloCard      `json:"cards"`
+	Checklists []TrelloChecklist `json:"checklists"`
+	Actions    []TrelloAction    `json:"actions"`
+}
+
+type TrelloList struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Closed bool    `json:"closed"`
+	Pos    float64 `json:"pos"`
+}
+
+type TrelloCard struct {
+	ID          string        `json:"id"`
+	IDList      string        `json:"idList"`
+	Name        string        `json:"name"`
+	Desc        string        `json:"desc"`
+	Closed      bool          `json:"closed"`
+	Pos         float64       `json:"pos"`
+	Due         *time.Time    `json:"due"`
+	DueComplete bool          `json:"dueComplete"`
+	Labels      []TrelloLabel `json:"labels"`
+	IDMembers   []string      `json:"idMembers"`
+	Attachments []struct{}    `json:"attachments"`
+}
+
+type TrelloLabel struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type TrelloChecklist struct {
+	ID         string            `json:"id"`
+	IDCard     string            `json:"idCard"`
+	Name       string            `json:"name"`
+	Pos        float64           `json:"pos"`
+	CheckItems []TrelloCheckItem `json:"checkItems"`
+}
+
+type TrelloCheckItem struct {
+	Name  string  `json:"name"`
+	State string  `json:"state"`
+	Pos   float64 `json:"pos"`
+}
+
+type TrelloAction struct {
+	Type string `json:"type"`
+	Data struct {
+		Card struct {
+			ID string `json:"id"`
+		} `json:"card"`
+	} `json:"data"`
+}
+
+// Board is what an import creates. A dry run returns it without storing
+// anything, IDs are assigned already so dependencies can refer to tasks.
+type Board struct {
+	Name                string   `json:"name"`
+	EnforceDependencies bool     `json:"enforce_dependencies"`
+	Columns             []Column `json:"columns"`
+}
+
+type Column struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Position int     `json:"position"`
+	Flag     *string `json:"flag"`
+	Tasks    []Task  `json:"tasks"`
+}
+
+type Task struct {
+	ID          string                  `json:"id"`
+	Name        string                  `json:"name"`
+	Description string                  `json:"description"`
+	Position    int                     `json:"position"`
+	Done        bool                    `json:"done"`
+	Deadline    *time.Time              `json:"deadline"`
+	BlockedBy   []string                `json:"blocked_by"`
+	Recurrence  *exportModel.Recurrence `json:"recurrence"`
+}
+
+// Warning reports an item of the source that was dropped or only partly
+// imported. SourceID is the item's ID in the imported file.
+type Warning struct {
+	Item     string `json:"item"`
+	SourceID string `json:"source_id"`
+	Name     string `json:"name"`
+	Message  string `json:"message"`
+}
+
+type Result struct {
+	DryRun   bool      `json:"dry_run"`
+	Format   string    `json:"format"`
+	BoardID  *string   `json:"board_id"`
+	Board    Board     `json:"board"`
+	Warnings []Warning `json:"warnings"`
+}
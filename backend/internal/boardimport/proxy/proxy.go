@@ -0,0 +1,38 @@
+package boardImportProxy
+
+import (
+	"errors"
+	"fmt"
+	boardImportModel "kanban/internal/boardimport/model"
+)
+
+var ErrForbidden = errors.New("access denied")
+
+type Service interface {
+	ImportBoard(userID string, query boardImportModel.Query, file boardImportModel.File) (*boardImportModel.Result, error)
+	GetWorkspaceRole(workspaceID, userID string) (*string, error)
+}
+
+type Proxy struct {
+	service Service
+}
+
+func NewProxy(service Service) *Proxy {
+	return &Proxy{service: service}
+}
+
+// ImportBoard lets members import into their workspaces, the personal one
+// by default.
+func (p *Proxy) ImportBoard(userID string, query boardImportModel.Query, file boardImportModel.File) (*boardImportModel.Result, error) {
+	if query.WorkspaceID != "" {
+		role, err := p.service.GetWorkspaceRole(query.WorkspaceID, userID)
+		if err != nil {
+			return nil, fmt.Errorf("boardImportProxy.ImportBoard: %w", err)
+		}
+		if *role == "" {
+			return nil, fmt.Errorf("boardImportProxy.ImportBoard: %w", ErrForbidden)
+		}
+	}
+
+	return p.service.ImportBoard(userID, query, file)
+}
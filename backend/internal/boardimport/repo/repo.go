@@ -0,0 +1,146 @@
+package boardImportRepo
+
+import (
+	"database/sql"
+	"fmt"
+	boardImportModel "kanban/internal/boardimport/model"
+	columnRepo "kanban/internal/column/repo"
+	"kanban/internal/history"
+	"kanban/internal/postgres"
+	taskRepo "kanban/internal/task/repo"
+	"time"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Create stores the imported board with all of its content in one
+// transaction, so a failed import leaves nothing behind.
+func (r *Repository) Create(boardID, userID, workspaceID string, board boardImportModel.Board, now time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("boardImportRepo.Create: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(postgres.QueryCreateBoard, boardID, userID, workspaceID, now, now, board.Name, board.EnforceDependencies)
+	if err != nil {
+		return fmt.Errorf("boardImportRepo.Create: %w", err)
+	}
+
+	for _, column := range board.Columns {
+		flag := ""
+		if column.Flag != nil {
+			flag = *column.Flag
+		}
+		_, err = tx.Exec(postgres.QueryCreateColumn, column.ID, boardID, now, now, column.Name, column.Position, flag)
+		if err != nil {
+			return fmt.Errorf("boardImportRepo.Create: %w", err)
+		}
+
+		for _, task := range column.Tasks {
+			_, err = tx.Exec(
+				postgres.QueryImportTask,
+				task.ID,
+				column.ID,
+				now,
+				task.Name,
+				task.Description,
+				task.Position,
+				task.Done,
+				task.Deadline,
+			)
+			if err != nil {
+				return fmt.Errorf("boardImportRepo.Create: %w", err)
+			}
+		}
+	}
+
+	// Dependencies and recurrences refer to other tasks and columns, so
+	// they go in once all of those exist.
+	for _, column := range board.Columns {
+		for _, task := range column.Tasks {
+			for _, blockerID := range task.BlockedBy {
+				if _, err = tx.Exec(postgres.QueryCreateTaskDependency, blockerID, task.ID, now); err != nil {
+					return fmt.Errorf("boardImportRepo.Create: %w", err)
+				}
+			}
+
+			if rec := task.Recurrence; rec != nil {
+				_, err = tx.Exec(
+					postgres.QueryImportTaskRecurrence,
+					task.ID,
+					rec.ColumnID,
+					now,
+					rec.RRule,
+					rec.DTStart,
+					rec.NextRunAt,
+					rec.Occurrences,
+					rec.Paused,
+				)
+				if err != nil {
+					return fmt.Errorf("boardImportRepo.Create: %w", err)
+				}
+			}
+		}
+	}
+
+	if err = recordImport(tx, userID, board); err != nil {
+		return fmt.Errorf("boardImportRepo.Create: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("boardImportRepo.Create: %w", err)
+	}
+	return nil
+}
+
+// recordImport records the creation of every column and then of every
+// task, so undo takes out the tasks before the columns they are in.
+func recordImport(tx *sql.Tx, userID string, board boardImportModel.Board) error {
+	for _, imported := range board.Columns {
+		column, err := columnRepo.LockColumn(tx, imported.ID)
+		if err != nil {
+			return err
+		}
+		if err = history.RecordColumn(tx, userID, history.ActionCreate, nil, column); err != nil {
+			return err
+		}
+	}
+
+	for _, column := range board.Columns {
+		for _, imported := range column.Tasks {
+			task, err := taskRepo.LockTask(tx, imported.ID)
+			if err != nil {
+				return err
+			}
+			if err = history.RecordTask(tx, userID, history.ActionCreate, nil, task); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *Repository) GetPersonalWorkspace(userID string) (*string, error) {
+	var workspaceID string
+	if err := r.db.QueryRow(postgres.QueryGetPersonalWorkspace, userID).Scan(&workspaceID); err != nil {
+		return nil, fmt.Errorf("boardImportRepo.GetPersonalWorkspace: %w", err)
+	}
+	return &workspaceID, nil
+}
+
+// GetWorkspaceRole returns the role of the user in the workspace, empty if
+// the user is not a member.
+func (r *Repository) GetWorkspaceRole(workspaceID, userID string) (*string, error) {
+	var role string
+	if err := r.db.QueryRow(postgres.QueryGetWorkspaceRole, workspaceID, userID).Scan(&role); err != nil {
+		return nil, fmt.Errorf("boardImportRepo.GetWorkspaceRole: %w", err)
+	}
+	return &role, nil
+}
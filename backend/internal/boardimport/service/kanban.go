@@ -0,0 +1,111 @@
+package boardImportService
+
+import (
+	"cmp"
+	boardImportModel "kanban/internal/boardimport/model"
+	exportModel "kanban/internal/export/model"
+	"kanban/internal/rrule"
+	"slices"
+)
+
+var columnFlags = []string{"in_progress", "done"}
+
+// planKanban maps our own export. Apart from limits everything carries
+// over, IDs are replaced so the same file can be imported again.
+func planKanban(file boardImportModel.File) *planner {
+	p := &planner{board: boardImportModel.Board{
+		Name:                file.Board.Name,
+		EnforceDependencies: file.Board.EnforceDependencies,
+		Columns:             []boardImportModel.Column{},
+	}, warnings: []boardImportModel.Warning{}}
+	if p.board.Name == "" {
+		p.warn("board", file.Board.ID, "", "empty name replaced with \""+untitled+"\"")
+		p.board.Name = untitled
+	}
+
+	sourceColumns := slices.Clone(file.Columns)
+	slices.SortStableFunc(sourceColumns, func(a, b exportModel.Column) int {
+		return cmp.Compare(a.Position, b.Position)
+	})
+
+	columns := map[string]int{}
+	columnIDs := map[string]string{}
+	for _, source := range sourceColumns {
+		flag := source.Flag
+		if flag != nil && !slices.Contains(columnFlags, *flag) {
+			p.warn("column", source.ID, source.Name, "unknown flag \""+*flag+"\" dropped")
+			flag = nil
+		}
+		if i := p.addColumn("column", source.ID, source.Name, flag); i >= 0 {
+			columns[source.ID] = i
+			columnIDs[source.ID] = p.board.Columns[i].ID
+		}
+	}
+
+	sourceTasks := slices.Clone(file.Tasks)
+	slices.SortStableFunc(sourceTasks, func(a, b exportModel.Task) int {
+		return cmp.Compare(a.Position, b.Position)
+	})
+
+	type placement struct{ column, index int }
+	placed := map[string]placement{}
+	taskIDs := map[string]string{}
+	for _, source := range sourceTasks {
+		column, ok := columns[source.ColumnID]
+		if !ok {
+			p.warn("task", source.ID, source.Name, "its column was not imported")
+			continue
+		}
+
+		index := p.addTask("task", source.ID, column, boardImportModel.Task{
+			Name:        source.Name,
+			Description: source.Description,
+			Done:        source.Done,
+			Deadline:    source.Deadline,
+		})
+		if index >= 0 {
+			placed[source.ID] = placement{column, index}
+			taskIDs[source.ID] = p.board.Columns[column].Tasks[index].ID
+		}
+	}
+
+	// Links between tasks are resolved once all of them have their new IDs.
+	for _, source := range sourceTasks {
+		at, ok := placed[source.ID]
+		if !ok {
+			continue
+		}
+		task := &p.board.Columns[at.column].Tasks[at.index]
+
+		for _, blockerID := range source.BlockedBy {
+			id, ok := taskIDs[blockerID]
+			switch {
+			case !ok:
+				p.warn("task", source.ID, source.Name, "blocker "+blockerID+" was not imported")
+			case id == task.ID:
+				p.warn("task", source.ID, source.Name, "task cannot block itself")
+			case slices.Contains(task.BlockedBy, id):
+				p.warn("task", source.ID, source.Name, "duplicate blocker "+blockerID+" dropped")
+			default:
+				task.BlockedBy = append(task.BlockedBy, id)
+			}
+		}
+
+		if rec := source.Recurrence; rec != nil {
+			if _, err := rrule.Parse(rec.RRule); err != nil {
+				p.warn("task", source.ID, source.Name, "invalid recurrence rule dropped")
+				continue
+			}
+			columnID, ok := columnIDs[rec.ColumnID]
+			if !ok {
+				p.warn("task", source.ID, source.Name, "recurrence column was not imported, recurrence dropped")
+				continue
+			}
+			recurrence := *rec
+			recurrence.ColumnID = columnID
+			task.Recurrence = &recurrence
+		}
+	}
+
+	return p
+}
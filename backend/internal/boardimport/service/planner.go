@@ -0,0 +1,110 @@
+package boardImportService
+
+import (
+	"fmt"
+	boardImportModel "kanban/internal/boardimport/model"
+	columnRepo "kanban/internal/column/repo"
+	taskRepo "kanban/internal/task/repo"
+	"kanban/internal/utils"
+)
+
+const untitled = "Untitled"
+
+// planner builds the board to import and collects warnings about what had
+// to be left out. It applies the same column and task limits as the API.
+type planner struct {
+	board    boardImportModel.Board
+	warnings []boardImportModel.Warning
+}
+
+func (p *planner) warn(item, sourceID, name, message string) {
+	p.warnings = append(p.warnings, boardImportModel.Warning{
+		Item:     item,
+		SourceID: sourceID,
+		Name:     name,
+		Message:  message,
+	})
+}
+
+// addColumn appends a column and returns its index, or -1 when the board
+// is full.
+func (p *planner) addColumn(item, sourceID, name string, flag *string) int {
+	if len(p.board.Columns) >= columnRepo.MaxColumns {
+		p.warn(item, sourceID, name, fmt.Sprintf("board already has the maximum of %d columns", columnRepo.MaxColumns))
+		return -1
+	}
+	if name == "" {
+		p.warn(item, sourceID, name, "empty name replaced with \""+untitled+"\"")
+		name = untitled
+	}
+
+	p.board.Columns = append(p.board.Columns, boardImportModel.Column{
+		ID:       utils.NewUUID(),
+		Name:     name,
+		Position: len(p.board.Columns) + 1,
+		Flag:     flag,
+		Tasks:    []boardImportModel.Task{},
+	})
+	return len(p.board.Columns) - 1
+}
+
+// hasCycle reports whether the blockers of the tasks form a cycle, which
+// the dependency endpoints would not have allowed.
+func (p *planner) hasCycle() bool {
+	blockers := map[string][]string{}
+	for _, column := range p.board.Columns {
+		for _, task := range column.Tasks {
+			blockers[task.ID] = task.BlockedBy
+		}
+	}
+
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := map[string]int{}
+	var visit func(id string) bool
+	visit = func(id string) bool {
+		switch state[id] {
+		case visiting:
+			return true
+		case done:
+			return false
+		}
+		state[id] = visiting
+		for _, blockerID := range blockers[id] {
+			if visit(blockerID) {
+				return true
+			}
+		}
+		state[id] = done
+		return false
+	}
+
+	for id := range blockers {
+		if visit(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// addTask appends the task to a column and returns its index there, or -1
+// when the column is full.
+func (p *planner) addTask(item, sourceID string, column int, task boardImportModel.Task) int {
+	tasks := p.board.Columns[column].Tasks
+	if len(tasks) >= taskRepo.MaxTasks {
+		p.warn(item, sourceID, task.Name, fmt.Sprintf("column already has the maximum of %d tasks", taskRepo.MaxTasks))
+		return -1
+	}
+	if task.Name == "" {
+		p.warn(item, sourceID, task.Name, "empty name replaced with \""+untitled+"\"")
+		task.Name = untitled
+	}
+
+	task.ID = utils.NewUUID()
+	task.Position = len(tasks) + 1
+	task.BlockedBy = []string{}
+	p.board.Columns[column].Tasks = append(tasks, task)
+	return len(tasks)
+}
@@ -0,0 +1,51 @@
+package boardImportService
+
+import (
+	boardImportModel "kanban/internal/boardimport/model"
+	exportModel "kanban/internal/export/model"
+	"testing"
+)
+
+func TestPlanKanbanDependencies(t *testing.T) {
+	tests := []struct {
+		name      string
+		blockedBy map[string][]string
+		cycle     bool
+		warnings  int
+	}{
+		{"chain", map[string][]string{"b": {"a"}, "c": {"b"}}, false, 0},
+		{"shared blocker", map[string][]string{"b": {"a"}, "c": {"a", "b"}}, false, 0},
+		{"duplicate blocker", map[string][]string{"b": {"a", "a"}}, false, 1},
+		{"self", map[string][]string{"a": {"a"}}, false, 1},
+		{"two tasks", map[string][]string{"a": {"b"}, "b": {"a"}}, true, 0},
+		{"three tasks", map[string][]string{"a": {"c"}, "b": {"a"}, "c": {"b"}}, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version := exportModel.Version
+			file := boardImportModel.File{
+				Version: &version,
+				Board:   &exportModel.Board{ID: "board", Name: "Board"},
+				Columns: []exportModel.Column{{ID: "column", Name: "Todo", Position: 1}},
+			}
+			for i, id := range []string{"a", "b", "c"} {
+				file.Tasks = append(file.Tasks, exportModel.Task{
+					ID:        id,
+					ColumnID:  "column",
+					Name:      id,
+					Position:  i + 1,
+					BlockedBy: tt.blockedBy[id],
+				})
+			}
+
+			p := planKanban(file)
+			if got := p.hasCycle(); got != tt.cycle {
+				t.Errorf("hasCycle: got %v, want %v", got, tt.cycle)
+			}
+			if got := len(p.warnings); got != tt.warnings {
+				t.Errorf("warnings: got %v, want %d", p.warnings, tt.warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package boardImportService
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	boardImportModel "kanban/internal/boardimport/model"
+	exportModel "kanban/internal/export/model"
+	"kanban/internal/utils"
+	"time"
+)
+
+var ErrUnknownFormat = errors.New("file is neither a board export nor a Trello export")
+var ErrUnsupportedVersion = errors.New("unsupported export version")
+var ErrWorkspaceNotFound = errors.New("workspace not found")
+var ErrDependencyCycle = errors.New("task dependencies form a cycle")
+
+type Repository interface {
+	Create(boardID, userID, workspaceID string, board boardImportModel.Board, now time.Time) error
+	GetPersonalWorkspace(userID string) (*string, error)
+	GetWorkspaceRole(workspaceID, userID string) (*string, error)
+}
+
+type Service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// ImportBoard creates a new board from our export or a Trello export.
+// Whatever cannot be mapped is skipped and reported as a warning. A dry run
+// returns the same result without storing the board.
+func (s *Service) ImportBoard(userID string, query boardImportModel.Query, file boardImportModel.File) (*boardImportModel.Result, error) {
+	var p *planner
+	var format string
+	switch {
+	case file.Version != nil:
+		if *file.Version < 1 || *file.Version > exportModel.Version {
+			return nil, fmt.Errorf("boardImportService.ImportBoard: %w", ErrUnsupportedVersion)
+		}
+		if file.Board == nil {
+			return nil, fmt.Errorf("boardImportService.ImportBoard: %w", ErrUnknownFormat)
+		}
+		p, format = planKanban(file), boardImportModel.FormatKanban
+	case file.Lists != nil || file.Cards != nil:
+		p, format = planTrello(file), boardImportModel.FormatTrello
+	default:
+		return nil, fmt.Errorf("boardImportService.ImportBoard: %w", ErrUnknownFormat)
+	}
+	if p.hasCycle() {
+		return nil, fmt.Errorf("boardImportService.ImportBoard: %w", ErrDependencyCycle)
+	}
+
+	result := &boardImportModel.Result{
+		DryRun:   query.DryRun,
+		Format:   format,
+		Board:    p.board,
+		Warnings: p.warnings,
+	}
+	if query.DryRun {
+		return result, nil
+	}
+
+	workspaceID := &query.WorkspaceID
+	if query.WorkspaceID == "" {
+		var err error
+		workspaceID, err = s.repo.GetPersonalWorkspace(userID)
+		if err != nil {
+			return nil, fmt.Errorf("boardImportService.ImportBoard: %w", err)
+		}
+	}
+
+	boardID := utils.NewUUID()
+	if err := s.repo.Create(boardID, userID, *workspaceID, p.board, utils.GenerateTimestamp()); err != nil {
+		return nil, fmt.Errorf("boardImportService.ImportBoard: %w", err)
+	}
+	result.BoardID = &boardID
+
+	return result, nil
+}
+
+// GetWorkspaceRole returns the role of the user in the workspace, empty if
+// the user is not a member.
+func (s *Service) GetWorkspaceRole(workspaceID, userID string) (*string, error) {
+	role, err := s.repo.GetWorkspaceRole(workspaceID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("boardImportService.GetWorkspaceRole: %w", ErrWorkspaceNotFound)
+		}
+		return nil, fmt.Errorf("boardImportService.GetWorkspaceRole: %w", err)
+	}
+
+	return role, nil
+}
@@ -0,0 +1,128 @@
+package boardImportService
+
+import (
+	"cmp"
+	"fmt"
+	boardImportModel "kanban/internal/boardimport/model"
+	"slices"
+	"strings"
+)
+
+// planTrello maps a Trello board export. Lists become columns and cards
+// become tasks, checklists are appended to the description. Archived items
+// are skipped, labels, members, attachments and comments have no
+// counterpart and are reported.
+func planTrello(file boardImportModel.File) *planner {
+	p := &planner{board: boardImportModel.Board{
+		Name:    file.Name,
+		Columns: []boardImportModel.Column{},
+	}, warnings: []boardImportModel.Warning{}}
+	if p.board.Name == "" {
+		p.warn("board", "", "", "empty name replaced with \""+untitled+"\"")
+		p.board.Name = untitled
+	}
+
+	lists := slices.Clone(file.Lists)
+	slices.SortStableFunc(lists, func(a, b boardImportModel.TrelloList) int {
+		return cmp.Compare(a.Pos, b.Pos)
+	})
+
+	columns := map[string]int{}
+	for _, list := range lists {
+		if list.Closed {
+			p.warn("list", list.ID, list.Name, "archived list skipped")
+			continue
+		}
+		if i := p.addColumn("list", list.ID, list.Name, nil); i >= 0 {
+			columns[list.ID] = i
+		}
+	}
+
+	checklists := map[string][]boardImportModel.TrelloChecklist{}
+	for _, checklist := range file.Checklists {
+		checklists[checklist.IDCard] = append(checklists[checklist.IDCard], checklist)
+	}
+
+	comments := map[string]int{}
+	for _, action := range file.Actions {
+		if action.Type == "commentCard" {
+			comments[action.Data.Card.ID]++
+		}
+	}
+
+	cards := slices.Clone(file.Cards)
+	slices.SortStableFunc(cards, func(a, b boardImportModel.TrelloCard) int {
+		return cmp.Compare(a.Pos, b.Pos)
+	})
+
+	for _, card := range cards {
+		if card.Closed {
+			p.warn("card", card.ID, card.Name, "archived card skipped")
+			continue
+		}
+		column, ok := columns[card.IDList]
+		if !ok {
+			p.warn("card", card.ID, card.Name, "its list was not imported")
+			continue
+		}
+
+		index := p.addTask("card", card.ID, column, boardImportModel.Task{
+			Name:        card.Name,
+			Description: describeCard(card, checklists[card.ID]),
+			Done:        card.DueComplete,
+			Deadline:    card.Due,
+		})
+		if index < 0 {
+			continue
+		}
+
+		if len(card.Labels) > 0 {
+			p.warn("card", card.ID, card.Name, fmt.Sprintf("%d labels not imported", len(card.Labels)))
+		}
+		if len(card.IDMembers) > 0 {
+			p.warn("card", card.ID, card.Name, fmt.Sprintf("%d members not imported", len(card.IDMembers)))
+		}
+		if len(card.Attachments) > 0 {
+			p.warn("card", card.ID, card.Name, fmt.Sprintf("%d attachments not imported", len(card.Attachments)))
+		}
+		if comments[card.ID] > 0 {
+			p.warn("card", card.ID, card.Name, fmt.Sprintf("%d comments not imported", comments[card.ID]))
+		}
+	}
+
+	return p
+}
+
+// describeCard appends the checklists of a card to its description as
+// Markdown task lists.
+func describeCard(card boardImportModel.TrelloCard, checklists []boardImportModel.TrelloChecklist) string {
+	if len(checklists) == 0 {
+		return card.Desc
+	}
+
+	slices.SortStableFunc(checklists, func(a, b boardImportModel.TrelloChecklist) int {
+		return cmp.Compare(a.Pos, b.Pos)
+	})
+
+	var b strings.Builder
+	b.WriteString(card.Desc)
+	for _, checklist := range checklists {
+		if b.Len() > 0 {
+			b.WriteString("\n\n")
+		}
+		fmt.Fprintf(&b, "**%s**\n", checklist.Name)
+
+		items := slices.Clone(checklist.CheckItems)
+		slices.SortStableFunc(items, func(a, b boardImportModel.TrelloCheckItem) int {
+			return cmp.Compare(a.Pos, b.Pos)
+		})
+		for _, item := range items {
+			mark := " "
+			if item.State == "complete" {
+				mark = "x"
+			}
+			fmt.Fprintf(&b, "\n- [%s] %s", mark, item.Name)
+		}
+	}
+	return b.String()
+}
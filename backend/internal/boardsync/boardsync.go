@@ -0,0 +1,20 @@
+package boardsync
+
+import (
+	"database/sql"
+	boardSyncHandler "kanban/internal/boardsync/handler"
+	boardSyncProxy "kanban/internal/boardsync/proxy"
+	boardSyncRepo "kanban/internal/boardsync/repo"
+	boardSyncService "kanban/internal/boardsync/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Init(db *sql.DB, grp *gin.RouterGroup) {
+	repo := boardSyncRepo.NewRepository(db)
+	service := boardSyncService.NewService(repo)
+	proxy := boardSyncProxy.NewProxy(service)
+	handler := boardSyncHandler.NewHandler(proxy)
+
+	grp.GET("/sync", handler.GetChangesHandler())
+}
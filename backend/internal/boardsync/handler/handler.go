@@ -0,0 +1,53 @@
+package boardSyncHandler
+
+import (
+	authctx "kanban/internal/auth/context"
+	boardSyncModel "kanban/internal/boardsync/model"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Proxy interface {
+	GetChanges(userID string, query boardSyncModel.Query) (*boardSyncModel.Changes, error)
+}
+
+type Handler struct {
+	proxy Proxy
+}
+
+func NewHandler(proxy Proxy) *Handler {
+	return &Handler{proxy: proxy}
+}
+
+func (h *Handler) GetChangesHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var query boardSyncModel.Query
+		if err := ctx.ShouldBindQuery(&query); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid query parameters",
+			})
+			return
+		}
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		changes, err := h.proxy.GetChanges(userID, query)
+		if err != nil {
+			log.Printf("Failed to get changes: %v", err)
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"detail": "Failed to get changes",
+			})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, changes)
+	}
+}
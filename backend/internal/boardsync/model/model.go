@@ -0,0 +1,36 @@
+package boardSyncModel
+
+import (
+	boardModel "kanban/internal/board/model"
+	columnModel "kanban/internal/column/model"
+	taskModel "kanban/internal/task/model"
+)
+
+const (
+	EntityBoard  = "board"
+	EntityColumn = "column"
+	EntityTask   = "task"
+)
+
+type Query struct {
+	Since int64 `form:"since" binding:"omitempty,min=0"`
+}
+
+// Tombstone reports an entity that was deleted or is no longer visible to
+// the user. Columns and tasks of a tombstoned board or column are gone with
+// it and get no tombstones of their own.
+type Tombstone struct {
+	Entity  string `json:"entity"`
+	ID      string `json:"id"`
+	BoardID string `json:"board_id"`
+}
+
+// Changes holds everything that changed after the cursor of the request.
+// Cursor is passed as since to the next sync.
+type Changes struct {
+	Cursor  int64                `json:"cursor,string"`
+	Boards  []boardModel.Board   `json:"boards"`
+	Columns []columnModel.Column `json:"columns"`
+	Tasks   []taskModel.Task     `json:"tasks"`
+	Deleted []Tombstone          `json:"deleted"`
+}
@@ -0,0 +1,23 @@
+package boardSyncProxy
+
+import (
+	boardSyncModel "kanban/internal/boardsync/model"
+)
+
+type Service interface {
+	GetChanges(userID string, query boardSyncModel.Query) (*boardSyncModel.Changes, error)
+}
+
+type Proxy struct {
+	service Service
+}
+
+func NewProxy(service Service) *Proxy {
+	return &Proxy{service: service}
+}
+
+// GetChanges needs no checks, the queries only return what the user can
+// see.
+func (p *Proxy) GetChanges(userID string, query boardSyncModel.Query) (*boardSyncModel.Changes, error) {
+	return p.service.GetChanges(userID, query)
+}
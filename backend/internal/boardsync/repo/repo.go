@@ -0,0 +1,177 @@
+package boardSyncRepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	boardModel "kanban/internal/board/model"
+	boardSyncModel "kanban/internal/boardsync/model"
+	columnModel "kanban/internal/column/model"
+	"kanban/internal/postgres"
+	taskModel "kanban/internal/task/model"
+
+	"github.com/lib/pq"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// GetChanges reads the changes visible to the user in one repeatable read
+// transaction. The cursor comes from the same snapshot, so everything
+// committed after it is picked up by the next sync.
+func (r *Repository) GetChanges(userID string, since int64) (*boardSyncModel.Changes, error) {
+	tx, err := r.db.BeginTx(context.Background(), &sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+		ReadOnly:  true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("boardSyncRepo.GetChanges: %w", err)
+	}
+	defer tx.Rollback()
+
+	changes := &boardSyncModel.Changes{}
+	if err = tx.QueryRow(postgres.QueryGetSyncCursor).Scan(&changes.Cursor); err != nil {
+		return nil, fmt.Errorf("boardSyncRepo.GetChanges: %w", err)
+	}
+
+	if changes.Boards, err = getBoards(tx, userID, since); err != nil {
+		return nil, fmt.Errorf("boardSyncRepo.GetChanges: %w", err)
+	}
+	if changes.Columns, err = getColumns(tx, userID, since); err != nil {
+		return nil, fmt.Errorf("boardSyncRepo.GetChanges: %w", err)
+	}
+	if changes.Tasks, err = getTasks(tx, userID, since); err != nil {
+		return nil, fmt.Errorf("boardSyncRepo.GetChanges: %w", err)
+	}
+
+	// A full sync has nothing to delete on the client.
+	changes.Deleted = []boardSyncModel.Tombstone{}
+	if since > 0 {
+		if changes.Deleted, err = getTombstones(tx, userID, since); err != nil {
+			return nil, fmt.Errorf("boardSyncRepo.GetChanges: %w", err)
+		}
+	}
+
+	return changes, nil
+}
+
+func getBoards(tx *sql.Tx, userID string, since int64) ([]boardModel.Board, error) {
+	rows, err := tx.Query(postgres.QueryGetSyncBoards, userID, since, postgres.OwnBoardsOnly())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	boards := []boardModel.Board{}
+	for rows.Next() {
+		var board boardModel.Board
+		if err = rows.Scan(
+			&board.ID,
+			&board.UserID,
+			&board.WorkspaceID,
+			&board.CreatedAt,
+			&board.UpdatedAt,
+			&board.Name,
+			&board.EnforceDependencies,
+		); err != nil {
+			return nil, err
+		}
+		boards = append(boards, board)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return boards, nil
+}
+
+func getColumns(tx *sql.Tx, userID string, since int64) ([]columnModel.Column, error) {
+	rows, err := tx.Query(postgres.QueryGetSyncColumns, userID, since, postgres.OwnBoardsOnly())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := []columnModel.Column{}
+	for rows.Next() {
+		var column columnModel.Column
+		if err = rows.Scan(
+			&column.ID,
+			&column.BoardID,
+			&column.CreatedAt,
+			&column.UpdatedAt,
+			&column.Name,
+			&column.Position,
+			&column.Flag,
+		); err != nil {
+			return nil, err
+		}
+		columns = append(columns, column)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+func getTasks(tx *sql.Tx, userID string, since int64) ([]taskModel.Task, error) {
+	rows, err := tx.Query(postgres.QueryGetSyncTasks, userID, since, postgres.OwnBoardsOnly())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := []taskModel.Task{}
+	for rows.Next() {
+		var task taskModel.Task
+		if err = rows.Scan(
+			&task.ID,
+			&task.ColumnID,
+			&task.CreatedAt,
+			&task.UpdatedAt,
+			&task.Name,
+			&task.Description,
+			&task.Position,
+			&task.Done,
+			&task.Deadline,
+			pq.Array(&task.BlockedBy),
+			pq.Array(&task.Blocks),
+		); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func getTombstones(tx *sql.Tx, userID string, since int64) ([]boardSyncModel.Tombstone, error) {
+	rows, err := tx.Query(postgres.QueryGetSyncTombstones, userID, since, postgres.OwnBoardsOnly())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tombstones := []boardSyncModel.Tombstone{}
+	for rows.Next() {
+		var tombstone boardSyncModel.Tombstone
+		if err = rows.Scan(&tombstone.Entity, &tombstone.ID, &tombstone.BoardID); err != nil {
+			return nil, err
+		}
+		tombstones = append(tombstones, tombstone)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return tombstones, nil
+}
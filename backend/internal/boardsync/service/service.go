@@ -0,0 +1,29 @@
+package boardSyncService
+
+import (
+	"fmt"
+	boardSyncModel "kanban/internal/boardsync/model"
+)
+
+type Repository interface {
+	GetChanges(userID string, since int64) (*boardSyncModel.Changes, error)
+}
+
+type Service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// GetChanges returns the boards, columns and tasks that changed after the
+// cursor, everything when it is zero. A change may be returned by two
+// consecutive syncs, clients have to apply them idempotently.
+func (s *Service) GetChanges(userID string, query boardSyncModel.Query) (*boardSyncModel.Changes, error) {
+	changes, err := s.repo.GetChanges(userID, query.Since)
+	if err != nil {
+		return nil, fmt.Errorf("boardSyncService.GetChanges: %w", err)
+	}
+	return changes, nil
+}
@@ -7,6 +7,7 @@ import (
 	columnProxy "kanban/internal/column/proxy"
 	columnRepo "kanban/internal/column/repo"
 	columnService "kanban/internal/column/service"
+	"kanban/internal/config"
 	"log"
 	"net/http"
 
@@ -14,10 +15,10 @@ import (
 )
 
 type Proxy interface {
-	CreateColumn(boardID, userID string, req columnModel.CreateRequest) error
+	CreateColumn(boardID, userID string, req columnModel.CreateRequest) (*columnModel.Column, error)
 	GetAllColumns(boardID, userID string) ([]columnModel.Column, error)
 	GetColumn(columnID, userID string) (*columnModel.Column, error)
-	UpdateColumn(columnID, userID string, req columnModel.UpdateRequest) error
+	UpdateColumn(columnID, userID string, req columnModel.UpdateRequest) (*columnModel.Column, error)
 	DeleteColumn(columnID, userID string) error
 }
 
@@ -49,14 +50,15 @@ func (h *Handler) CreateColumnHandler() gin.HandlerFunc {
 
 		boardID := ctx.Param("id")
 
-		err := h.proxy.CreateColumn(boardID, userID, req);
+		column, err := h.proxy.CreateColumn(boardID, userID, req)
 		if err != nil {
 			log.Printf("Failed to create column: %v", err)
 			h.handleError(ctx, err, "Failed to create column")
 			return
 		}
 
-		ctx.Status(http.StatusCreated)
+		ctx.Header("Location", config.Get().APIPrefix+"/columns/"+column.ID)
+		ctx.JSON(http.StatusCreated, column)
 	}
 }
 
@@ -126,14 +128,14 @@ func (h *Handler) UpdateColumnHandler() gin.HandlerFunc {
 			return
 		}
 
-		err := h.proxy.UpdateColumn(id, userID, req);
+		column, err := h.proxy.UpdateColumn(id, userID, req)
 		if err != nil {
 			log.Printf("Failed to update column: %v", err)
 			h.handleError(ctx, err, "Failed to update column")
 			return
 		}
 
-		ctx.Status(http.StatusOK)
+		ctx.JSON(http.StatusOK, column)
 	}
 }
 
@@ -9,13 +9,16 @@ type Column struct {
 	UpdatedAt time.Time `json:"updated_at"`
 	Name      string    `json:"name"`
 	Position  int       `json:"position"`
+	Flag      *string   `json:"flag"`
 }
 
 type CreateRequest struct {
 	Name string `json:"name" binding:"required"`
+	Flag string `json:"flag" binding:"omitempty,oneof=in_progress done"`
 }
 
 type UpdateRequest struct {
 	Name     *string `json:"name"`
 	Position *int    `json:"position"`
+	Flag     *string `json:"flag" binding:"omitempty,oneof='' in_progress done"`
 }
\ No newline at end of file
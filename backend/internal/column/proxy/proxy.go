@@ -9,13 +9,13 @@ import (
 var ErrForbidden = errors.New("access denied")
 
 type Service interface {
-	CreateColumn(boardID string, req columnModel.CreateRequest) error
+	CreateColumn(boardID, userID string, req columnModel.CreateRequest) (*columnModel.Column, error)
 	GetAllColumns(boardID string) ([]columnModel.Column, error)
 	GetColumn(boardID string) (*columnModel.Column, error)
-	UpdateColumn(columnID string, req columnModel.UpdateRequest) error
-	DeleteColumn(columnID string) error
-	GetUserByBoard(boardID string) (*string, error)
-	GetUserByColumn(columnID string) (*string, error)
+	UpdateColumn(columnID, userID string, req columnModel.UpdateRequest) (*columnModel.Column, error)
+	DeleteColumn(columnID, userID string) error
+	HasBoardAccess(boardID, userID string) (bool, error)
+	HasColumnAccess(columnID, userID string) (bool, error)
 }
 
 type Proxy struct {
@@ -26,26 +26,26 @@ func NewProxy(service Service) *Proxy {
 	return &Proxy{service: service}
 }
 
-func (p *Proxy) CreateColumn(boardID, userID string, req columnModel.CreateRequest) error {
-	isOwner, err := p.checkBoardOwnership(boardID, userID)
+func (p *Proxy) CreateColumn(boardID, userID string, req columnModel.CreateRequest) (*columnModel.Column, error) {
+	hasAccess, err := p.checkBoardAccess(boardID, userID)
 	if err != nil {
-		return fmt.Errorf("columnProxy.CreateColumn: %w", err)
+		return nil, fmt.Errorf("columnProxy.CreateColumn: %w", err)
 	}
 
-	if isOwner {
-		return p.service.CreateColumn(boardID, req)
+	if hasAccess {
+		return p.service.CreateColumn(boardID, userID, req)
 	} else {
-		return fmt.Errorf("columnProxy.CreateColumn: %w", ErrForbidden)
+		return nil, fmt.Errorf("columnProxy.CreateColumn: %w", ErrForbidden)
 	}
 }
 
 func (p *Proxy) GetAllColumns(boardID, userID string) ([]columnModel.Column, error) {
-	isOwner, err := p.checkBoardOwnership(boardID, userID)
+	hasAccess, err := p.checkBoardAccess(boardID, userID)
 	if err != nil {
 		return nil, fmt.Errorf("columnProxy.GetAllColumns: %w", err)
 	}
 
-	if isOwner {
+	if hasAccess {
 		return p.service.GetAllColumns(boardID)
 	} else {
 		return nil, fmt.Errorf("columnProxy.GetAllColumns: %w", ErrForbidden)
@@ -53,58 +53,58 @@ func (p *Proxy) GetAllColumns(boardID, userID string) ([]columnModel.Column, err
 }
 
 func (p *Proxy) GetColumn(columnID, userID string) (*columnModel.Column, error) {
-	isOwner, err := p.checkColumnOwnership(columnID, userID)
+	hasAccess, err := p.checkColumnAccess(columnID, userID)
 	if err != nil {
 		return nil, fmt.Errorf("columnProxy.GetColumn: %w", err)
 	}
 
-	if isOwner {
+	if hasAccess {
 		return p.service.GetColumn(columnID)
 	} else {
 		return nil, fmt.Errorf("columnProxy.GetColumn: %w", ErrForbidden)
 	}
 }
 
-func (p *Proxy) UpdateColumn(columnID, userID string, req columnModel.UpdateRequest) error {
-	isOwner, err := p.checkColumnOwnership(columnID, userID)
+func (p *Proxy) UpdateColumn(columnID, userID string, req columnModel.UpdateRequest) (*columnModel.Column, error) {
+	hasAccess, err := p.checkColumnAccess(columnID, userID)
 	if err != nil {
-		return fmt.Errorf("columnProxy.UpdateColumn: %w", err)
+		return nil, fmt.Errorf("columnProxy.UpdateColumn: %w", err)
 	}
 
-	if isOwner {
-		return p.service.UpdateColumn(columnID, req)
+	if hasAccess {
+		return p.service.UpdateColumn(columnID, userID, req)
 	} else {
-		return fmt.Errorf("columnProxy.UpdateColumn: %w", ErrForbidden)
+		return nil, fmt.Errorf("columnProxy.UpdateColumn: %w", ErrForbidden)
 	}
 }
 
 func (p *Proxy) DeleteColumn(columnID, userID string) error {
-	isOwner, err := p.checkColumnOwnership(columnID, userID)
+	hasAccess, err := p.checkColumnAccess(columnID, userID)
 	if err != nil {
 		return fmt.Errorf("columnProxy.UpdateColumn: %w", err)
 	}
 
-	if isOwner {
-		return p.service.DeleteColumn(columnID)
+	if hasAccess {
+		return p.service.DeleteColumn(columnID, userID)
 	} else {
 		return fmt.Errorf("columnProxy.DeleteColumn: %w", ErrForbidden)
 	}
 }
 
-func (p *Proxy) checkBoardOwnership(boardID, userID string) (bool, error) {
-	realUserID, err := p.service.GetUserByBoard(boardID)
+func (p *Proxy) checkBoardAccess(boardID, userID string) (bool, error) {
+	hasAccess, err := p.service.HasBoardAccess(boardID, userID)
 	if err != nil {
-		return false, fmt.Errorf("columnProxy.checkBoardOwnership: %w", err)
+		return false, fmt.Errorf("columnProxy.checkBoardAccess: %w", err)
 	}
 
-	return *realUserID == userID, nil
+	return hasAccess, nil
 }
 
-func (p *Proxy) checkColumnOwnership(columnID, userID string) (bool, error) {
-	realUserID, err := p.service.GetUserByColumn(columnID)
+func (p *Proxy) checkColumnAccess(columnID, userID string) (bool, error) {
+	hasAccess, err := p.service.HasColumnAccess(columnID, userID)
 	if err != nil {
-		return false, fmt.Errorf("columnProxy.checkColumnOwnership: %w", err)
+		return false, fmt.Errorf("columnProxy.checkColumnAccess: %w", err)
 	}
 
-	return *realUserID == userID, nil
+	return hasAccess, nil
 }
@@ -5,11 +5,12 @@ import (
 	"errors"
 	"fmt"
 	columnModel "kanban/internal/column/model"
+	"kanban/internal/history"
 	"kanban/internal/postgres"
 	"kanban/internal/utils"
 )
 
-const maxColumns int = 42
+const MaxColumns int = 42
 
 var ErrColumnLimitReached = errors.New("column limit reached")
 var ErrIncorrectPosition = errors.New("column position is greater than possible or not positive")
@@ -22,41 +23,24 @@ func NewRepository(db *sql.DB) *Repository {
 	return &Repository{db: db}
 }
 
-func (r *Repository) Create(column columnModel.Column) error {
+func (r *Repository) Create(column columnModel.Column, userID string) (*columnModel.Column, error) {
 	tx, err := r.db.Begin()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer tx.Rollback()
 
-	var count int
-	err = tx.QueryRow(postgres.QueryGetColumnsCount, column.BoardID).Scan(&count)
-	if err != nil {
-		return err
-	}
-	if count >= maxColumns {
-		return ErrColumnLimitReached
-	}
-
-	err = tx.QueryRow(postgres.QueryGetMaxColumnPosition, column.BoardID).Scan(&column.Position)
+	created, err := CreateColumn(tx, column, utils.GenerateTimestamp())
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = tx.Exec(
-		postgres.QueryCreateColumn,
-		column.ID,
-		column.BoardID,
-		utils.GenerateTimestamp(),
-		utils.GenerateTimestamp(),
-		column.Name,
-		column.Position,
-	)
+	err = history.RecordColumn(tx, userID, history.ActionCreate, nil, created)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return tx.Commit()
+	return created, tx.Commit()
 }
 
 func (r *Repository) GetAll(boardID string) ([]columnModel.Column, error) {
@@ -76,6 +60,7 @@ func (r *Repository) GetAll(boardID string) ([]columnModel.Column, error) {
 			&column.UpdatedAt,
 			&column.Name,
 			&column.Position,
+			&column.Flag,
 		); err != nil {
 			return nil, err
 		}
@@ -90,87 +75,52 @@ func (r *Repository) GetAll(boardID string) ([]columnModel.Column, error) {
 }
 
 func (r *Repository) Get(columnID string) (*columnModel.Column, error) {
-	var column columnModel.Column
-	err := r.db.QueryRow(postgres.QueryGetColumn, columnID).Scan(
-		&column.ID,
-		&column.BoardID,
-		&column.CreatedAt,
-		&column.UpdatedAt,
-		&column.Name,
-		&column.Position,
-	)
-
-	if err != nil {
-		return nil, err
-	}
-
-	return &column, nil
+	return scanColumn(r.db.QueryRow(postgres.QueryGetColumn, columnID))
 }
 
-func (r *Repository) Update(columnID string, newName *string, newPos *int) error {
+func (r *Repository) Update(columnID, userID string, newName *string, newPos *int, newFlag *string) (*columnModel.Column, error) {
 	tx, err := r.db.Begin()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer tx.Rollback()
 
-	var boardID string
-	var oldPos int
-	err = tx.QueryRow(postgres.QueryGetBoardIDAndColumnPos, columnID).Scan(&boardID, &oldPos)
+	before, err := LockColumn(tx, columnID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var maxPos int
-	if newPos != nil {
-		err = tx.QueryRow(postgres.QueryGetMaxColumnPosition, boardID).Scan(&maxPos)
-		if err != nil {
-			return err
-		}
-		if *newPos >= maxPos || *newPos <= 0 {
-			return ErrIncorrectPosition
-		}
-	}
-	
-	if newPos != nil && *newPos != oldPos {
-		if *newPos > oldPos {
-			_, err = tx.Exec(postgres.QueryMoveColumnsLeft, boardID, oldPos, *newPos)
-		} else {
-			_, err = tx.Exec(postgres.QueryMoveColumnsRight, boardID, *newPos, oldPos)
-		}
-		if err != nil {
-			return err
-		}
+	column, err := UpdateColumn(tx, before, newName, newPos, newFlag, utils.GenerateTimestamp())
+	if err != nil {
+		return nil, err
 	}
 
-	_, err = tx.Exec(postgres.QueryUpdateColumn, newName, newPos, utils.GenerateTimestamp(), columnID)
+	err = history.RecordColumn(tx, userID, history.ActionUpdate, before, column)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return tx.Commit()
+	return column, tx.Commit()
 }
 
-func (r *Repository) Delete(columnID string) error {
+func (r *Repository) Delete(columnID, userID string) error {
 	tx, err := r.db.Begin()
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
-	var boardID string
-	var pos int
-	err = tx.QueryRow(postgres.QueryGetBoardIDAndColumnPos, columnID).Scan(&boardID, &pos)
+	column, err := LockColumn(tx, columnID)
 	if err != nil {
 		return err
 	}
 
-	_, err = tx.Exec(postgres.QueryDeleteColumn, columnID)
+	err = RecordDelete(tx, userID, column)
 	if err != nil {
 		return err
 	}
 
-	_, err = tx.Exec(postgres.QueryDecreaseColumnsPosition, boardID, pos)
+	err = DeleteColumn(tx, column)
 	if err != nil {
 		return err
 	}
@@ -178,20 +128,38 @@ func (r *Repository) Delete(columnID string) error {
 	return tx.Commit()
 }
 
-func (r *Repository) GetUserByBoard(boardID string) (*string, error) {
-	var userID string
-	err := r.db.QueryRow(postgres.QueryGetUserByBoardID, boardID).Scan(&userID)
+func (r *Repository) HasBoardAccess(boardID, userID string) (bool, error) {
+	var hasAccess bool
+	err := r.db.QueryRow(postgres.QueryHasBoardAccess, boardID, userID, postgres.OwnBoardsOnly()).Scan(&hasAccess)
+	if err != nil {
+		return false, fmt.Errorf("columnRepo.HasBoardAccess: %w", err)
+	}
+	return hasAccess, nil
+}
+
+func (r *Repository) HasColumnAccess(columnID, userID string) (bool, error) {
+	var hasAccess bool
+	err := r.db.QueryRow(postgres.QueryHasColumnAccess, columnID, userID, postgres.OwnBoardsOnly()).Scan(&hasAccess)
 	if err != nil {
-		return nil, fmt.Errorf("columnRepo.GetUserByBoard: %w", err)
+		return false, fmt.Errorf("columnRepo.HasColumnAccess: %w", err)
 	}
-	return &userID, nil
+	return hasAccess, nil
 }
 
-func (r *Repository) GetUserByColumn(columnID string) (*string, error) {
-	var userID string
-	err := r.db.QueryRow(postgres.QueryGetUserByColumnID, columnID).Scan(&userID)
+
+func scanColumn(row *sql.Row) (*columnModel.Column, error) {
+	var column columnModel.Column
+	err := row.Scan(
+		&column.ID,
+		&column.BoardID,
+		&column.CreatedAt,
+		&column.UpdatedAt,
+		&column.Name,
+		&column.Position,
+		&column.Flag,
+	)
 	if err != nil {
-		return nil, fmt.Errorf("columnRepo.GetUserByColumn: %w", err)
+		return nil, err
 	}
-	return &userID, nil
+	return &column, nil
 }
@@ -0,0 +1,110 @@
+package columnRepo
+
+import (
+	"database/sql"
+	columnModel "kanban/internal/column/model"
+	"kanban/internal/history"
+	"kanban/internal/postgres"
+	taskRepo "kanban/internal/task/repo"
+	"time"
+)
+
+// The functions below make one change to a column in a transaction of the
+// caller. Every write path of columns goes through them, recording the
+// change in the history is left to the caller, see RecordDelete for
+// deletions.
+
+// LockColumn returns the column and locks it until the transaction ends.
+func LockColumn(tx *sql.Tx, columnID string) (*columnModel.Column, error) {
+	return scanColumn(tx.QueryRow(postgres.QueryGetColumnForUpdate, columnID))
+}
+
+// NextPosition returns the position after the last column of the board.
+func NextPosition(tx *sql.Tx, boardID string) (int, error) {
+	var pos int
+	err := tx.QueryRow(postgres.QueryGetMaxColumnPosition, boardID).Scan(&pos)
+	return pos, err
+}
+
+// CreateColumn appends the column to the end of its board.
+func CreateColumn(tx *sql.Tx, column columnModel.Column, now time.Time) (*columnModel.Column, error) {
+	var count int
+	err := tx.QueryRow(postgres.QueryGetColumnsCount, column.BoardID).Scan(&count)
+	if err != nil {
+		return nil, err
+	}
+	if count >= MaxColumns {
+		return nil, ErrColumnLimitReached
+	}
+
+	column.Position, err = NextPosition(tx, column.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanColumn(tx.QueryRow(
+		postgres.QueryCreateColumn,
+		column.ID,
+		column.BoardID,
+		now,
+		now,
+		column.Name,
+		column.Position,
+		column.Flag,
+	))
+}
+
+// UpdateColumn sets the fields that are not nil, an empty flag clears it.
+func UpdateColumn(tx *sql.Tx, column *columnModel.Column, name *string, position *int, flag *string, now time.Time) (*columnModel.Column, error) {
+	if position != nil {
+		maxPos, err := NextPosition(tx, column.BoardID)
+		if err != nil {
+			return nil, err
+		}
+		if *position >= maxPos || *position <= 0 {
+			return nil, ErrIncorrectPosition
+		}
+
+		if *position > column.Position {
+			_, err = tx.Exec(postgres.QueryMoveColumnsLeft, column.BoardID, column.Position, *position)
+		} else if *position < column.Position {
+			_, err = tx.Exec(postgres.QueryMoveColumnsRight, column.BoardID, *position, column.Position)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return scanColumn(tx.QueryRow(postgres.QueryUpdateColumn, name, position, flag, now, column.ID))
+}
+
+// RecordDelete records the deletion of the column and of its tasks, so it
+// runs before DeleteColumn. The tasks are recorded first, from the last
+// one back, so undo restores the column and then its tasks front to back.
+func RecordDelete(tx *sql.Tx, userID string, column *columnModel.Column) error {
+	tasks, err := taskRepo.GetColumnTasks(tx, column.ID)
+	if err != nil {
+		return err
+	}
+
+	for i := len(tasks) - 1; i >= 0; i-- {
+		err = history.RecordTask(tx, userID, history.ActionDelete, &tasks[i], nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return history.RecordColumn(tx, userID, history.ActionDelete, column, nil)
+}
+
+// DeleteColumn removes the column with its tasks and closes the gap it
+// leaves on the board.
+func DeleteColumn(tx *sql.Tx, column *columnModel.Column) error {
+	_, err := tx.Exec(postgres.QueryDeleteColumn, column.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(postgres.QueryDecreaseColumnsPosition, column.BoardID, column.Position)
+	return err
+}
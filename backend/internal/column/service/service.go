@@ -11,13 +11,13 @@ import (
 var ErrColumnNotFound = errors.New("column not found")
 
 type Repository interface {
-	Create(column columnModel.Column) error
+	Create(column columnModel.Column, userID string) (*columnModel.Column, error)
 	GetAll(boardID string) ([]columnModel.Column, error)
 	Get(columnID string) (*columnModel.Column, error)
-	Update(columnID string, newName *string, newPos *int) error
-	Delete(columnID string) error
-	GetUserByBoard(boardID string) (*string, error)
-	GetUserByColumn(columnID string) (*string, error)
+	Update(columnID, userID string, newName *string, newPos *int, newFlag *string) (*columnModel.Column, error)
+	Delete(columnID, userID string) error
+	HasBoardAccess(boardID, userID string) (bool, error)
+	HasColumnAccess(columnID, userID string) (bool, error)
 }
 
 type Service struct {
@@ -28,19 +28,22 @@ func NewService(repo Repository) *Service {
 	return &Service{repo: repo}
 }
 
-func (s *Service) CreateColumn(boardID string, req columnModel.CreateRequest) error {
+func (s *Service) CreateColumn(boardID, userID string, req columnModel.CreateRequest) (*columnModel.Column, error) {
 	column := columnModel.Column{
 			ID: utils.NewUUID(),
 			BoardID: boardID,
 			Name: req.Name,
 	}
+	if req.Flag != "" {
+		column.Flag = &req.Flag
+	}
 
-	err := s.repo.Create(column)
+	created, err := s.repo.Create(column, userID)
 	if err != nil {
-		return fmt.Errorf("columnService.CreateColumn: %w", err)
+		return nil, fmt.Errorf("columnService.CreateColumn: %w", err)
 	}
 
-	return nil
+	return created, nil
 }
 
 func (s *Service) GetAllColumns(boardID string) ([]columnModel.Column, error) {
@@ -64,20 +67,20 @@ func (s *Service) GetColumn(boardID string) (*columnModel.Column, error) {
 	return column, nil
 }
 
-func (s *Service) UpdateColumn(columnID string, req columnModel.UpdateRequest) error {
-	err := s.repo.Update(columnID, req.Name, req.Position)
+func (s *Service) UpdateColumn(columnID, userID string, req columnModel.UpdateRequest) (*columnModel.Column, error) {
+	column, err := s.repo.Update(columnID, userID, req.Name, req.Position, req.Flag)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return fmt.Errorf("columnService.UpdateColumn: %w", ErrColumnNotFound)
+			return nil, fmt.Errorf("columnService.UpdateColumn: %w", ErrColumnNotFound)
 		}
-		return fmt.Errorf("columnService.UpdateColumn: %w", err)
+		return nil, fmt.Errorf("columnService.UpdateColumn: %w", err)
 	}
 
-	return nil
+	return column, nil
 }
 
-func (s *Service) DeleteColumn(columnID string) error {
-	err := s.repo.Delete(columnID)
+func (s *Service) DeleteColumn(columnID, userID string) error {
+	err := s.repo.Delete(columnID, userID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return fmt.Errorf("columnService.DeleteColumn: %w", ErrColumnNotFound)
@@ -88,26 +91,26 @@ func (s *Service) DeleteColumn(columnID string) error {
 	return nil
 }
 
-func (s *Service) GetUserByBoard(boardID string) (*string, error) {
-	userID, err := s.repo.GetUserByBoard(boardID)
+func (s *Service) HasBoardAccess(boardID, userID string) (bool, error) {
+	hasAccess, err := s.repo.HasBoardAccess(boardID, userID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("columnService.GetUserByBoard: %w", ErrColumnNotFound)
+			return false, fmt.Errorf("columnService.HasBoardAccess: %w", ErrColumnNotFound)
 		}
-		return nil, fmt.Errorf("columnService.GetUserByBoard: %w", err)
+		return false, fmt.Errorf("columnService.HasBoardAccess: %w", err)
 	}
 
-	return userID, nil
+	return hasAccess, nil
 }
 
-func (s *Service) GetUserByColumn(columnID string) (*string, error) {
-	userID, err := s.repo.GetUserByColumn(columnID)
+func (s *Service) HasColumnAccess(columnID, userID string) (bool, error) {
+	hasAccess, err := s.repo.HasColumnAccess(columnID, userID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("columnService.GetUserByColumn: %w", ErrColumnNotFound)
+			return false, fmt.Errorf("columnService.HasColumnAccess: %w", ErrColumnNotFound)
 		}
-		return nil, fmt.Errorf("columnService.GetUserByColumn: %w", err)
+		return false, fmt.Errorf("columnService.HasColumnAccess: %w", err)
 	}
 
-	return userID, nil
+	return hasAccess, nil
 }
\ No newline at end of file
@@ -1,16 +1,62 @@
 package config
 
 import (
+	"encoding/base64"
+	"fmt"
 	"log"
 	"os"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 )
 
 type Config struct {
 	PostgresURI string
 	DBname 		string
 	Host        string
-	JWTSecret   []byte
+	AppURL      string
+	APIPrefix   string
+
+	JWTAlgorithm         string
+	JWTAllowedAlgorithms []string
+	JWTKeyRotation       time.Duration
+	JWTKeySecrets        [][]byte
+	JWTIssuer            string
+	JWTAudience          string
+
+	SchedulerInterval time.Duration
+
+	UnverifiedAccess string
+
+	RateLimitStore string
+	TrustedProxies []string
+
+	IdempotencyTTL time.Duration
+
+	OIDCIssuer         string
+	OIDCClientID       string
+	OIDCClientSecret   string
+	OIDCRedirectURL    string
+	OIDCScopes         []string
+	OIDCAllowedDomains []string
+
+	LDAPURL          string
+	LDAPStartTLS     bool
+	LDAPBindDN       string
+	LDAPBindPassword string
+	LDAPBaseDN       string
+	LDAPUserFilter   string
+	LDAPIDAttribute  string
+	LDAPGroupBaseDN  string
+	LDAPWorkspaceID  string
+	LDAPGroupRoles   map[string]string
+	LDAPRequireGroup bool
+
+	SMTPAddr     string
+	SMTPFrom     string
+	SMTPUsername string
+	SMTPPassword string
 }
 
 var (
@@ -18,6 +64,44 @@ var (
 	once   sync.Once
 )
 
+const defaultSchedulerInterval = time.Minute
+
+// Stored responses are replayed for retries with the same Idempotency-Key
+// within this window.
+const defaultIdempotencyTTL = 24 * time.Hour
+
+const (
+	defaultJWTKeyRotation = 30 * 24 * time.Hour
+	defaultJWTAudience    = "kanban"
+)
+
+// Algorithms tokens can be signed with.
+var jwtAlgorithms = []string{"RS256", "EdDSA"}
+
+const (
+	defaultLDAPUserFilter  = "(mail=%s)"
+	defaultLDAPIDAttribute = "entryUUID"
+)
+
+// Workspace roles directory groups can grant.
+var ldapRoles = []string{"owner", "admin", "member"}
+
+// Access levels for users who have not verified their email yet.
+const (
+	UnverifiedAccessFull      = "full"
+	UnverifiedAccessOwnBoards = "own_boards"
+	UnverifiedAccessNone      = "none"
+)
+
+// Stores for login rate limiting.
+const (
+	RateLimitStoreMemory   = "memory"
+	RateLimitStorePostgres = "postgres"
+)
+
+// Requests from these networks may set the client address via X-Forwarded-For.
+var defaultTrustedProxies = []string{"127.0.0.0/8", "10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "::1/128"}
+
 func Load() {
 	once.Do(func ()  {
 		pg := os.Getenv("POSTGRES")
@@ -35,19 +119,234 @@ func Load() {
 			log.Fatal("HOST env is required")
 		}
 
-		jwtKey := os.Getenv("JWT_SECRET")
-		if jwtKey == "" {
-			log.Fatal("JWT_SECRET env is required")
+		jwtAlgorithm := os.Getenv("JWT_ALGORITHM")
+		if jwtAlgorithm == "" {
+			jwtAlgorithm = "EdDSA"
+		}
+		if !slices.Contains(jwtAlgorithms, jwtAlgorithm) {
+			log.Fatalf("JWT_ALGORITHM env is invalid: %q", jwtAlgorithm)
+		}
+
+		jwtAllowedAlgorithms := jwtAlgorithms
+		if raw := os.Getenv("JWT_ALLOWED_ALGORITHMS"); raw != "" {
+			jwtAllowedAlgorithms = splitList(raw)
+			for _, alg := range jwtAllowedAlgorithms {
+				if !slices.Contains(jwtAlgorithms, alg) {
+					log.Fatalf("JWT_ALLOWED_ALGORITHMS env is invalid: %q", alg)
+				}
+			}
+			if !slices.Contains(jwtAllowedAlgorithms, jwtAlgorithm) {
+				log.Fatal("JWT_ALLOWED_ALGORITHMS env must include JWT_ALGORITHM")
+			}
+		}
+
+		jwtKeyRotation := defaultJWTKeyRotation
+		if raw := os.Getenv("JWT_KEY_ROTATION"); raw != "" {
+			rotation, err := time.ParseDuration(raw)
+			if err != nil || rotation < time.Hour {
+				log.Fatalf("JWT_KEY_ROTATION env is invalid: %q", raw)
+			}
+			jwtKeyRotation = rotation
+		}
+
+		// Signing keys are encrypted with the first secret, the others only
+		// decrypt keys until they are re-encrypted with the first one.
+		var jwtKeySecrets [][]byte
+		for _, raw := range splitList(os.Getenv("JWT_KEY_SECRETS")) {
+			secret, err := base64.StdEncoding.DecodeString(raw)
+			if err != nil || len(secret) != 32 {
+				log.Fatal("JWT_KEY_SECRETS env must hold base64 encoded 32 byte secrets")
+			}
+			jwtKeySecrets = append(jwtKeySecrets, secret)
+		}
+		if len(jwtKeySecrets) == 0 {
+			log.Fatal("JWT_KEY_SECRETS env is required")
+		}
+
+		schedulerInterval := defaultSchedulerInterval
+		if raw := os.Getenv("SCHEDULER_INTERVAL"); raw != "" {
+			interval, err := time.ParseDuration(raw)
+			if err != nil || interval <= 0 {
+				log.Fatalf("SCHEDULER_INTERVAL env is invalid: %q", raw)
+			}
+			schedulerInterval = interval
+		}
+
+		appURL := os.Getenv("APP_URL")
+		if appURL == "" {
+			appURL = "http://localhost"
+		}
+		appURL = strings.TrimSuffix(appURL, "/")
+
+		// The path the API is served under behind the proxy, used in the
+		// links the API returns.
+		apiPrefix, ok := os.LookupEnv("API_PREFIX")
+		if !ok {
+			apiPrefix = "/api"
+		}
+		apiPrefix = strings.TrimSuffix(apiPrefix, "/")
+		if apiPrefix != "" && !strings.HasPrefix(apiPrefix, "/") {
+			apiPrefix = "/" + apiPrefix
+		}
+
+		jwtIssuer := os.Getenv("JWT_ISSUER")
+		if jwtIssuer == "" {
+			jwtIssuer = appURL
+		}
+
+		jwtAudience := os.Getenv("JWT_AUDIENCE")
+		if jwtAudience == "" {
+			jwtAudience = defaultJWTAudience
+		}
+
+		unverifiedAccess := os.Getenv("UNVERIFIED_ACCESS")
+		switch unverifiedAccess {
+		case "":
+			unverifiedAccess = UnverifiedAccessFull
+		case UnverifiedAccessFull, UnverifiedAccessOwnBoards, UnverifiedAccessNone:
+		default:
+			log.Fatalf("UNVERIFIED_ACCESS env is invalid: %q", unverifiedAccess)
+		}
+
+		rateLimitStore := os.Getenv("RATE_LIMIT_STORE")
+		switch rateLimitStore {
+		case "":
+			rateLimitStore = RateLimitStoreMemory
+		case RateLimitStoreMemory, RateLimitStorePostgres:
+		default:
+			log.Fatalf("RATE_LIMIT_STORE env is invalid: %q", rateLimitStore)
+		}
+
+		idempotencyTTL := defaultIdempotencyTTL
+		if raw := os.Getenv("IDEMPOTENCY_TTL"); raw != "" {
+			ttl, err := time.ParseDuration(raw)
+			if err != nil || ttl <= 0 {
+				log.Fatalf("IDEMPOTENCY_TTL env is invalid: %q", raw)
+			}
+			idempotencyTTL = ttl
+		}
+
+		trustedProxies := defaultTrustedProxies
+		if raw, ok := os.LookupEnv("TRUSTED_PROXIES"); ok {
+			trustedProxies = splitList(raw)
+		}
+
+		// OIDC login is enabled by setting OIDC_ISSUER.
+		oidcIssuer := os.Getenv("OIDC_ISSUER")
+		oidcClientID := os.Getenv("OIDC_CLIENT_ID")
+		if oidcIssuer != "" && oidcClientID == "" {
+			log.Fatal("OIDC_CLIENT_ID env is required with OIDC_ISSUER")
+		}
+
+		oidcRedirectURL := os.Getenv("OIDC_REDIRECT_URL")
+		if oidcRedirectURL == "" {
+			oidcRedirectURL = appURL + apiPrefix + "/auth/oidc/callback"
+		}
+
+		oidcScopes := splitList(os.Getenv("OIDC_SCOPES"))
+		if len(oidcScopes) == 0 {
+			oidcScopes = []string{"openid", "email", "profile"}
+		}
+
+		// LDAP login is enabled by setting LDAP_URL.
+		ldapURL := os.Getenv("LDAP_URL")
+		ldapBaseDN := os.Getenv("LDAP_BASE_DN")
+		if ldapURL != "" && ldapBaseDN == "" {
+			log.Fatal("LDAP_BASE_DN env is required with LDAP_URL")
+		}
+
+		ldapUserFilter := os.Getenv("LDAP_USER_FILTER")
+		if ldapUserFilter == "" {
+			ldapUserFilter = defaultLDAPUserFilter
+		}
+		if strings.Count(ldapUserFilter, "%s") != 1 {
+			log.Fatalf("LDAP_USER_FILTER env must contain a single %%s: %q", ldapUserFilter)
+		}
+
+		ldapIDAttribute := os.Getenv("LDAP_ID_ATTRIBUTE")
+		if ldapIDAttribute == "" {
+			ldapIDAttribute = defaultLDAPIDAttribute
+		}
+
+		ldapGroupRoles, err := parseGroupRoles(os.Getenv("LDAP_GROUP_ROLES"))
+		if err != nil {
+			log.Fatalf("LDAP_GROUP_ROLES env is invalid: %v", err)
+		}
+		ldapWorkspaceID := os.Getenv("LDAP_WORKSPACE_ID")
+		if len(ldapGroupRoles) > 0 && ldapWorkspaceID == "" {
+			log.Fatal("LDAP_WORKSPACE_ID env is required with LDAP_GROUP_ROLES")
 		}
 
 		config = &Config{
 			PostgresURI: pg,
 			Host: host,
-			JWTSecret: []byte(jwtKey),
+			AppURL: appURL,
+			APIPrefix: apiPrefix,
+			JWTAlgorithm: jwtAlgorithm,
+			JWTAllowedAlgorithms: jwtAllowedAlgorithms,
+			JWTKeyRotation: jwtKeyRotation,
+			JWTKeySecrets: jwtKeySecrets,
+			JWTIssuer: jwtIssuer,
+			JWTAudience: jwtAudience,
+			SchedulerInterval: schedulerInterval,
+			UnverifiedAccess: unverifiedAccess,
+			RateLimitStore: rateLimitStore,
+			TrustedProxies: trustedProxies,
+			IdempotencyTTL: idempotencyTTL,
+			OIDCIssuer: oidcIssuer,
+			OIDCClientID: oidcClientID,
+			OIDCClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+			OIDCRedirectURL: oidcRedirectURL,
+			OIDCScopes: oidcScopes,
+			OIDCAllowedDomains: splitList(strings.ToLower(os.Getenv("OIDC_ALLOWED_DOMAINS"))),
+			LDAPURL: ldapURL,
+			LDAPStartTLS: os.Getenv("LDAP_START_TLS") == "true",
+			LDAPBindDN: os.Getenv("LDAP_BIND_DN"),
+			LDAPBindPassword: os.Getenv("LDAP_BIND_PASSWORD"),
+			LDAPBaseDN: ldapBaseDN,
+			LDAPUserFilter: ldapUserFilter,
+			LDAPIDAttribute: ldapIDAttribute,
+			LDAPGroupBaseDN: os.Getenv("LDAP_GROUP_BASE_DN"),
+			LDAPWorkspaceID: ldapWorkspaceID,
+			LDAPGroupRoles: ldapGroupRoles,
+			LDAPRequireGroup: os.Getenv("LDAP_REQUIRE_GROUP") == "true",
+			SMTPAddr: os.Getenv("SMTP_ADDR"),
+			SMTPFrom: os.Getenv("SMTP_FROM"),
+			SMTPUsername: os.Getenv("SMTP_USERNAME"),
+			SMTPPassword: os.Getenv("SMTP_PASSWORD"),
 		}
 	})
 }
 
+// splitList splits a comma or space separated env value.
+func splitList(raw string) []string {
+	return strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+}
+
+// parseGroupRoles parses "group DN:role" pairs separated by semicolons,
+// since DNs themselves contain commas. Group DNs are matched case-insensitively.
+func parseGroupRoles(raw string) (map[string]string, error) {
+	roles := make(map[string]string)
+	for _, pair := range strings.Split(raw, ";") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		i := strings.LastIndex(pair, ":")
+		if i <= 0 || i == len(pair)-1 {
+			return nil, fmt.Errorf("malformed pair %q", pair)
+		}
+		role := strings.TrimSpace(pair[i+1:])
+		if !slices.Contains(ldapRoles, role) {
+			return nil, fmt.Errorf("unknown role %q", role)
+		}
+		roles[strings.ToLower(strings.TrimSpace(pair[:i]))] = role
+	}
+	return roles, nil
+}
+
 func Get() *Config {
 	if config == nil {
 		log.Fatal("config not loaded: call config.Load() first")
@@ -0,0 +1,20 @@
+package export
+
+import (
+	"database/sql"
+	exportHandler "kanban/internal/export/handler"
+	exportProxy "kanban/internal/export/proxy"
+	exportRepo "kanban/internal/export/repo"
+	exportService "kanban/internal/export/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Init(db *sql.DB, grp *gin.RouterGroup) {
+	repo := exportRepo.NewRepository(db)
+	service := exportService.NewService(repo)
+	proxy := exportProxy.NewProxy(service)
+	handler := exportHandler.NewHandler(proxy)
+
+	grp.GET("/boards/:id/export", handler.ExportBoardHandler())
+}
@@ -0,0 +1,110 @@
+package exportHandler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	authctx "kanban/internal/auth/context"
+	exportModel "kanban/internal/export/model"
+	exportProxy "kanban/internal/export/proxy"
+	exportService "kanban/internal/export/service"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Proxy interface {
+	ExportBoard(boardID, userID, format string, w io.Writer) error
+}
+
+type Handler struct {
+	proxy Proxy
+}
+
+func NewHandler(proxy Proxy) *Handler {
+	return &Handler{proxy: proxy}
+}
+
+var contentTypes = map[string]string{
+	exportModel.FormatJSON:     "application/json; charset=utf-8",
+	exportModel.FormatCSV:      "text/csv; charset=utf-8",
+	exportModel.FormatMarkdown: "text/markdown; charset=utf-8",
+}
+
+func (h *Handler) ExportBoardHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var query exportModel.Query
+		if err := ctx.ShouldBindQuery(&query); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid query parameters",
+			})
+			return
+		}
+		if query.Format == "" {
+			query.Format = exportModel.FormatJSON
+		}
+
+		boardID := ctx.Param("id")
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		w := &download{
+			ctx:         ctx,
+			contentType: contentTypes[query.Format],
+			filename:    fmt.Sprintf("board-%s.%s", boardID, query.Format),
+		}
+		if err := h.proxy.ExportBoard(boardID, userID, query.Format, w); err != nil {
+			log.Printf("Failed to export board: %v", err)
+			// Once the body has started the status is sent, the client sees
+			// a truncated download.
+			if w.started {
+				return
+			}
+			h.handleError(ctx, err, "Failed to export board")
+			return
+		}
+	}
+}
+
+// download sets the attachment headers on the first write, so errors that
+// happen before any output are still answered with JSON.
+type download struct {
+	ctx         *gin.Context
+	contentType string
+	filename    string
+	started     bool
+}
+
+func (d *download) Write(p []byte) (int, error) {
+	if !d.started {
+		d.started = true
+		d.ctx.Header("Content-Type", d.contentType)
+		d.ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, d.filename))
+		d.ctx.Status(http.StatusOK)
+	}
+	return d.ctx.Writer.Write(p)
+}
+
+func (h *Handler) handleError(ctx *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, exportProxy.ErrForbidden):
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"detail": "Access denied",
+		})
+	case errors.Is(err, exportService.ErrBoardNotFound):
+		ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+			"detail": "Board not found",
+		})
+	default:
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"detail": message,
+		})
+	}
+}
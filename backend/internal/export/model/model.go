@@ -0,0 +1,73 @@
+package exportModel
+
+import "time"
+
+// Version of the JSON export format. Bump it on changes that older
+// importers cannot read.
+const Version = 1
+
+const (
+	FormatJSON     = "json"
+	FormatCSV      = "csv"
+	FormatMarkdown = "md"
+)
+
+// Header opens a JSON export. Tasks follow in a separate list, so they can
+// be written one by one.
+type Header struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exported_at"`
+	Board      Board     `json:"board"`
+	Columns    []Column  `json:"columns"`
+}
+
+type Board struct {
+	ID                  string    `json:"id"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+	Name                string    `json:"name"`
+	EnforceDependencies bool      `json:"enforce_dependencies"`
+}
+
+type Column struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Name      string    `json:"name"`
+	Position  int       `json:"position"`
+	Flag      *string   `json:"flag"`
+}
+
+type Task struct {
+	ID          string      `json:"id"`
+	ColumnID    string      `json:"column_id"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Position    int         `json:"position"`
+	Done        bool        `json:"done"`
+	Deadline    *time.Time  `json:"deadline"`
+	BlockedBy   []string    `json:"blocked_by"`
+	Recurrence  *Recurrence `json:"recurrence"`
+}
+
+type Recurrence struct {
+	RRule       string    `json:"rrule"`
+	DTStart     time.Time `json:"dtstart"`
+	ColumnID    string    `json:"column_id"`
+	NextRunAt   time.Time `json:"next_run_at"`
+	Occurrences int       `json:"occurrences"`
+	Paused      bool      `json:"paused"`
+}
+
+// Writer receives the board as it is read. Tasks arrive one at a time, in
+// column order and by position within a column.
+type Writer interface {
+	Board(board Board, columns []Column) error
+	Task(task Task) error
+}
+
+type Query struct {
+	Format string `form:"format" binding:"omitempty,oneof=json csv md"`
+}
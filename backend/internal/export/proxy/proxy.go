@@ -0,0 +1,35 @@
+package exportProxy
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+var ErrForbidden = errors.New("access denied")
+
+type Service interface {
+	ExportBoard(boardID, format string, w io.Writer) error
+	HasBoardAccess(boardID, userID string) (bool, error)
+}
+
+type Proxy struct {
+	service Service
+}
+
+func NewProxy(service Service) *Proxy {
+	return &Proxy{service: service}
+}
+
+func (p *Proxy) ExportBoard(boardID, userID, format string, w io.Writer) error {
+	hasAccess, err := p.service.HasBoardAccess(boardID, userID)
+	if err != nil {
+		return fmt.Errorf("exportProxy.ExportBoard: %w", err)
+	}
+
+	if hasAccess {
+		return p.service.ExportBoard(boardID, format, w)
+	} else {
+		return fmt.Errorf("exportProxy.ExportBoard: %w", ErrForbidden)
+	}
+}
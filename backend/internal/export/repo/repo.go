@@ -0,0 +1,156 @@
+package exportRepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	exportModel "kanban/internal/export/model"
+	"kanban/internal/postgres"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Export streams the board to w straight from the database cursor, so
+// large boards are never held in memory. The read-only repeatable read
+// transaction gives a consistent view of the board.
+func (r *Repository) Export(boardID string, w exportModel.Writer) error {
+	tx, err := r.db.BeginTx(context.Background(), &sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+		ReadOnly:  true,
+	})
+	if err != nil {
+		return fmt.Errorf("exportRepo.Export: %w", err)
+	}
+	defer tx.Rollback()
+
+	var board exportModel.Board
+	err = tx.QueryRow(postgres.QueryGetExportBoard, boardID).Scan(
+		&board.ID,
+		&board.CreatedAt,
+		&board.UpdatedAt,
+		&board.Name,
+		&board.EnforceDependencies,
+	)
+	if err != nil {
+		return fmt.Errorf("exportRepo.Export: %w", err)
+	}
+
+	columns, err := getColumns(tx, boardID)
+	if err != nil {
+		return fmt.Errorf("exportRepo.Export: %w", err)
+	}
+
+	if err = w.Board(board, columns); err != nil {
+		return fmt.Errorf("exportRepo.Export: %w", err)
+	}
+
+	rows, err := tx.Query(postgres.QueryGetExportTasks, boardID)
+	if err != nil {
+		return fmt.Errorf("exportRepo.Export: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return fmt.Errorf("exportRepo.Export: %w", err)
+		}
+		if err = w.Task(*task); err != nil {
+			return fmt.Errorf("exportRepo.Export: %w", err)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("exportRepo.Export: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) HasBoardAccess(boardID, userID string) (bool, error) {
+	var hasAccess bool
+	err := r.db.QueryRow(postgres.QueryHasBoardAccess, boardID, userID, postgres.OwnBoardsOnly()).Scan(&hasAccess)
+	if err != nil {
+		return false, fmt.Errorf("exportRepo.HasBoardAccess: %w", err)
+	}
+	return hasAccess, nil
+}
+
+func getColumns(tx *sql.Tx, boardID string) ([]exportModel.Column, error) {
+	rows, err := tx.Query(postgres.QueryGetAllColumns, boardID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := []exportModel.Column{}
+	for rows.Next() {
+		var column exportModel.Column
+		var columnBoardID string
+		if err = rows.Scan(
+			&column.ID,
+			&columnBoardID,
+			&column.CreatedAt,
+			&column.UpdatedAt,
+			&column.Name,
+			&column.Position,
+			&column.Flag,
+		); err != nil {
+			return nil, err
+		}
+		columns = append(columns, column)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+func scanTask(rows *sql.Rows) (*exportModel.Task, error) {
+	var task exportModel.Task
+	var rrule, recurrenceColumnID sql.NullString
+	var dtstart, nextRunAt *time.Time
+	var occurrences sql.NullInt64
+	var paused sql.NullBool
+	if err := rows.Scan(
+		&task.ID,
+		&task.ColumnID,
+		&task.CreatedAt,
+		&task.UpdatedAt,
+		&task.Name,
+		&task.Description,
+		&task.Position,
+		&task.Done,
+		&task.Deadline,
+		pq.Array(&task.BlockedBy),
+		&rrule,
+		&dtstart,
+		&recurrenceColumnID,
+		&nextRunAt,
+		&occurrences,
+		&paused,
+	); err != nil {
+		return nil, err
+	}
+
+	if rrule.Valid {
+		task.Recurrence = &exportModel.Recurrence{
+			RRule:       rrule.String,
+			DTStart:     *dtstart,
+			ColumnID:    recurrenceColumnID.String,
+			NextRunAt:   *nextRunAt,
+			Occurrences: int(occurrences.Int64),
+			Paused:      paused.Bool,
+		}
+	}
+	return &task, nil
+}
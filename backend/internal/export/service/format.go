@@ -0,0 +1,200 @@
+package exportService
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	exportModel "kanban/internal/export/model"
+	"kanban/internal/utils"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// jsonWriter writes the versioned dump. The header is marshalled as a
+// whole, then the task list is left open and filled task by task.
+type jsonWriter struct {
+	w     *bufio.Writer
+	tasks int
+}
+
+func newJSONWriter(w io.Writer) *jsonWriter {
+	return &jsonWriter{w: bufio.NewWriter(w)}
+}
+
+func (j *jsonWriter) Board(board exportModel.Board, columns []exportModel.Column) error {
+	header, err := json.Marshal(exportModel.Header{
+		Version:    exportModel.Version,
+		ExportedAt: utils.GenerateTimestamp().UTC(),
+		Board:      board,
+		Columns:    columns,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Reopen the marshalled object to append the task list.
+	header = header[:len(header)-1]
+	if _, err = j.w.Write(header); err != nil {
+		return err
+	}
+	_, err = j.w.WriteString(`,"tasks":[`)
+	return err
+}
+
+func (j *jsonWriter) Task(task exportModel.Task) error {
+	if task.BlockedBy == nil {
+		task.BlockedBy = []string{}
+	}
+	data, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+
+	if j.tasks > 0 {
+		if err = j.w.WriteByte(','); err != nil {
+			return err
+		}
+	}
+	j.tasks++
+	_, err = j.w.Write(data)
+	return err
+}
+
+func (j *jsonWriter) Flush() error {
+	if _, err := j.w.WriteString("]}\n"); err != nil {
+		return err
+	}
+	return j.w.Flush()
+}
+
+// csvWriter writes one row per task, with the name of its column.
+type csvWriter struct {
+	w       *csv.Writer
+	columns map[string]string
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+var csvHeader = []string{
+	"id", "column", "name", "description", "position", "done", "deadline", "blocked_by", "created_at", "updated_at",
+}
+
+func (c *csvWriter) Board(board exportModel.Board, columns []exportModel.Column) error {
+	c.columns = make(map[string]string, len(columns))
+	for _, column := range columns {
+		c.columns[column.ID] = column.Name
+	}
+	return c.w.Write(csvHeader)
+}
+
+func (c *csvWriter) Task(task exportModel.Task) error {
+	deadline := ""
+	if task.Deadline != nil {
+		deadline = task.Deadline.UTC().Format(time.RFC3339)
+	}
+
+	return c.w.Write([]string{
+		task.ID,
+		c.columns[task.ColumnID],
+		task.Name,
+		task.Description,
+		strconv.Itoa(task.Position),
+		strconv.FormatBool(task.Done),
+		deadline,
+		strings.Join(task.BlockedBy, ";"),
+		task.CreatedAt.UTC().Format(time.RFC3339),
+		task.UpdatedAt.UTC().Format(time.RFC3339),
+	})
+}
+
+func (c *csvWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// markdownWriter writes a heading per column and a checklist item per
+// task, with the description indented below it.
+type markdownWriter struct {
+	w       *bufio.Writer
+	columns []exportModel.Column
+	// current is the index of the column whose heading was written last.
+	current int
+	// listed is set once the current column has a task written.
+	listed bool
+}
+
+func newMarkdownWriter(w io.Writer) *markdownWriter {
+	return &markdownWriter{w: bufio.NewWriter(w), current: -1}
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`, `#`, `\#`, `<`, `\<`, `>`, `\>`,
+)
+
+func (m *markdownWriter) Board(board exportModel.Board, columns []exportModel.Column) error {
+	m.columns = columns
+	_, err := fmt.Fprintf(m.w, "# %s\n", markdownEscaper.Replace(board.Name))
+	return err
+}
+
+func (m *markdownWriter) Task(task exportModel.Task) error {
+	// Tasks come in column order, so headings of columns without tasks are
+	// written on the way to the column of this task.
+	for m.current < 0 || m.columns[m.current].ID != task.ColumnID {
+		if m.current+1 >= len(m.columns) {
+			return fmt.Errorf("task %s is not in a column of the board", task.ID)
+		}
+		if err := m.heading(); err != nil {
+			return err
+		}
+	}
+
+	if !m.listed {
+		m.listed = true
+		if err := m.w.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+
+	mark := " "
+	if task.Done {
+		mark = "x"
+	}
+	line := fmt.Sprintf("- [%s] %s", mark, markdownEscaper.Replace(task.Name))
+	if task.Deadline != nil {
+		line += fmt.Sprintf(" (due %s)", task.Deadline.UTC().Format(time.DateOnly))
+	}
+	if _, err := fmt.Fprintln(m.w, line); err != nil {
+		return err
+	}
+
+	if task.Description != "" {
+		for _, descriptionLine := range strings.Split(task.Description, "\n") {
+			if _, err := fmt.Fprintf(m.w, "  %s\n", descriptionLine); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m *markdownWriter) Flush() error {
+	for m.current+1 < len(m.columns) {
+		if err := m.heading(); err != nil {
+			return err
+		}
+	}
+	return m.w.Flush()
+}
+
+func (m *markdownWriter) heading() error {
+	m.current++
+	m.listed = false
+	_, err := fmt.Fprintf(m.w, "\n## %s\n", markdownEscaper.Replace(m.columns[m.current].Name))
+	return err
+}
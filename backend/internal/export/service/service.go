@@ -0,0 +1,64 @@
+package exportService
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	exportModel "kanban/internal/export/model"
+)
+
+var ErrBoardNotFound = errors.New("board not found")
+
+type Repository interface {
+	Export(boardID string, w exportModel.Writer) error
+	HasBoardAccess(boardID, userID string) (bool, error)
+}
+
+type Service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// ExportBoard writes the board to w in the given format as it is read from
+// the database. Nothing is written when the board cannot be found.
+func (s *Service) ExportBoard(boardID, format string, w io.Writer) error {
+	var writer interface {
+		exportModel.Writer
+		Flush() error
+	}
+	switch format {
+	case exportModel.FormatCSV:
+		writer = newCSVWriter(w)
+	case exportModel.FormatMarkdown:
+		writer = newMarkdownWriter(w)
+	default:
+		writer = newJSONWriter(w)
+	}
+
+	if err := s.repo.Export(boardID, writer); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("exportService.ExportBoard: %w", ErrBoardNotFound)
+		}
+		return fmt.Errorf("exportService.ExportBoard: %w", err)
+	}
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("exportService.ExportBoard: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) HasBoardAccess(boardID, userID string) (bool, error) {
+	hasAccess, err := s.repo.HasBoardAccess(boardID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, fmt.Errorf("exportService.HasBoardAccess: %w", ErrBoardNotFound)
+		}
+		return false, fmt.Errorf("exportService.HasBoardAccess: %w", err)
+	}
+	return hasAccess, nil
+}
@@ -0,0 +1,123 @@
+package history
+
+import (
+	"database/sql"
+	"encoding/json"
+	columnModel "kanban/internal/column/model"
+	"kanban/internal/postgres"
+	taskModel "kanban/internal/task/model"
+	"kanban/internal/utils"
+)
+
+const (
+	EntityTask   = "task"
+	EntityColumn = "column"
+)
+
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionMove   = "move"
+	ActionDelete = "delete"
+	ActionRevert = "revert"
+)
+
+// Event is a change made by a user, or by the server itself when UserID
+// is empty. Before is empty for created entities and After for deleted
+// ones.
+type Event struct {
+	ID        string
+	UserID    string
+	BoardID   string
+	Entity    string
+	EntityID  string
+	Action    string
+	Before    any
+	After     any
+	RevertsID *string
+}
+
+// Record stores the event in the transaction of the change, so the event
+// exists exactly when the change does.
+func Record(tx *sql.Tx, event Event) error {
+	before, err := image(event.Before)
+	if err != nil {
+		return err
+	}
+	after, err := image(event.After)
+	if err != nil {
+		return err
+	}
+
+	var userID *string
+	if event.UserID != "" {
+		userID = &event.UserID
+	}
+
+	_, err = tx.Exec(
+		postgres.QueryCreateActivity,
+		event.ID,
+		userID,
+		event.BoardID,
+		event.Entity,
+		event.EntityID,
+		event.Action,
+		before,
+		after,
+		event.RevertsID,
+		utils.GenerateTimestamp(),
+	)
+	return err
+}
+
+func RecordTask(tx *sql.Tx, userID, action string, before, after *taskModel.Task) error {
+	task := after
+	if task == nil {
+		task = before
+	}
+
+	var boardID string
+	err := tx.QueryRow(postgres.QueryGetBoardIDByColumnID, task.ColumnID).Scan(&boardID)
+	if err != nil {
+		return err
+	}
+
+	return Record(tx, Event{
+		ID:       utils.NewUUID(),
+		UserID:   userID,
+		BoardID:  boardID,
+		Entity:   EntityTask,
+		EntityID: task.ID,
+		Action:   action,
+		Before:   before,
+		After:    after,
+	})
+}
+
+func RecordColumn(tx *sql.Tx, userID, action string, before, after *columnModel.Column) error {
+	column := after
+	if column == nil {
+		column = before
+	}
+
+	return Record(tx, Event{
+		ID:       utils.NewUUID(),
+		UserID:   userID,
+		BoardID:  column.BoardID,
+		Entity:   EntityColumn,
+		EntityID: column.ID,
+		Action:   action,
+		Before:   before,
+		After:    after,
+	})
+}
+
+// image encodes the state of an entity, a nil pointer is stored as NULL.
+// The JSON goes as a string, lib/pq would send bytes as bytea.
+func image(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil || string(data) == "null" {
+		return nil, err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,228 @@
+package idempotency
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	authctx "kanban/internal/auth/context"
+	"kanban/internal/utils"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const Header = "Idempotency-Key"
+
+const maxKeyLength = 255
+
+// maxBodySize bounds the body kept in memory for the fingerprint, it is
+// above what any endpoint accepts.
+const maxBodySize = 16 << 20
+
+// Keys of the gin context for responses carrying a secret.
+const (
+	resourceKey = "idempotency_resource"
+	replayedKey = "idempotency_replayed"
+)
+
+// Record is the stored outcome of a request. Status is nil while the first
+// request with the key is still running. Responses marked with SetResource
+// have a ResourceID instead of a body.
+type Record struct {
+	Fingerprint string
+	Status      *int
+	ContentType *string
+	Location    *string
+	ResourceID  *string
+	Body        []byte
+}
+
+// Response is the outcome of a request to store.
+type Response struct {
+	Status      int
+	ContentType string
+	Location    string
+	ResourceID  string
+	Body        []byte
+}
+
+// Store keeps records per user and key. Claim reserves a key that is
+// unknown or expired and reports false for a live one. Get returns nil
+// for unknown keys.
+type Store interface {
+	Claim(userID, key, fingerprint string, now, expiresAt time.Time) (bool, error)
+	Get(userID, key string) (*Record, error)
+	Save(userID, key string, response Response) error
+	Release(userID, key string) error
+	DeleteExpired(now time.Time) (int, error)
+}
+
+// Middleware makes POST and PATCH requests carrying an Idempotency-Key
+// safe to retry. The first response for a key is stored for ttl and
+// replayed for retries with the same method, path and body. Server errors
+// are not stored, so such requests can be retried for real, and neither are
+// requests that panic.
+func Middleware(store Store, ttl time.Duration) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader(Header)
+		method := ctx.Request.Method
+		if key == "" || (method != http.MethodPost && method != http.MethodPatch) {
+			ctx.Next()
+			return
+		}
+		if len(key) > maxKeyLength {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Idempotency-Key is too long",
+			})
+			return
+		}
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.Next()
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxBodySize))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				ctx.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
+					"detail": "Request body is too large",
+				})
+				return
+			}
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid request body",
+			})
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		fingerprint := utils.HashToken(method + " " + ctx.Request.URL.RequestURI() + "\n" + string(body))
+		now := utils.GenerateTimestamp()
+
+		claimed, err := store.Claim(userID, key, fingerprint, now, now.Add(ttl))
+		if err != nil {
+			log.Printf("Failed to claim idempotency key: %v", err)
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"detail": "Failed to check Idempotency-Key",
+			})
+			return
+		}
+		if !claimed {
+			replay(ctx, store, userID, key, fingerprint)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: ctx.Writer}
+		ctx.Writer = recorder
+		defer func() {
+			if p := recover(); p != nil {
+				if err := store.Release(userID, key); err != nil {
+					log.Printf("Failed to release idempotency key: %v", err)
+				}
+				panic(p)
+			}
+			save(ctx, store, userID, key, recorder)
+		}()
+		ctx.Next()
+	}
+}
+
+// SetResource marks the response as carrying a secret, such as a token
+// that is only shown once. Only the status, the Location header and the
+// resource ID are stored then, and retries reach the handler again with
+// Replayed reporting them, so it answers with the resource read anew.
+func SetResource(ctx *gin.Context, id string) {
+	ctx.Set(resourceKey, id)
+}
+
+// Replayed returns the status and the resource ID stored by SetResource
+// when the request is a retry. The handler must not repeat the write then.
+func Replayed(ctx *gin.Context) (int, string, bool) {
+	value, ok := ctx.Get(replayedKey)
+	if !ok {
+		return 0, "", false
+	}
+	record := value.(*Record)
+	return *record.Status, *record.ResourceID, true
+}
+
+func save(ctx *gin.Context, store Store, userID, key string, recorder *responseRecorder) {
+	var err error
+	status := recorder.Status()
+	if status >= http.StatusInternalServerError {
+		err = store.Release(userID, key)
+	} else {
+		response := Response{
+			Status:      status,
+			ContentType: recorder.Header().Get("Content-Type"),
+			Location:    recorder.Header().Get("Location"),
+			Body:        recorder.body.Bytes(),
+		}
+		if id := ctx.GetString(resourceKey); id != "" {
+			response.ResourceID = id
+			response.Body = nil
+		}
+		err = store.Save(userID, key, response)
+	}
+	if err != nil {
+		log.Printf("Failed to store idempotent response: %v", err)
+	}
+}
+
+func replay(ctx *gin.Context, store Store, userID, key, fingerprint string) {
+	record, err := store.Get(userID, key)
+	if err != nil {
+		log.Printf("Failed to get idempotency key: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"detail": "Failed to check Idempotency-Key",
+		})
+		return
+	}
+
+	switch {
+	case record == nil || record.Status == nil:
+		ctx.AbortWithStatusJSON(http.StatusConflict, gin.H{
+			"detail": "A request with this Idempotency-Key is in progress",
+		})
+	case record.Fingerprint != fingerprint:
+		ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{
+			"detail": "Idempotency-Key was already used for a different request",
+		})
+	case record.ResourceID != nil:
+		ctx.Header("Idempotent-Replayed", "true")
+		ctx.Set(replayedKey, record)
+		ctx.Next()
+	default:
+		ctx.Header("Idempotent-Replayed", "true")
+		if record.Location != nil {
+			ctx.Header("Location", *record.Location)
+		}
+		if record.ContentType == nil || *record.ContentType == "" {
+			ctx.AbortWithStatus(*record.Status)
+			return
+		}
+		ctx.Data(*record.Status, *record.ContentType, record.Body)
+		ctx.Abort()
+	}
+}
+
+// responseRecorder keeps a copy of the response body for storing.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
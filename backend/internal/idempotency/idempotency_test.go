@@ -0,0 +1,155 @@
+package idempotency
+
+import (
+	authctx "kanban/internal/auth/context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// memoryStore keeps records of a single user in memory.
+type memoryStore struct {
+	records map[string]*Record
+}
+
+func (s *memoryStore) Claim(userID, key, fingerprint string, now, expiresAt time.Time) (bool, error) {
+	if _, ok := s.records[key]; ok {
+		return false, nil
+	}
+	s.records[key] = &Record{Fingerprint: fingerprint}
+	return true, nil
+}
+
+func (s *memoryStore) Get(userID, key string) (*Record, error) {
+	return s.records[key], nil
+}
+
+func (s *memoryStore) Save(userID, key string, response Response) error {
+	record := s.records[key]
+	record.Status = &response.Status
+	record.ContentType = &response.ContentType
+	if response.Location != "" {
+		record.Location = &response.Location
+	}
+	if response.ResourceID != "" {
+		record.ResourceID = &response.ResourceID
+	}
+	record.Body = response.Body
+	return nil
+}
+
+func (s *memoryStore) Release(userID, key string) error {
+	delete(s.records, key)
+	return nil
+}
+
+func (s *memoryStore) DeleteExpired(now time.Time) (int, error) {
+	return 0, nil
+}
+
+func newEngine(store Store) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(gin.Recovery(), func(ctx *gin.Context) {
+		authctx.SetUserID(ctx, "user")
+	}, Middleware(store, time.Hour))
+	return engine
+}
+
+func send(engine *gin.Engine, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set(Header, "key")
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	return w
+}
+
+func TestReplay(t *testing.T) {
+	store := &memoryStore{records: map[string]*Record{}}
+	engine := newEngine(store)
+
+	calls := 0
+	engine.POST("/things", func(ctx *gin.Context) {
+		calls++
+		ctx.Header("Location", "/api/things/1")
+		ctx.JSON(http.StatusCreated, gin.H{"id": "1"})
+	})
+
+	first := send(engine, "/things", `{"name":"a"}`)
+	second := send(engine, "/things", `{"name":"a"}`)
+
+	if calls != 1 {
+		t.Fatalf("handler calls: got %d, want 1", calls)
+	}
+	if second.Code != http.StatusCreated || second.Body.String() != first.Body.String() {
+		t.Errorf("replay: got %d %q, want %d %q", second.Code, second.Body, first.Code, first.Body)
+	}
+	if got := second.Header().Get("Location"); got != "/api/things/1" {
+		t.Errorf("replayed Location: got %q", got)
+	}
+	if second.Header().Get("Idempotent-Replayed") != "true" {
+		t.Error("replay is not marked")
+	}
+
+	if w := send(engine, "/things", `{"name":"b"}`); w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("other body with the key: got %d, want %d", w.Code, http.StatusUnprocessableEntity)
+	}
+}
+
+func TestReplaySecret(t *testing.T) {
+	store := &memoryStore{records: map[string]*Record{}}
+	engine := newEngine(store)
+
+	calls := 0
+	engine.POST("/tokens", func(ctx *gin.Context) {
+		if status, id, ok := Replayed(ctx); ok {
+			ctx.JSON(status, gin.H{"id": id})
+			return
+		}
+		calls++
+		SetResource(ctx, "1")
+		ctx.JSON(http.StatusCreated, gin.H{"id": "1", "token": "secret"})
+	})
+
+	send(engine, "/tokens", `{}`)
+	if body := store.records["key"].Body; len(body) != 0 {
+		t.Fatalf("stored body: got %q, want none", body)
+	}
+
+	w := send(engine, "/tokens", `{}`)
+	if calls != 1 {
+		t.Fatalf("writes: got %d, want 1", calls)
+	}
+	if w.Code != http.StatusCreated || strings.Contains(w.Body.String(), "secret") {
+		t.Errorf("replay: got %d %q", w.Code, w.Body)
+	}
+}
+
+func TestPanicReleasesKey(t *testing.T) {
+	store := &memoryStore{records: map[string]*Record{}}
+	engine := newEngine(store)
+
+	fail := true
+	engine.POST("/things", func(ctx *gin.Context) {
+		if fail {
+			panic("boom")
+		}
+		ctx.Status(http.StatusCreated)
+	})
+
+	if w := send(engine, "/things", `{}`); w.Code != http.StatusInternalServerError {
+		t.Fatalf("panic: got %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+	if _, ok := store.records["key"]; ok {
+		t.Fatal("key is still claimed after a panic")
+	}
+
+	fail = false
+	if w := send(engine, "/things", `{}`); w.Code != http.StatusCreated {
+		t.Errorf("retry: got %d, want %d", w.Code, http.StatusCreated)
+	}
+}
@@ -0,0 +1,89 @@
+package idempotency
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"kanban/internal/postgres"
+	"time"
+)
+
+// PostgresStore keeps records in the idempotency_key table, so retries
+// reaching another instance are recognised too.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+func (s *PostgresStore) Claim(userID, key, fingerprint string, now, expiresAt time.Time) (bool, error) {
+	result, err := s.db.Exec(postgres.QueryClaimIdempotencyKey, userID, key, fingerprint, now, expiresAt)
+	if err != nil {
+		return false, fmt.Errorf("idempotency.PostgresStore.Claim: %w", err)
+	}
+
+	claimed, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("idempotency.PostgresStore.Claim: %w", err)
+	}
+	return claimed > 0, nil
+}
+
+func (s *PostgresStore) Get(userID, key string) (*Record, error) {
+	var record Record
+	err := s.db.QueryRow(postgres.QueryGetIdempotencyKey, userID, key).Scan(
+		&record.Fingerprint,
+		&record.Status,
+		&record.ContentType,
+		&record.Location,
+		&record.ResourceID,
+		&record.Body,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("idempotency.PostgresStore.Get: %w", err)
+	}
+	return &record, nil
+}
+
+func (s *PostgresStore) Save(userID, key string, response Response) error {
+	_, err := s.db.Exec(
+		postgres.QuerySaveIdempotentResponse,
+		response.Status,
+		response.ContentType,
+		response.Location,
+		response.ResourceID,
+		response.Body,
+		userID,
+		key,
+	)
+	if err != nil {
+		return fmt.Errorf("idempotency.PostgresStore.Save: %w", err)
+	}
+	return nil
+}
+
+func (s *PostgresStore) Release(userID, key string) error {
+	_, err := s.db.Exec(postgres.QueryReleaseIdempotencyKey, userID, key)
+	if err != nil {
+		return fmt.Errorf("idempotency.PostgresStore.Release: %w", err)
+	}
+	return nil
+}
+
+func (s *PostgresStore) DeleteExpired(now time.Time) (int, error) {
+	result, err := s.db.Exec(postgres.QueryDeleteExpiredIdempotencyKeys, now)
+	if err != nil {
+		return 0, fmt.Errorf("idempotency.PostgresStore.DeleteExpired: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("idempotency.PostgresStore.DeleteExpired: %w", err)
+	}
+	return int(deleted), nil
+}
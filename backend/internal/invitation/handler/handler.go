@@ -0,0 +1,235 @@
+package invitationHandler
+
+import (
+	"errors"
+	authctx "kanban/internal/auth/context"
+	invitationModel "kanban/internal/invitation/model"
+	invitationProxy "kanban/internal/invitation/proxy"
+	invitationService "kanban/internal/invitation/service"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Proxy interface {
+	CreateInvitation(boardID, userID string, req invitationModel.Request) (*invitationModel.Invitation, error)
+	GetInvitations(boardID, userID string) ([]invitationModel.Invitation, error)
+	RevokeInvitation(boardID, invitationID, userID string) error
+	AcceptInvitation(token, userID string) error
+	DeclineInvitation(token string) error
+	GetMembers(boardID, userID string) ([]invitationModel.Member, error)
+	RemoveMember(boardID, memberID, userID string) error
+}
+
+type Handler struct {
+	proxy Proxy
+}
+
+func NewHandler(proxy Proxy) *Handler {
+	return &Handler{proxy: proxy}
+}
+
+func (h *Handler) CreateInvitationHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req invitationModel.Request
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid request body",
+			})
+			return
+		}
+
+		boardID := ctx.Param("id")
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		invitation, err := h.proxy.CreateInvitation(boardID, userID, req)
+		if err != nil {
+			log.Printf("Failed to create invitation: %v", err)
+			h.handleError(ctx, err, "Failed to create invitation")
+			return
+		}
+
+		ctx.JSON(http.StatusCreated, invitation)
+	}
+}
+
+func (h *Handler) GetInvitationsHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		boardID := ctx.Param("id")
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		invitations, err := h.proxy.GetInvitations(boardID, userID)
+		if err != nil {
+			log.Printf("Failed to get invitations: %v", err)
+			h.handleError(ctx, err, "Failed to get invitations")
+			return
+		}
+
+		ctx.JSON(http.StatusOK, invitations)
+	}
+}
+
+func (h *Handler) RevokeInvitationHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		boardID := ctx.Param("id")
+		invitationID := ctx.Param("invitationID")
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		if err := h.proxy.RevokeInvitation(boardID, invitationID, userID); err != nil {
+			log.Printf("Failed to revoke invitation: %v", err)
+			h.handleError(ctx, err, "Failed to revoke invitation")
+			return
+		}
+
+		ctx.Status(http.StatusOK)
+	}
+}
+
+func (h *Handler) AcceptInvitationHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req invitationModel.TokenRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid request body",
+			})
+			return
+		}
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		if err := h.proxy.AcceptInvitation(req.Token, userID); err != nil {
+			log.Printf("Failed to accept invitation: %v", err)
+			h.handleError(ctx, err, "Failed to accept invitation")
+			return
+		}
+
+		ctx.Status(http.StatusOK)
+	}
+}
+
+func (h *Handler) DeclineInvitationHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req invitationModel.TokenRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid request body",
+			})
+			return
+		}
+
+		if err := h.proxy.DeclineInvitation(req.Token); err != nil {
+			log.Printf("Failed to decline invitation: %v", err)
+			h.handleError(ctx, err, "Failed to decline invitation")
+			return
+		}
+
+		ctx.Status(http.StatusOK)
+	}
+}
+
+func (h *Handler) GetMembersHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		boardID := ctx.Param("id")
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		members, err := h.proxy.GetMembers(boardID, userID)
+		if err != nil {
+			log.Printf("Failed to get board members: %v", err)
+			h.handleError(ctx, err, "Failed to get board members")
+			return
+		}
+
+		ctx.JSON(http.StatusOK, members)
+	}
+}
+
+func (h *Handler) RemoveMemberHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		boardID := ctx.Param("id")
+		memberID := ctx.Param("userID")
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		if err := h.proxy.RemoveMember(boardID, memberID, userID); err != nil {
+			log.Printf("Failed to remove board member: %v", err)
+			h.handleError(ctx, err, "Failed to remove board member")
+			return
+		}
+
+		ctx.Status(http.StatusOK)
+	}
+}
+
+func (h *Handler) handleError(ctx *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, invitationProxy.ErrForbidden):
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"detail": "Access denied",
+		})
+	case errors.Is(err, invitationService.ErrBoardNotFound):
+		ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+			"detail": "Board not found",
+		})
+	case errors.Is(err, invitationService.ErrMemberNotFound):
+		ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+			"detail": "Board member not found",
+		})
+	case errors.Is(err, invitationService.ErrInvitationNotFound):
+		ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+			"detail": "Invitation not found",
+		})
+	case errors.Is(err, invitationService.ErrInvalidInvitation):
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"detail": "Invalid or expired invitation",
+		})
+	case errors.Is(err, invitationService.ErrEmailMismatch):
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"detail": "Invitation was sent to another email",
+		})
+	default:
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"detail": message,
+		})
+	}
+}
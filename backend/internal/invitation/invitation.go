@@ -0,0 +1,32 @@
+package invitation
+
+import (
+	"database/sql"
+	invitationHandler "kanban/internal/invitation/handler"
+	invitationProxy "kanban/internal/invitation/proxy"
+	invitationRepo "kanban/internal/invitation/repo"
+	invitationService "kanban/internal/invitation/service"
+	"kanban/internal/mailer"
+	"kanban/internal/notify"
+	workspaceRepo "kanban/internal/workspace/repo"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Init registers the invitation routes. Declining needs only the link, so
+// it is served without authentication.
+func Init(db *sql.DB, grp *gin.RouterGroup, public *gin.RouterGroup) {
+	repo := invitationRepo.NewRepository(db)
+	service := invitationService.NewService(repo, mailer.New(), notify.New(db), workspaceRepo.NewRepository(db))
+	proxy := invitationProxy.NewProxy(service)
+	handler := invitationHandler.NewHandler(proxy)
+
+	grp.POST("/boards/:id/invitations", handler.CreateInvitationHandler())
+	grp.GET("/boards/:id/invitations", handler.GetInvitationsHandler())
+	grp.DELETE("/boards/:id/invitations/:invitationID", handler.RevokeInvitationHandler())
+	grp.POST("/invitations/accept", handler.AcceptInvitationHandler())
+	grp.GET("/boards/:id/members", handler.GetMembersHandler())
+	grp.DELETE("/boards/:id/members/:userID", handler.RemoveMemberHandler())
+
+	public.POST("/invitations/decline", handler.DeclineInvitationHandler())
+}
@@ -0,0 +1,47 @@
+package invitationModel
+
+import "time"
+
+// Board roles granted by an invitation. Board admins may invite others.
+const (
+	RoleAdmin  = "admin"
+	RoleMember = "member"
+)
+
+const (
+	StatusPending  = "pending"
+	StatusAccepted = "accepted"
+	StatusDeclined = "declined"
+	StatusRevoked  = "revoked"
+	StatusExpired  = "expired"
+)
+
+type Invitation struct {
+	ID          string     `json:"id"`
+	BoardID     string     `json:"board_id"`
+	Email       string     `json:"email"`
+	Role        string     `json:"role"`
+	InvitedBy   *string    `json:"invited_by"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	RespondedAt *time.Time `json:"responded_at"`
+}
+
+type Request struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role"  binding:"required,oneof=admin member"`
+}
+
+type TokenRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// Member is a user who joined the board through an invitation.
+type Member struct {
+	UserID    string    `json:"user_id"`
+	Email     string    `json:"email"`
+	Username  string    `json:"username"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,98 @@
+package invitationProxy
+
+import (
+	"errors"
+	"fmt"
+	invitationModel "kanban/internal/invitation/model"
+)
+
+var ErrForbidden = errors.New("access denied")
+
+type Service interface {
+	CreateInvitation(boardID, userID string, req invitationModel.Request) (*invitationModel.Invitation, error)
+	GetInvitations(boardID string) ([]invitationModel.Invitation, error)
+	RevokeInvitation(boardID, invitationID string) error
+	AcceptInvitation(token, userID string) error
+	DeclineInvitation(token string) error
+	CanShareBoard(boardID, userID string) (bool, error)
+	GetMembers(boardID string) ([]invitationModel.Member, error)
+	RemoveMember(boardID, userID string) error
+	CanManageBoard(boardID, userID string) (bool, error)
+}
+
+type Proxy struct {
+	service Service
+}
+
+func NewProxy(service Service) *Proxy {
+	return &Proxy{service: service}
+}
+
+func (p *Proxy) CreateInvitation(boardID, userID string, req invitationModel.Request) (*invitationModel.Invitation, error) {
+	if err := p.checkAdmin(boardID, userID); err != nil {
+		return nil, fmt.Errorf("invitationProxy.CreateInvitation: %w", err)
+	}
+
+	return p.service.CreateInvitation(boardID, userID, req)
+}
+
+func (p *Proxy) GetInvitations(boardID, userID string) ([]invitationModel.Invitation, error) {
+	if err := p.checkAdmin(boardID, userID); err != nil {
+		return nil, fmt.Errorf("invitationProxy.GetInvitations: %w", err)
+	}
+
+	return p.service.GetInvitations(boardID)
+}
+
+func (p *Proxy) RevokeInvitation(boardID, invitationID, userID string) error {
+	if err := p.checkAdmin(boardID, userID); err != nil {
+		return fmt.Errorf("invitationProxy.RevokeInvitation: %w", err)
+	}
+
+	return p.service.RevokeInvitation(boardID, invitationID)
+}
+
+func (p *Proxy) GetMembers(boardID, userID string) ([]invitationModel.Member, error) {
+	if err := p.checkAdmin(boardID, userID); err != nil {
+		return nil, fmt.Errorf("invitationProxy.GetMembers: %w", err)
+	}
+
+	return p.service.GetMembers(boardID)
+}
+
+// RemoveMember is left to the owners of the board, see CanManageBoard.
+// Invitees may leave the board themselves.
+func (p *Proxy) RemoveMember(boardID, memberID, userID string) error {
+	if memberID != userID {
+		canManage, err := p.service.CanManageBoard(boardID, userID)
+		if err != nil {
+			return fmt.Errorf("invitationProxy.RemoveMember: %w", err)
+		}
+		if !canManage {
+			return fmt.Errorf("invitationProxy.RemoveMember: %w", ErrForbidden)
+		}
+	}
+
+	return p.service.RemoveMember(boardID, memberID)
+}
+
+func (p *Proxy) AcceptInvitation(token, userID string) error {
+	return p.service.AcceptInvitation(token, userID)
+}
+
+func (p *Proxy) DeclineInvitation(token string) error {
+	return p.service.DeclineInvitation(token)
+}
+
+// checkAdmin lets those who can manage the board invite others, as well as
+// users invited to the board as admins, see CanShareBoard.
+func (p *Proxy) checkAdmin(boardID, userID string) error {
+	canShare, err := p.service.CanShareBoard(boardID, userID)
+	if err != nil {
+		return fmt.Errorf("invitationProxy.checkAdmin: %w", err)
+	}
+	if !canShare {
+		return ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package invitationRepo
+
+import (
+	"database/sql"
+	"fmt"
+	invitationModel "kanban/internal/invitation/model"
+	"kanban/internal/postgres"
+	"time"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Create stores the invitation and revokes the pending ones sent to the
+// same address before, so only the latest link works.
+func (r *Repository) Create(invitation invitationModel.Invitation) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("invitationRepo.Create: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(postgres.QueryRevokePendingInvitations, invitation.CreatedAt, invitation.BoardID, invitation.Email)
+	if err != nil {
+		return fmt.Errorf("invitationRepo.Create: %w", err)
+	}
+
+	_, err = tx.Exec(
+		postgres.QueryCreateInvitation,
+		invitation.ID,
+		invitation.BoardID,
+		invitation.Email,
+		invitation.Role,
+		invitation.InvitedBy,
+		invitation.CreatedAt,
+		invitation.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("invitationRepo.Create: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("invitationRepo.Create: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) GetByBoard(boardID string) ([]invitationModel.Invitation, error) {
+	rows, err := r.db.Query(postgres.QueryGetBoardInvitations, boardID)
+	if err != nil {
+		return nil, fmt.Errorf("invitationRepo.GetByBoard: %w", err)
+	}
+	defer rows.Close()
+
+	invitations := []invitationModel.Invitation{}
+	for rows.Next() {
+		var invitation invitationModel.Invitation
+		if err := rows.Scan(
+			&invitation.ID,
+			&invitation.BoardID,
+			&invitation.Email,
+			&invitation.Role,
+			&invitation.InvitedBy,
+			&invitation.Status,
+			&invitation.CreatedAt,
+			&invitation.ExpiresAt,
+			&invitation.RespondedAt,
+		); err != nil {
+			return nil, fmt.Errorf("invitationRepo.GetByBoard: %w", err)
+		}
+		invitations = append(invitations, invitation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("invitationRepo.GetByBoard: %w", err)
+	}
+	return invitations, nil
+}
+
+func (r *Repository) Revoke(boardID, invitationID string, now time.Time) error {
+	res, err := r.db.Exec(postgres.QueryRevokeInvitation, now, invitationID, boardID)
+	if err != nil {
+		return fmt.Errorf("invitationRepo.Revoke: %w", err)
+	}
+	return checkAffected("invitationRepo.Revoke", res)
+}
+
+// Accept marks a pending invitation accepted and gives the user access to
+// the board. The link was delivered to the user's address, so an
+// unverified email counts as verified from now on.
+func (r *Repository) Accept(invitationID, userID string, now time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("invitationRepo.Accept: %w", err)
+	}
+	defer tx.Rollback()
+
+	var boardID, role string
+	err = tx.QueryRow(postgres.QueryRespondInvitation, invitationModel.StatusAccepted, now, invitationID).Scan(&boardID, &role)
+	if err != nil {
+		return fmt.Errorf("invitationRepo.Accept: %w", err)
+	}
+
+	if _, err = tx.Exec(postgres.QueryAddBoardMember, boardID, userID, role, now); err != nil {
+		return fmt.Errorf("invitationRepo.Accept: %w", err)
+	}
+
+	if _, err = tx.Exec(postgres.QueryVerifyInviteeEmail, now, userID); err != nil {
+		return fmt.Errorf("invitationRepo.Accept: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("invitationRepo.Accept: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) Decline(invitationID string, now time.Time) error {
+	var boardID, role string
+	err := r.db.QueryRow(postgres.QueryRespondInvitation, invitationModel.StatusDeclined, now, invitationID).Scan(&boardID, &role)
+	if err != nil {
+		return fmt.Errorf("invitationRepo.Decline: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) GetBoardName(boardID string) (*string, error) {
+	var name string
+	if err := r.db.QueryRow(postgres.QueryGetInvitationBoardName, boardID).Scan(&name); err != nil {
+		return nil, fmt.Errorf("invitationRepo.GetBoardName: %w", err)
+	}
+	return &name, nil
+}
+
+func (r *Repository) GetUserEmail(userID string) (*string, error) {
+	var email string
+	if err := r.db.QueryRow(postgres.QueryGetInviteeEmail, userID).Scan(&email); err != nil {
+		return nil, fmt.Errorf("invitationRepo.GetUserEmail: %w", err)
+	}
+	return &email, nil
+}
+
+// GetInviteeID finds the account an invitation is sent to.
+func (r *Repository) GetInviteeID(email string) (*string, error) {
+	var userID string
+	if err := r.db.QueryRow(postgres.QueryGetInviteeByEmail, email).Scan(&userID); err != nil {
+		return nil, fmt.Errorf("invitationRepo.GetInviteeID: %w", err)
+	}
+	return &userID, nil
+}
+
+func (r *Repository) GetMembers(boardID string) ([]invitationModel.Member, error) {
+	rows, err := r.db.Query(postgres.QueryGetBoardMembers, boardID)
+	if err != nil {
+		return nil, fmt.Errorf("invitationRepo.GetMembers: %w", err)
+	}
+	defer rows.Close()
+
+	members := []invitationModel.Member{}
+	for rows.Next() {
+		var member invitationModel.Member
+		if err := rows.Scan(
+			&member.UserID,
+			&member.Email,
+			&member.Username,
+			&member.Role,
+			&member.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("invitationRepo.GetMembers: %w", err)
+		}
+		members = append(members, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("invitationRepo.GetMembers: %w", err)
+	}
+	return members, nil
+}
+
+// RemoveMember takes away the board access an invitation gave. The user
+// keeps access through the workspace if they are also a member of it.
+func (r *Repository) RemoveMember(boardID, userID string) error {
+	res, err := r.db.Exec(postgres.QueryRemoveBoardMember, boardID, userID)
+	if err != nil {
+		return fmt.Errorf("invitationRepo.RemoveMember: %w", err)
+	}
+	return checkAffected("invitationRepo.RemoveMember", res)
+}
+
+func checkAffected(op string, res sql.Result) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, sql.ErrNoRows)
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+package invitationService
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	authService "kanban/internal/auth/service"
+	"kanban/internal/config"
+	invitationModel "kanban/internal/invitation/model"
+	"kanban/internal/mailer"
+	"kanban/internal/notify"
+	"kanban/internal/utils"
+	"strings"
+	"time"
+)
+
+var ErrBoardNotFound = errors.New("board not found")
+var ErrInvitationNotFound = errors.New("invitation not found")
+var ErrInvalidInvitation = errors.New("invalid or expired invitation")
+var ErrEmailMismatch = errors.New("invitation was sent to another email")
+var ErrMemberNotFound = errors.New("board member not found")
+
+type Repository interface {
+	Create(invitation invitationModel.Invitation) error
+	GetByBoard(boardID string) ([]invitationModel.Invitation, error)
+	Revoke(boardID, invitationID string, now time.Time) error
+	Accept(invitationID, userID string, now time.Time) error
+	Decline(invitationID string, now time.Time) error
+	GetBoardName(boardID string) (*string, error)
+	GetUserEmail(userID string) (*string, error)
+	GetInviteeID(email string) (*string, error)
+	GetMembers(boardID string) ([]invitationModel.Member, error)
+	RemoveMember(boardID, userID string) error
+}
+
+// Access is the lookup of board permissions shared with other packages,
+// implemented by the workspace repository.
+type Access interface {
+	CanShareBoard(boardID, userID string) (bool, error)
+	CanManageBoard(boardID, userID string) (bool, error)
+}
+
+type Service struct {
+	repo     Repository
+	mailer   mailer.Mailer
+	notifier notify.Notifier
+	access   Access
+}
+
+func NewService(repo Repository, mailer mailer.Mailer, notifier notify.Notifier, access Access) *Service {
+	return &Service{repo: repo, mailer: mailer, notifier: notifier, access: access}
+}
+
+// CreateInvitation stores a pending invitation and mails its link. The
+// same link lets people without an account register and join the board.
+// The mail is sent whatever the invitee's preferences, the invitation is
+// bound to the address, and users with an account also see it in the app
+// if they chose to. The token is only in the link, never in the returned
+// invitation.
+func (s *Service) CreateInvitation(boardID, userID string, req invitationModel.Request) (*invitationModel.Invitation, error) {
+	name, err := s.repo.GetBoardName(boardID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("invitationService.CreateInvitation: %w", ErrBoardNotFound)
+		}
+		return nil, fmt.Errorf("invitationService.CreateInvitation: %w", err)
+	}
+
+	now := utils.GenerateTimestamp()
+	invitation := invitationModel.Invitation{
+		ID:        utils.NewUUID(),
+		BoardID:   boardID,
+		Email:     req.Email,
+		Role:      req.Role,
+		InvitedBy: &userID,
+		Status:    invitationModel.StatusPending,
+		CreatedAt: now,
+		ExpiresAt: now.Add(authService.InvitationTTL),
+	}
+
+	token, err := authService.GenerateInvitationToken(invitation.ID, invitation.Email)
+	if err != nil {
+		return nil, fmt.Errorf("invitationService.CreateInvitation: %w", err)
+	}
+
+	if err = s.repo.Create(invitation); err != nil {
+		return nil, fmt.Errorf("invitationService.CreateInvitation: %w", err)
+	}
+
+	body := fmt.Sprintf(
+		"Hi,\n\nyou have been invited to the board \"%s\". Open the link below to accept or decline the invitation, "+
+			"or to sign up if you do not have an account yet. It expires in %s.\n\n%s/invitations?token=%s",
+		*name, authService.InvitationTTL, config.Get().AppURL, token,
+	)
+	if err = s.mailer.Send(invitation.Email, "Board invitation", body); err != nil {
+		return nil, fmt.Errorf("invitationService.CreateInvitation: %w", err)
+	}
+
+	// The link has been mailed already, so the notification goes without
+	// the address and only reaches the in-app channel.
+	inviteeID, err := s.repo.GetInviteeID(invitation.Email)
+	switch {
+	case err == nil:
+		err = s.notifier.Notify(notify.Notification{
+			UserID:  *inviteeID,
+			Kind:    notify.KindBoardInvitation,
+			Title:   "Board invitation",
+			Body:    body,
+			BoardID: &boardID,
+		})
+	case errors.Is(err, sql.ErrNoRows):
+		err = nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invitationService.CreateInvitation: %w", err)
+	}
+
+	return &invitation, nil
+}
+
+func (s *Service) GetInvitations(boardID string) ([]invitationModel.Invitation, error) {
+	invitations, err := s.repo.GetByBoard(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("invitationService.GetInvitations: %w", err)
+	}
+
+	return invitations, nil
+}
+
+func (s *Service) RevokeInvitation(boardID, invitationID string) error {
+	if err := s.repo.Revoke(boardID, invitationID, utils.GenerateTimestamp()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("invitationService.RevokeInvitation: %w", ErrInvitationNotFound)
+		}
+		return fmt.Errorf("invitationService.RevokeInvitation: %w", err)
+	}
+
+	return nil
+}
+
+// AcceptInvitation gives the user access to the board. The invitation is
+// bound to the address it was sent to, so a forwarded link is of no use.
+func (s *Service) AcceptInvitation(token, userID string) error {
+	claims, err := authService.ValidateInvitationToken(token)
+	if err != nil {
+		return fmt.Errorf("invitationService.AcceptInvitation: %w", ErrInvalidInvitation)
+	}
+
+	email, err := s.repo.GetUserEmail(userID)
+	if err != nil {
+		return fmt.Errorf("invitationService.AcceptInvitation: %w", err)
+	}
+	if !strings.EqualFold(*email, claims.Email) {
+		return fmt.Errorf("invitationService.AcceptInvitation: %w", ErrEmailMismatch)
+	}
+
+	if err = s.repo.Accept(claims.InvitationID, userID, utils.GenerateTimestamp()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("invitationService.AcceptInvitation: %w", ErrInvalidInvitation)
+		}
+		return fmt.Errorf("invitationService.AcceptInvitation: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetMembers(boardID string) ([]invitationModel.Member, error) {
+	members, err := s.repo.GetMembers(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("invitationService.GetMembers: %w", err)
+	}
+
+	return members, nil
+}
+
+func (s *Service) RemoveMember(boardID, userID string) error {
+	if err := s.repo.RemoveMember(boardID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("invitationService.RemoveMember: %w", ErrMemberNotFound)
+		}
+		return fmt.Errorf("invitationService.RemoveMember: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) CanManageBoard(boardID, userID string) (bool, error) {
+	canManage, err := s.access.CanManageBoard(boardID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, fmt.Errorf("invitationService.CanManageBoard: %w", ErrBoardNotFound)
+		}
+		return false, fmt.Errorf("invitationService.CanManageBoard: %w", err)
+	}
+
+	return canManage, nil
+}
+
+// DeclineInvitation needs only the link, invitees may not have an account.
+func (s *Service) DeclineInvitation(token string) error {
+	claims, err := authService.ValidateInvitationToken(token)
+	if err != nil {
+		return fmt.Errorf("invitationService.DeclineInvitation: %w", ErrInvalidInvitation)
+	}
+
+	if err = s.repo.Decline(claims.InvitationID, utils.GenerateTimestamp()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("invitationService.DeclineInvitation: %w", ErrInvalidInvitation)
+		}
+		return fmt.Errorf("invitationService.DeclineInvitation: %w", err)
+	}
+
+	return nil
+}
+
+// CanShareBoard reports whether the user may invite others to the board and
+// publish it.
+func (s *Service) CanShareBoard(boardID, userID string) (bool, error) {
+	canShare, err := s.access.CanShareBoard(boardID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, fmt.Errorf("invitationService.CanShareBoard: %w", ErrBoardNotFound)
+		}
+		return false, fmt.Errorf("invitationService.CanShareBoard: %w", err)
+	}
+
+	return canShare, nil
+}
@@ -0,0 +1,199 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	ldapv3 "github.com/go-ldap/ldap/v3"
+)
+
+var ErrInvalidCredentials = errors.New("invalid directory credentials")
+
+// timeout bounds connecting to the directory and each request to it.
+const timeout = 10 * time.Second
+
+type Config struct {
+	URL          string
+	StartTLS     bool
+	BindDN       string
+	BindPassword string
+	BaseDN       string
+	// UserFilter finds the entry of a login, %s is replaced with the escaped login.
+	UserFilter  string
+	IDAttribute string
+	// GroupBaseDN enables searching groups by member. Without it groups are
+	// read from the memberOf attribute of the user entry.
+	GroupBaseDN string
+}
+
+// Entry is the directory user a login resolved to.
+type Entry struct {
+	DN     string
+	ID     string
+	Email  string
+	Name   string
+	Groups []string
+}
+
+// Directory authenticates users by binding to an LDAP server as them. The
+// user entry is looked up with the search account, or anonymously when no
+// bind DN is configured.
+type Directory struct {
+	config Config
+}
+
+func NewDirectory(config Config) *Directory {
+	return &Directory{config: config}
+}
+
+func (d *Directory) URL() string {
+	return d.config.URL
+}
+
+// Authenticate checks the password of a login and returns its entry. Logins
+// matching no entry, or more than one, and wrong passwords are reported as
+// ErrInvalidCredentials.
+func (d *Directory) Authenticate(login, password string) (*Entry, error) {
+	// An empty password makes an unauthenticated bind, which servers accept.
+	if login == "" || password == "" {
+		return nil, fmt.Errorf("ldap.Authenticate: %w", ErrInvalidCredentials)
+	}
+
+	conn, err := d.dial()
+	if err != nil {
+		return nil, fmt.Errorf("ldap.Authenticate: %w", err)
+	}
+	defer conn.Close()
+
+	if err = d.bindSearch(conn); err != nil {
+		return nil, fmt.Errorf("ldap.Authenticate: %w", err)
+	}
+
+	entry, err := d.findUser(conn, login)
+	if err != nil {
+		return nil, fmt.Errorf("ldap.Authenticate: %w", err)
+	}
+
+	err = conn.Bind(entry.DN, password)
+	if ldapv3.IsErrorWithCode(err, ldapv3.LDAPResultInvalidCredentials) {
+		return nil, fmt.Errorf("ldap.Authenticate: %w", ErrInvalidCredentials)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ldap.Authenticate: %w", err)
+	}
+
+	if d.config.GroupBaseDN != "" {
+		// Groups may not be readable by the user, look them up as the search account.
+		if err = d.bindSearch(conn); err != nil {
+			return nil, fmt.Errorf("ldap.Authenticate: %w", err)
+		}
+		entry.Groups, err = d.findGroups(conn, entry.DN)
+		if err != nil {
+			return nil, fmt.Errorf("ldap.Authenticate: %w", err)
+		}
+	}
+
+	return entry, nil
+}
+
+func (d *Directory) dial() (*ldapv3.Conn, error) {
+	conn, err := ldapv3.DialURL(d.config.URL, ldapv3.DialWithDialer(&net.Dialer{Timeout: timeout}))
+	if err != nil {
+		return nil, err
+	}
+	conn.SetTimeout(timeout)
+
+	if d.config.StartTLS {
+		host := strings.TrimPrefix(d.config.URL, "ldap://")
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if err = conn.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+func (d *Directory) bindSearch(conn *ldapv3.Conn) error {
+	if d.config.BindDN == "" {
+		return conn.UnauthenticatedBind("")
+	}
+	return conn.Bind(d.config.BindDN, d.config.BindPassword)
+}
+
+func (d *Directory) findUser(conn *ldapv3.Conn, login string) (*Entry, error) {
+	res, err := conn.Search(ldapv3.NewSearchRequest(
+		d.config.BaseDN,
+		ldapv3.ScopeWholeSubtree,
+		ldapv3.NeverDerefAliases,
+		2,
+		0,
+		false,
+		fmt.Sprintf(d.config.UserFilter, ldapv3.EscapeFilter(login)),
+		[]string{d.config.IDAttribute, "mail", "displayName", "cn", "memberOf"},
+		nil,
+	))
+	if err != nil && !ldapv3.IsErrorWithCode(err, ldapv3.LDAPResultSizeLimitExceeded) {
+		return nil, err
+	}
+	if res == nil || len(res.Entries) != 1 {
+		return nil, ErrInvalidCredentials
+	}
+
+	found := res.Entries[0]
+	entry := &Entry{
+		DN:     found.DN,
+		ID:     attributeString(found.GetRawAttributeValue(d.config.IDAttribute)),
+		Email:  found.GetAttributeValue("mail"),
+		Name:   found.GetAttributeValue("displayName"),
+		Groups: found.GetAttributeValues("memberOf"),
+	}
+	if entry.Name == "" {
+		entry.Name = found.GetAttributeValue("cn")
+	}
+	if entry.ID == "" {
+		return nil, fmt.Errorf("entry %s has no %s attribute", entry.DN, d.config.IDAttribute)
+	}
+	return entry, nil
+}
+
+func (d *Directory) findGroups(conn *ldapv3.Conn, userDN string) ([]string, error) {
+	dn := ldapv3.EscapeFilter(userDN)
+	res, err := conn.Search(ldapv3.NewSearchRequest(
+		d.config.GroupBaseDN,
+		ldapv3.ScopeWholeSubtree,
+		ldapv3.NeverDerefAliases,
+		0,
+		0,
+		false,
+		fmt.Sprintf("(|(member=%s)(uniqueMember=%s))", dn, dn),
+		[]string{"dn"},
+		nil,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]string, 0, len(res.Entries))
+	for _, entry := range res.Entries {
+		groups = append(groups, entry.DN)
+	}
+	return groups, nil
+}
+
+// attributeString keeps textual IDs such as entryUUID as they are and
+// hex-encodes binary ones such as Active Directory's objectGUID.
+func attributeString(raw []byte) string {
+	if utf8.Valid(raw) {
+		return string(raw)
+	}
+	return hex.EncodeToString(raw)
+}
@@ -0,0 +1,98 @@
+package mailer
+
+import (
+	"fmt"
+	"kanban/internal/config"
+	"log"
+	"net"
+	"net/smtp"
+	"strings"
+	"sync"
+)
+
+type Mailer interface {
+	Send(to, subject, body string) error
+}
+
+// New returns an SMTP mailer when SMTP_ADDR is configured and a mailer
+// that only logs outgoing mail otherwise.
+func New() Mailer {
+	cfg := config.Get()
+	if cfg.SMTPAddr == "" {
+		return &LogMailer{}
+	}
+	return NewSMTPMailer(cfg.SMTPAddr, cfg.SMTPFrom, cfg.SMTPUsername, cfg.SMTPPassword)
+}
+
+type SMTPMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+func NewSMTPMailer(addr, from, username, password string) *SMTPMailer {
+	m := &SMTPMailer{addr: addr, from: from}
+	if username != "" {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			host = addr
+		}
+		m.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return m
+}
+
+func (m *SMTPMailer) Send(to, subject, body string) error {
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", m.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	err := smtp.SendMail(m.addr, m.auth, m.from, []string{to}, []byte(msg.String()))
+	if err != nil {
+		return fmt.Errorf("mailer.Send: %w", err)
+	}
+	return nil
+}
+
+type LogMailer struct{}
+
+func (m *LogMailer) Send(to, subject, body string) error {
+	log.Printf("Mail to %s: %s\n%s", to, subject, body)
+	return nil
+}
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// MemoryMailer keeps sent messages in memory instead of delivering them.
+type MemoryMailer struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+func (m *MemoryMailer) Send(to, subject, body string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = append(m.messages, Message{To: to, Subject: subject, Body: body})
+	return nil
+}
+
+func (m *MemoryMailer) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Message(nil), m.messages...)
+}
@@ -0,0 +1,195 @@
+package notificationHandler
+
+import (
+	"errors"
+	authctx "kanban/internal/auth/context"
+	notificationModel "kanban/internal/notification/model"
+	notificationProxy "kanban/internal/notification/proxy"
+	notificationService "kanban/internal/notification/service"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Proxy interface {
+	GetNotifications(userID string, query notificationModel.ListQuery) (*notificationModel.Page, error)
+	GetUnreadCount(userID string) (*notificationModel.UnreadCount, error)
+	MarkRead(notificationID, userID string) error
+	MarkAllRead(userID string) error
+	GetPreferences(userID string) ([]notificationModel.Preference, error)
+	UpdatePreferences(userID string, req notificationModel.PreferencesRequest) error
+}
+
+type Handler struct {
+	proxy Proxy
+}
+
+func NewHandler(proxy Proxy) *Handler {
+	return &Handler{proxy: proxy}
+}
+
+func (h *Handler) GetNotificationsHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var query notificationModel.ListQuery
+		if err := ctx.ShouldBindQuery(&query); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid query parameters",
+			})
+			return
+		}
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		page, err := h.proxy.GetNotifications(userID, query)
+		if err != nil {
+			log.Printf("Failed to get notifications: %v", err)
+			h.handleError(ctx, err, "Failed to get notifications")
+			return
+		}
+
+		ctx.JSON(http.StatusOK, page)
+	}
+}
+
+func (h *Handler) GetUnreadCountHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		count, err := h.proxy.GetUnreadCount(userID)
+		if err != nil {
+			log.Printf("Failed to get unread count: %v", err)
+			h.handleError(ctx, err, "Failed to get unread count")
+			return
+		}
+
+		ctx.JSON(http.StatusOK, count)
+	}
+}
+
+func (h *Handler) MarkReadHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		notificationID := ctx.Param("id")
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		err := h.proxy.MarkRead(notificationID, userID)
+		if err != nil {
+			log.Printf("Failed to mark notification as read: %v", err)
+			h.handleError(ctx, err, "Failed to mark notification as read")
+			return
+		}
+
+		ctx.Status(http.StatusOK)
+	}
+}
+
+func (h *Handler) MarkAllReadHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		err := h.proxy.MarkAllRead(userID)
+		if err != nil {
+			log.Printf("Failed to mark notifications as read: %v", err)
+			h.handleError(ctx, err, "Failed to mark notifications as read")
+			return
+		}
+
+		ctx.Status(http.StatusOK)
+	}
+}
+
+func (h *Handler) GetPreferencesHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		preferences, err := h.proxy.GetPreferences(userID)
+		if err != nil {
+			log.Printf("Failed to get notification preferences: %v", err)
+			h.handleError(ctx, err, "Failed to get notification preferences")
+			return
+		}
+
+		ctx.JSON(http.StatusOK, preferences)
+	}
+}
+
+func (h *Handler) UpdatePreferencesHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req notificationModel.PreferencesRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid request body",
+			})
+			return
+		}
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		err := h.proxy.UpdatePreferences(userID, req)
+		if err != nil {
+			log.Printf("Failed to update notification preferences: %v", err)
+			h.handleError(ctx, err, "Failed to update notification preferences")
+			return
+		}
+
+		ctx.Status(http.StatusOK)
+	}
+}
+
+func (h *Handler) handleError(ctx *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, notificationProxy.ErrForbidden):
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"detail": "Access denied",
+		})
+	case errors.Is(err, notificationService.ErrNotificationNotFound):
+		ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+			"detail": "Notification not found",
+		})
+	case errors.Is(err, notificationService.ErrUnknownKind):
+		ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{
+			"detail": "Unknown notification kind",
+		})
+	default:
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"detail": message,
+		})
+	}
+}
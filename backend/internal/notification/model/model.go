@@ -0,0 +1,41 @@
+package notificationModel
+
+import "time"
+
+type Notification struct {
+	ID        string     `json:"id"`
+	UserID    string     `json:"user_id"`
+	CreatedAt time.Time  `json:"created_at"`
+	Kind      string     `json:"kind"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	BoardID   *string    `json:"board_id"`
+	TaskID    *string    `json:"task_id"`
+	ReadAt    *time.Time `json:"read_at"`
+}
+
+type Page struct {
+	Notifications []Notification `json:"notifications"`
+	Total         int            `json:"total"`
+	Limit         int            `json:"limit"`
+	Offset        int            `json:"offset"`
+}
+
+type ListQuery struct {
+	Limit  int  `form:"limit"  binding:"omitempty,min=1,max=100"`
+	Offset int  `form:"offset" binding:"omitempty,min=0"`
+	Unread bool `form:"unread"`
+}
+
+type UnreadCount struct {
+	Count int `json:"count"`
+}
+
+type Preference struct {
+	Kind     string   `json:"kind"     binding:"required"`
+	Channels []string `json:"channels" binding:"dive,oneof=in_app email"`
+}
+
+type PreferencesRequest struct {
+	Preferences []Preference `json:"preferences" binding:"required,dive"`
+}
@@ -0,0 +1,25 @@
+package notification
+
+import (
+	"database/sql"
+	notificationHandler "kanban/internal/notification/handler"
+	notificationProxy "kanban/internal/notification/proxy"
+	notificationRepo "kanban/internal/notification/repo"
+	notificationService "kanban/internal/notification/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Init(db *sql.DB, grp *gin.RouterGroup) {
+	repo := notificationRepo.NewRepository(db)
+	service := notificationService.NewService(repo)
+	proxy := notificationProxy.NewProxy(service)
+	handler := notificationHandler.NewHandler(proxy)
+
+	grp.GET("/me/notifications", handler.GetNotificationsHandler())
+	grp.GET("/me/notifications/unread-count", handler.GetUnreadCountHandler())
+	grp.POST("/me/notifications/read-all", handler.MarkAllReadHandler())
+	grp.POST("/me/notifications/:id/read", handler.MarkReadHandler())
+	grp.GET("/me/notification-preferences", handler.GetPreferencesHandler())
+	grp.PUT("/me/notification-preferences", handler.UpdatePreferencesHandler())
+}
@@ -0,0 +1,69 @@
+package notificationProxy
+
+import (
+	"errors"
+	"fmt"
+	notificationModel "kanban/internal/notification/model"
+)
+
+var ErrForbidden = errors.New("access denied")
+
+type Service interface {
+	GetNotifications(userID string, query notificationModel.ListQuery) (*notificationModel.Page, error)
+	GetUnreadCount(userID string) (*notificationModel.UnreadCount, error)
+	MarkRead(notificationID string) error
+	MarkAllRead(userID string) error
+	GetPreferences(userID string) ([]notificationModel.Preference, error)
+	UpdatePreferences(userID string, req notificationModel.PreferencesRequest) error
+	GetUserByNotification(notificationID string) (*string, error)
+}
+
+type Proxy struct {
+	service Service
+}
+
+func NewProxy(service Service) *Proxy {
+	return &Proxy{service: service}
+}
+
+func (p *Proxy) GetNotifications(userID string, query notificationModel.ListQuery) (*notificationModel.Page, error) {
+	return p.service.GetNotifications(userID, query)
+}
+
+func (p *Proxy) GetUnreadCount(userID string) (*notificationModel.UnreadCount, error) {
+	return p.service.GetUnreadCount(userID)
+}
+
+func (p *Proxy) MarkRead(notificationID, userID string) error {
+	isOwner, err := p.checkNotificationOwnership(notificationID, userID)
+	if err != nil {
+		return fmt.Errorf("notificationProxy.MarkRead: %w", err)
+	}
+
+	if isOwner {
+		return p.service.MarkRead(notificationID)
+	} else {
+		return fmt.Errorf("notificationProxy.MarkRead: %w", ErrForbidden)
+	}
+}
+
+func (p *Proxy) MarkAllRead(userID string) error {
+	return p.service.MarkAllRead(userID)
+}
+
+func (p *Proxy) GetPreferences(userID string) ([]notificationModel.Preference, error) {
+	return p.service.GetPreferences(userID)
+}
+
+func (p *Proxy) UpdatePreferences(userID string, req notificationModel.PreferencesRequest) error {
+	return p.service.UpdatePreferences(userID, req)
+}
+
+func (p *Proxy) checkNotificationOwnership(notificationID, userID string) (bool, error) {
+	realUserID, err := p.service.GetUserByNotification(notificationID)
+	if err != nil {
+		return false, fmt.Errorf("notificationProxy.checkNotificationOwnership: %w", err)
+	}
+
+	return *realUserID == userID, nil
+}
@@ -0,0 +1,143 @@
+package notificationRepo
+
+import (
+	"database/sql"
+	"fmt"
+	notificationModel "kanban/internal/notification/model"
+	"kanban/internal/postgres"
+	"kanban/internal/utils"
+
+	"github.com/lib/pq"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) GetAll(userID string, query notificationModel.ListQuery) ([]notificationModel.Notification, error) {
+	rows, err := r.db.Query(
+		postgres.QueryGetNotifications,
+		userID,
+		query.Unread,
+		query.Limit,
+		query.Offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("notificationRepo.GetAll: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := []notificationModel.Notification{}
+	for rows.Next() {
+		var n notificationModel.Notification
+		if err := rows.Scan(
+			&n.ID,
+			&n.UserID,
+			&n.CreatedAt,
+			&n.Kind,
+			&n.Title,
+			&n.Body,
+			&n.BoardID,
+			&n.TaskID,
+			&n.ReadAt,
+		); err != nil {
+			return nil, fmt.Errorf("notificationRepo.GetAll: %w", err)
+		}
+		notifications = append(notifications, n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("notificationRepo.GetAll: %w", err)
+	}
+
+	return notifications, nil
+}
+
+func (r *Repository) Count(userID string, unread bool) (int, error) {
+	var count int
+	err := r.db.QueryRow(postgres.QueryGetNotificationsCount, userID, unread).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("notificationRepo.Count: %w", err)
+	}
+	return count, nil
+}
+
+func (r *Repository) MarkRead(notificationID string) error {
+	_, err := r.db.Exec(postgres.QueryMarkNotificationRead, utils.GenerateTimestamp(), notificationID)
+	if err != nil {
+		return fmt.Errorf("notificationRepo.MarkRead: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) MarkAllRead(userID string) error {
+	_, err := r.db.Exec(postgres.QueryMarkAllNotificationsRead, utils.GenerateTimestamp(), userID)
+	if err != nil {
+		return fmt.Errorf("notificationRepo.MarkAllRead: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) GetPreferences(userID string) (map[string][]string, error) {
+	rows, err := r.db.Query(postgres.QueryGetNotificationPreferences, userID)
+	if err != nil {
+		return nil, fmt.Errorf("notificationRepo.GetPreferences: %w", err)
+	}
+	defer rows.Close()
+
+	preferences := make(map[string][]string)
+	for rows.Next() {
+		var kind string
+		var channels pq.StringArray
+		if err := rows.Scan(&kind, &channels); err != nil {
+			return nil, fmt.Errorf("notificationRepo.GetPreferences: %w", err)
+		}
+		preferences[kind] = channels
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("notificationRepo.GetPreferences: %w", err)
+	}
+
+	return preferences, nil
+}
+
+func (r *Repository) UpsertPreferences(userID string, preferences []notificationModel.Preference) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("notificationRepo.UpsertPreferences: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := utils.GenerateTimestamp()
+	for _, preference := range preferences {
+		_, err = tx.Exec(
+			postgres.QueryUpsertNotificationPreference,
+			userID,
+			preference.Kind,
+			now,
+			pq.StringArray(preference.Channels),
+		)
+		if err != nil {
+			return fmt.Errorf("notificationRepo.UpsertPreferences: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("notificationRepo.UpsertPreferences: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) GetUserByNotification(notificationID string) (*string, error) {
+	var userID string
+	err := r.db.QueryRow(postgres.QueryGetUserByNotificationID, notificationID).Scan(&userID)
+	if err != nil {
+		return nil, fmt.Errorf("notificationRepo.GetUserByNotification: %w", err)
+	}
+	return &userID, nil
+}
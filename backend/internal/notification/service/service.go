@@ -0,0 +1,136 @@
+package notificationService
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	notificationModel "kanban/internal/notification/model"
+	"kanban/internal/notify"
+	"slices"
+)
+
+const defaultPageSize int = 20
+
+var ErrNotificationNotFound = errors.New("notification not found")
+var ErrUnknownKind = errors.New("unknown notification kind")
+
+type Repository interface {
+	GetAll(userID string, query notificationModel.ListQuery) ([]notificationModel.Notification, error)
+	Count(userID string, unread bool) (int, error)
+	MarkRead(notificationID string) error
+	MarkAllRead(userID string) error
+	GetPreferences(userID string) (map[string][]string, error)
+	UpsertPreferences(userID string, preferences []notificationModel.Preference) error
+	GetUserByNotification(notificationID string) (*string, error)
+}
+
+type Service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+func (s *Service) GetNotifications(userID string, query notificationModel.ListQuery) (*notificationModel.Page, error) {
+	if query.Limit == 0 {
+		query.Limit = defaultPageSize
+	}
+
+	notifications, err := s.repo.GetAll(userID, query)
+	if err != nil {
+		return nil, fmt.Errorf("notificationService.GetNotifications: %w", err)
+	}
+
+	total, err := s.repo.Count(userID, query.Unread)
+	if err != nil {
+		return nil, fmt.Errorf("notificationService.GetNotifications: %w", err)
+	}
+
+	return &notificationModel.Page{
+		Notifications: notifications,
+		Total:         total,
+		Limit:         query.Limit,
+		Offset:        query.Offset,
+	}, nil
+}
+
+func (s *Service) GetUnreadCount(userID string) (*notificationModel.UnreadCount, error) {
+	count, err := s.repo.Count(userID, true)
+	if err != nil {
+		return nil, fmt.Errorf("notificationService.GetUnreadCount: %w", err)
+	}
+
+	return &notificationModel.UnreadCount{Count: count}, nil
+}
+
+func (s *Service) MarkRead(notificationID string) error {
+	err := s.repo.MarkRead(notificationID)
+	if err != nil {
+		return fmt.Errorf("notificationService.MarkRead: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) MarkAllRead(userID string) error {
+	err := s.repo.MarkAllRead(userID)
+	if err != nil {
+		return fmt.Errorf("notificationService.MarkAllRead: %w", err)
+	}
+
+	return nil
+}
+
+// GetPreferences returns the channels for every notification kind, filling
+// in the defaults for kinds the user has not configured.
+func (s *Service) GetPreferences(userID string) ([]notificationModel.Preference, error) {
+	stored, err := s.repo.GetPreferences(userID)
+	if err != nil {
+		return nil, fmt.Errorf("notificationService.GetPreferences: %w", err)
+	}
+
+	preferences := make([]notificationModel.Preference, 0, len(notify.Kinds))
+	for _, kind := range notify.Kinds {
+		channels, ok := stored[kind]
+		if !ok {
+			channels = notify.DefaultChannels
+		}
+		preferences = append(preferences, notificationModel.Preference{
+			Kind:     kind,
+			Channels: channels,
+		})
+	}
+
+	return preferences, nil
+}
+
+func (s *Service) UpdatePreferences(userID string, req notificationModel.PreferencesRequest) error {
+	for i, preference := range req.Preferences {
+		if !slices.Contains(notify.Kinds, preference.Kind) {
+			return fmt.Errorf("notificationService.UpdatePreferences: %w: %s", ErrUnknownKind, preference.Kind)
+		}
+		if preference.Channels == nil {
+			req.Preferences[i].Channels = []string{}
+		}
+	}
+
+	err := s.repo.UpsertPreferences(userID, req.Preferences)
+	if err != nil {
+		return fmt.Errorf("notificationService.UpdatePreferences: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetUserByNotification(notificationID string) (*string, error) {
+	userID, err := s.repo.GetUserByNotification(notificationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("notificationService.GetUserByNotification: %w", ErrNotificationNotFound)
+		}
+		return nil, fmt.Errorf("notificationService.GetUserByNotification: %w", err)
+	}
+
+	return userID, nil
+}
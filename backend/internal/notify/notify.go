@@ -0,0 +1,139 @@
+package notify
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"kanban/internal/mailer"
+	"kanban/internal/postgres"
+	"kanban/internal/utils"
+
+	"github.com/lib/pq"
+)
+
+const (
+	KindDeadlineReminder string = "deadline_reminder"
+	KindTaskOverdue      string = "task_overdue"
+	KindMention          string = "mention"
+	KindBoardInvitation  string = "board_invitation"
+)
+
+// Kinds lists every event a user can configure notification channels for.
+// Only add a kind here together with the code that emits it.
+var Kinds = []string{
+	KindDeadlineReminder,
+	KindTaskOverdue,
+	KindMention,
+	KindBoardInvitation,
+}
+
+const (
+	ChannelInApp string = "in_app"
+	ChannelEmail string = "email"
+)
+
+// DefaultChannels are used for events the user has not configured.
+var DefaultChannels = []string{ChannelInApp, ChannelEmail}
+
+type Notification struct {
+	UserID  string
+	Email   string
+	Kind    string
+	Title   string
+	Body    string
+	BoardID *string
+	TaskID  *string
+}
+
+type Notifier interface {
+	Notify(n Notification) error
+}
+
+// New returns the notifier used by the application: in-app and email
+// delivery routed according to each user's preferences.
+func New(db *sql.DB) Notifier {
+	return NewDispatcher(db, map[string]Notifier{
+		ChannelInApp: NewInApp(db),
+		ChannelEmail: NewEmail(mailer.New()),
+	})
+}
+
+// Dispatcher delivers a notification through the channels the recipient
+// enabled for its kind.
+type Dispatcher struct {
+	db       *sql.DB
+	channels map[string]Notifier
+}
+
+func NewDispatcher(db *sql.DB, channels map[string]Notifier) *Dispatcher {
+	return &Dispatcher{db: db, channels: channels}
+}
+
+func (d *Dispatcher) Notify(n Notification) error {
+	var channels pq.StringArray
+	err := d.db.QueryRow(postgres.QueryGetNotificationChannels, n.UserID, n.Kind).Scan(&channels)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("notify.Dispatcher.Notify: %w", err)
+		}
+		channels = DefaultChannels
+	}
+
+	var errs []error
+	for _, channel := range channels {
+		notifier, ok := d.channels[channel]
+		if !ok {
+			continue
+		}
+		if err := notifier.Notify(n); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+type InApp struct {
+	db *sql.DB
+}
+
+func NewInApp(db *sql.DB) *InApp {
+	return &InApp{db: db}
+}
+
+func (i *InApp) Notify(n Notification) error {
+	_, err := i.db.Exec(
+		postgres.QueryCreateNotification,
+		utils.NewUUID(),
+		n.UserID,
+		utils.GenerateTimestamp(),
+		n.Kind,
+		n.Title,
+		n.Body,
+		n.BoardID,
+		n.TaskID,
+	)
+	if err != nil {
+		return fmt.Errorf("notify.InApp.Notify: %w", err)
+	}
+	return nil
+}
+
+type Email struct {
+	mailer mailer.Mailer
+}
+
+func NewEmail(mailer mailer.Mailer) *Email {
+	return &Email{mailer: mailer}
+}
+
+func (e *Email) Notify(n Notification) error {
+	if n.Email == "" {
+		return nil
+	}
+
+	err := e.mailer.Send(n.Email, n.Title, n.Body)
+	if err != nil {
+		return fmt.Errorf("notify.Email.Notify: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package oidc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"kanban/internal/jwk"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// mockProvider is an identity provider issuing one authorization code,
+// whose ID token claims the test may tamper with.
+type mockProvider struct {
+	server    *httptest.Server
+	key       *ecdsa.PrivateKey
+	signer    *ecdsa.PrivateKey
+	challenge string
+	nonce     string
+	tamper    func(claims jwt.MapClaims)
+}
+
+func newMockProvider(t *testing.T) *mockProvider {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &mockProvider{key: key, signer: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(Metadata{
+			Issuer:                p.server.URL,
+			AuthorizationEndpoint: p.server.URL + "/authorize",
+			TokenEndpoint:         p.server.URL + "/token",
+			JWKSURI:               p.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		k, err := jwk.FromPublicKey("idp", "ES256", &p.key.PublicKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(jwk.Set{Keys: []jwk.Key{k}})
+	})
+	mux.HandleFunc("/token", p.token)
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+// authorize stands in for the user logging in at the provider and returns
+// the code sent back to the callback.
+func (p *mockProvider) authorize(t *testing.T, authURL string) string {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := u.Query()
+	if query.Get("code_challenge_method") != "S256" {
+		t.Fatalf("code challenge method: got %q", query.Get("code_challenge_method"))
+	}
+	p.challenge = query.Get("code_challenge")
+	p.nonce = query.Get("nonce")
+	return "code"
+}
+
+func (p *mockProvider) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("code") != "code" {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+		return
+	}
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(sum[:]) != p.challenge {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            p.server.URL,
+		"sub":            "alice-subject",
+		"aud":            "kanban",
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"nonce":          p.nonce,
+		"email":          "alice@example.org",
+		"email_verified": "true",
+	}
+	if p.tamper != nil {
+		p.tamper(claims)
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header["kid"] = "idp"
+	idToken, err := token.SignedString(p.signer)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]string{"id_token": idToken})
+}
+
+func TestLogin(t *testing.T) {
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		tamper   func(claims jwt.MapClaims)
+		forge    bool
+		verifier string
+		wantErr  error
+	}{
+		{
+			name: "login",
+		},
+		{
+			name:    "replayed nonce",
+			tamper:  func(claims jwt.MapClaims) { claims["nonce"] = "other" },
+			wantErr: ErrInvalidIDToken,
+		},
+		{
+			name:    "token for another client",
+			tamper:  func(claims jwt.MapClaims) { claims["aud"] = "other" },
+			wantErr: ErrInvalidIDToken,
+		},
+		{
+			name:    "other issuer",
+			tamper:  func(claims jwt.MapClaims) { claims["iss"] = "https://evil.example.org" },
+			wantErr: ErrInvalidIDToken,
+		},
+		{
+			name:    "expired",
+			tamper:  func(claims jwt.MapClaims) { claims["exp"] = time.Now().Add(-time.Hour).Unix() },
+			wantErr: ErrInvalidIDToken,
+		},
+		{
+			name:    "forged signature",
+			forge:   true,
+			wantErr: ErrInvalidIDToken,
+		},
+		{
+			name:     "wrong code verifier",
+			verifier: "other",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockProvider(t)
+			mock.tamper = tt.tamper
+			if tt.forge {
+				mock.signer = otherKey
+			}
+			provider := NewProvider(Config{
+				Issuer:      mock.server.URL + "/",
+				ClientID:    "kanban",
+				RedirectURL: "https://kanban.example.org/api/auth/oidc/callback",
+				Scopes:      []string{"openid", "email"},
+			}, mock.server.Client())
+
+			verifier, challenge, err := NewPKCE()
+			if err != nil {
+				t.Fatal(err)
+			}
+			authURL, err := provider.AuthCodeURL("state", "nonce", challenge)
+			if err != nil {
+				t.Fatalf("auth code url: %v", err)
+			}
+			if !strings.HasPrefix(authURL, mock.server.URL+"/authorize?") {
+				t.Fatalf("auth code url: got %s", authURL)
+			}
+			code := mock.authorize(t, authURL)
+
+			if tt.verifier != "" {
+				verifier = tt.verifier
+			}
+			claims, err := provider.Exchange(code, verifier, "nonce")
+			switch {
+			case tt.verifier != "":
+				if err == nil {
+					t.Fatal("exchange succeeded with a wrong code verifier")
+				}
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error: got %v, want %v", err, tt.wantErr)
+				}
+			case err != nil:
+				t.Fatalf("exchange: %v", err)
+			case claims.Subject != "alice-subject" || claims.Email != "alice@example.org" || !bool(claims.EmailVerified):
+				t.Errorf("claims: got %+v", claims)
+			}
+		})
+	}
+}
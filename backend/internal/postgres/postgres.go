@@ -17,7 +17,13 @@ var (
 	dbConnectRetryDelay = 1 * time.Second
 )
 
+// ownBoardsOnly is taken from the config when the database is opened, so
+// repositories used without a loaded config see the default full access.
+var ownBoardsOnly bool
+
 func NewPostgres() *sql.DB {
+	ownBoardsOnly = config.Get().UnverifiedAccess == config.UnverifiedAccessOwnBoards
+
 	db, err := sql.Open("postgres", config.Get().PostgresURI)
 	if err != nil {
 		log.Fatal("Failed to open DB:", err)
@@ -59,4 +65,10 @@ func runMigrations(db *sql.DB) {
 	if err != nil && err != migrate.ErrNoChange {
 		log.Fatalf("Migration failed: %v", err)
 	}
-}
\ No newline at end of file
+}
+// OwnBoardsOnly fills the last parameter of the board access queries:
+// with UNVERIFIED_ACCESS=own_boards users who have not verified their
+// email only reach the boards of their personal workspace.
+func OwnBoardsOnly() bool {
+	return ownBoardsOnly
+}
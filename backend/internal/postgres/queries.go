@@ -10,31 +10,692 @@ const (
 		VALUES ($1, $2, $3, $4, $5)`
 
 	QueryGetUserByEmail = `
-		SELECT id, email, username, hashed_password 
+		SELECT id, email, username, hashed_password, email_verified_at 
 		FROM "user" WHERE email=$1`
 
+	QueryGetUserByID = `
+		SELECT id, email, username, hashed_password, email_verified_at 
+		FROM "user" WHERE id=$1`
+
+	QueryGetSession = `
+		SELECT tokens_valid_after, email_verified_at IS NOT NULL 
+		FROM "user" WHERE id=$1`
+
+	QueryVerifyUserEmail = `
+		UPDATE "user"
+		SET email_verified_at = COALESCE(email_verified_at, $1)
+		WHERE id = $2
+		AND email = $3`
+
+	QueryUpdateUserPassword = `
+		UPDATE "user"
+		SET hashed_password = $1,
+			tokens_valid_after = $2
+		WHERE id = $3`
+
+	QueryCreatePasswordResetToken = `
+		INSERT INTO password_reset_token
+		(id, user_id, token_hash, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	QueryConsumePasswordResetToken = `
+		UPDATE password_reset_token
+		SET used_at = $1
+		WHERE token_hash = $2
+		AND used_at IS NULL
+		AND expires_at > $1
+		RETURNING user_id`
+
+	QueryDeletePasswordResetTokens = `
+		DELETE FROM password_reset_token
+		WHERE user_id = $1
+		AND used_at IS NULL`
+
+	// Signing key queries
+
+	QueryLockSigningKeys = `
+		LOCK TABLE signing_key IN EXCLUSIVE MODE`
+
+	QueryGetSigningKeys = `
+		SELECT kid, algorithm, secret_id, private_key, activates_at, retires_at, expires_at
+		FROM signing_key
+		WHERE expires_at > $1
+		ORDER BY activates_at`
+
+	QueryGetLatestSigningKeyRetirement = `
+		SELECT MAX(retires_at)
+		FROM signing_key
+		WHERE algorithm = $1`
+
+	QueryCreateSigningKey = `
+		INSERT INTO signing_key
+		(kid, algorithm, secret_id, private_key, created_at, activates_at, retires_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	QueryGetSigningKeysToReseal = `
+		SELECT kid, secret_id, private_key
+		FROM signing_key
+		WHERE secret_id IS DISTINCT FROM $1`
+
+	QueryResealSigningKey = `
+		UPDATE signing_key
+		SET secret_id = $1,
+			private_key = $2
+		WHERE kid = $3`
+
+	QueryDeleteExpiredSigningKeys = `
+		DELETE FROM signing_key
+		WHERE expires_at <= $1`
+
+	// Two-factor authentication queries
+
+	QueryGetUserMFA = `
+		SELECT secret, enabled_at, last_used_step
+		FROM user_mfa
+		WHERE user_id = $1`
+
+	QueryCreatePendingMFA = `
+		INSERT INTO user_mfa
+		(user_id, secret, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET
+		secret = EXCLUDED.secret,
+		created_at = EXCLUDED.created_at,
+		last_used_step = 0
+		WHERE user_mfa.enabled_at IS NULL`
+
+	QueryEnableMFA = `
+		UPDATE user_mfa
+		SET enabled_at = $1,
+			last_used_step = $2
+		WHERE user_id = $3
+		AND enabled_at IS NULL`
+
+	QueryUseMFAStep = `
+		UPDATE user_mfa
+		SET last_used_step = $1
+		WHERE user_id = $2
+		AND last_used_step < $1`
+
+	QueryDeleteMFA = `
+		DELETE FROM user_mfa
+		WHERE user_id = $1`
+
+	QueryCreateRecoveryCode = `
+		INSERT INTO mfa_recovery_code
+		(id, user_id, code_hash, created_at)
+		VALUES ($1, $2, $3, $4)`
+
+	QueryUseRecoveryCode = `
+		UPDATE mfa_recovery_code
+		SET used_at = $1
+		WHERE user_id = $2
+		AND code_hash = $3
+		AND used_at IS NULL`
+
+	QueryDeleteRecoveryCodes = `
+		DELETE FROM mfa_recovery_code
+		WHERE user_id = $1`
+
+	// External identity queries
+
+	QueryGetUserByIdentity = `
+		SELECT user_id
+		FROM user_identity
+		WHERE issuer = $1
+		AND subject = $2`
+
+	QueryCreateIdentity = `
+		INSERT INTO user_identity
+		(issuer, subject, user_id, created_at)
+		VALUES ($1, $2, $3, $4)`
+
+	QueryHasIdentity = `
+		SELECT EXISTS (
+			SELECT 1 FROM user_identity
+			WHERE user_id = $1
+		)`
+
+	// Personal access token queries
+
+	QueryGetAccessTokenByHash = `
+		SELECT id, user_id, scopes, board_id, expires_at
+		FROM personal_access_token
+		WHERE token_hash = $1`
+
+	QueryTouchAccessToken = `
+		UPDATE personal_access_token
+		SET last_used_at = $1
+		WHERE id = $2
+		AND (last_used_at IS NULL OR last_used_at < $1 - interval '1 minute')`
+
+	QueryGetBoardIDByColumnID = `
+		SELECT board_id
+		FROM "column"
+		WHERE id = $1`
+
+	QueryGetBoardIDByTaskID = `
+		SELECT "column".board_id
+		FROM task
+		JOIN "column" ON task.column_id = "column".id
+		WHERE task.id = $1`
+
+	QueryCreateAccessToken = `
+		INSERT INTO personal_access_token
+		(id, user_id, board_id, name, token_hash, scopes, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	QueryGetAccessTokens = `
+		SELECT id, name, scopes, board_id, created_at, expires_at, last_used_at
+		FROM personal_access_token
+		WHERE user_id = $1
+		ORDER BY created_at DESC`
+
+	QueryDeleteAccessToken = `
+		DELETE FROM personal_access_token
+		WHERE id = $1`
+
+	QueryGetAccessToken = `
+		SELECT id, name, scopes, board_id, created_at, expires_at, last_used_at
+		FROM personal_access_token
+		WHERE id = $1
+		AND user_id = $2`
+
+	QueryGetUserByAccessTokenID = `
+		SELECT user_id
+		FROM personal_access_token
+		WHERE id = $1`
+
+	// Account queries
+
+	QueryGetProfile = `
+		SELECT id, email, username, created_at, email_verified_at, timezone, locale
+		FROM "user" WHERE id = $1`
+
+	QueryUpdateProfile = `
+		UPDATE "user"
+		SET username = COALESCE($1, username),
+			email = COALESCE($2, email),
+			email_verified_at = CASE WHEN $2::text IS NULL OR $2 = email THEN email_verified_at ELSE NULL END,
+			timezone = COALESCE($3, timezone),
+			locale = COALESCE($4, locale)
+		WHERE id = $5`
+
+	QueryGetPasswordHash = `
+		SELECT hashed_password 
+		FROM "user" WHERE id = $1`
+
+	// Deleting a user cascades to their personal workspace, so boards shared
+	// from it and workspaces left without an owner block the deletion.
+	QueryGetDeletionConflicts = `
+		SELECT EXISTS (
+			SELECT 1 FROM workspace_member
+			JOIN workspace ON workspace.id = workspace_member.workspace_id
+			WHERE workspace_member.user_id = $1
+			AND workspace_member.role = 'owner'
+			AND workspace.personal_user_id IS NULL
+			AND NOT EXISTS (
+				SELECT 1 FROM workspace_member other
+				WHERE other.workspace_id = workspace_member.workspace_id
+				AND other.role = 'owner'
+				AND other.user_id <> $1
+			)
+		), EXISTS (
+			SELECT 1 FROM board_member
+			JOIN board ON board.id = board_member.board_id
+			JOIN workspace ON workspace.id = board.workspace_id
+			WHERE workspace.personal_user_id = $1
+			AND board_member.user_id <> $1
+		)`
+
+	QueryDeleteUser = `
+		WITH tombstone AS (
+			INSERT INTO change_tombstone
+			(entity, entity_id, board_id, workspace_id, member_ids)
+			SELECT 'board', board.id, board.id, board.workspace_id,
+				ARRAY(SELECT user_id FROM board_member WHERE board_id = board.id)
+			FROM board
+			JOIN workspace ON workspace.id = board.workspace_id
+			WHERE workspace.personal_user_id = $1
+		)
+		DELETE FROM "user" 
+		WHERE id = $1`
+
 	// Board Queries
 
 	QueryCreateBoard = `
 		INSERT INTO board 
-		(id, user_id, created_at, updated_at, name) 
-		VALUES ($1, $2, $3, $4, $5)`
+		(id, user_id, workspace_id, created_at, updated_at, name, enforce_dependencies) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, user_id, workspace_id, created_at, updated_at, name, enforce_dependencies`
 
 	QueryGetAllBoards = `
-		SELECT * FROM board 
-		WHERE user_id = $1 
-		ORDER BY created_at`
+		SELECT board.id, board.user_id, board.workspace_id, board.created_at, board.updated_at,
+			board.name, board.enforce_dependencies 
+		FROM board 
+		WHERE (board.workspace_id IN (SELECT workspace_id FROM workspace_member WHERE user_id = $1)
+		OR board.id IN (SELECT board_id FROM board_member WHERE user_id = $1))
+		AND (NOT $2 OR EXISTS (
+			SELECT 1 FROM workspace
+			WHERE workspace.id = board.workspace_id
+			AND workspace.personal_user_id = $1
+		) OR EXISTS (
+			SELECT 1 FROM "user"
+			WHERE "user".id = $1
+			AND "user".email_verified_at IS NOT NULL
+		))
+		ORDER BY board.created_at`
 
-	QueryGetBoard = `SELECT * FROM board WHERE id = $1`
+	QueryGetBoard = `
+		SELECT id, user_id, workspace_id, created_at, updated_at, name, enforce_dependencies 
+		FROM board 
+		WHERE id = $1`
 
 	QueryUpdateBoard = `UPDATE board 
-		SET updated_at = $1, name = $2
-		WHERE id = $3`
+		SET updated_at = $1, 
+			name = $2,
+			enforce_dependencies = COALESCE($3, enforce_dependencies),
+			change_xid = pg_current_xact_id()
+		WHERE id = $4
+		RETURNING id, user_id, workspace_id, created_at, updated_at, name, enforce_dependencies`
 
 	QueryDeleteBoard = `
+		WITH tombstone AS (
+			INSERT INTO change_tombstone
+			(entity, entity_id, board_id, workspace_id, member_ids)
+			SELECT 'board', board.id, board.id, board.workspace_id,
+				ARRAY(SELECT user_id FROM board_member WHERE board_id = board.id)
+			FROM board
+			WHERE board.id = $1
+		)
 		DELETE FROM board 
 		WHERE id = $1`
 
+	QueryMoveBoard = `
+		WITH tombstone AS (
+			INSERT INTO change_tombstone
+			(entity, entity_id, board_id, workspace_id)
+			SELECT 'board', id, id, workspace_id
+			FROM board
+			WHERE id = $3
+			AND workspace_id <> $2
+		), touched_columns AS (
+			UPDATE "column"
+			SET change_xid = pg_current_xact_id()
+			WHERE board_id = $3
+			RETURNING id
+		), touched_tasks AS (
+			UPDATE task
+			SET change_xid = pg_current_xact_id()
+			WHERE column_id IN (SELECT id FROM touched_columns)
+		)
+		UPDATE board
+		SET updated_at = $1,
+			workspace_id = $2,
+			change_xid = pg_current_xact_id()
+		WHERE id = $3
+		RETURNING id, user_id, workspace_id, created_at, updated_at, name, enforce_dependencies`
+
+	// Workspace queries
+
+	QueryCreateWorkspace = `
+		INSERT INTO workspace
+		(id, created_at, updated_at, name)
+		VALUES ($1, $2, $2, $3)`
+
+	QueryCreatePersonalWorkspace = `
+		WITH personal AS (
+			INSERT INTO workspace
+			(id, created_at, updated_at, name, personal_user_id)
+			SELECT $1, $2, $2, username, id
+			FROM "user" WHERE id = $3
+			ON CONFLICT (personal_user_id) DO NOTHING
+			RETURNING id
+		)
+		INSERT INTO workspace_member
+		(workspace_id, user_id, role, created_at)
+		SELECT id, $3, 'owner', $2 FROM personal`
+
+	QueryGetPersonalWorkspace = `
+		SELECT id
+		FROM workspace
+		WHERE personal_user_id = $1`
+
+	QueryGetWorkspaces = `
+		SELECT workspace.id, workspace.created_at, workspace.updated_at, workspace.name,
+			workspace.personal_user_id IS NOT NULL, workspace_member.role
+		FROM workspace
+		JOIN workspace_member ON workspace_member.workspace_id = workspace.id
+		WHERE workspace_member.user_id = $1
+		ORDER BY workspace.personal_user_id IS NULL, workspace.created_at`
+
+	QueryGetWorkspace = `
+		SELECT workspace.id, workspace.created_at, workspace.updated_at, workspace.name,
+			workspace.personal_user_id IS NOT NULL, COALESCE(workspace_member.role, '')
+		FROM workspace
+		LEFT JOIN workspace_member ON workspace_member.workspace_id = workspace.id
+		AND workspace_member.user_id = $2
+		WHERE workspace.id = $1`
+
+	QueryUpdateWorkspace = `
+		UPDATE workspace
+		SET updated_at = $1,
+			name = $2
+		WHERE id = $3
+		RETURNING id, created_at, updated_at, name, personal_user_id IS NOT NULL,
+			COALESCE((
+				SELECT role FROM workspace_member
+				WHERE workspace_member.workspace_id = workspace.id
+				AND workspace_member.user_id = $4
+			), '')`
+
+	QueryDeleteWorkspace = `
+		WITH tombstone AS (
+			INSERT INTO change_tombstone
+			(entity, entity_id, board_id, workspace_id, member_ids)
+			SELECT 'board', board.id, board.id, board.workspace_id,
+				ARRAY(SELECT user_id FROM board_member WHERE board_id = board.id)
+			FROM board
+			WHERE board.workspace_id = $1
+		)
+		DELETE FROM workspace
+		WHERE id = $1`
+
+	QueryGetWorkspaceBoards = `
+		SELECT id, user_id, workspace_id, created_at, updated_at, name, enforce_dependencies
+		FROM board
+		WHERE workspace_id = $1
+		ORDER BY created_at`
+
+	QueryGetWorkspaceMembers = `
+		SELECT "user".id, "user".email, "user".username, workspace_member.role, workspace_member.created_at
+		FROM workspace_member
+		JOIN "user" ON "user".id = workspace_member.user_id
+		WHERE workspace_member.workspace_id = $1
+		ORDER BY workspace_member.created_at`
+
+	QueryGetWorkspaceMemberCandidate = `
+		SELECT id, email_verified_at IS NOT NULL
+		FROM "user"
+		WHERE email = $1`
+
+	QueryAddWorkspaceMember = `
+		WITH touched_boards AS (
+			UPDATE board
+			SET change_xid = pg_current_xact_id()
+			WHERE workspace_id = $1
+			RETURNING id
+		), touched_columns AS (
+			UPDATE "column"
+			SET change_xid = pg_current_xact_id()
+			WHERE board_id IN (SELECT id FROM touched_boards)
+			RETURNING id
+		), touched_tasks AS (
+			UPDATE task
+			SET change_xid = pg_current_xact_id()
+			WHERE column_id IN (SELECT id FROM touched_columns)
+		), member AS (
+			INSERT INTO workspace_member
+			(workspace_id, user_id, role, created_at)
+			VALUES ($1, $2, $3, $4)
+			RETURNING user_id, role, created_at
+		)
+		SELECT "user".id, "user".email, "user".username, member.role, member.created_at
+		FROM member
+		JOIN "user" ON "user".id = member.user_id`
+
+	QueryUpdateWorkspaceMember = `
+		UPDATE workspace_member
+		SET role = $1
+		WHERE workspace_id = $2
+		AND user_id = $3`
+
+	QueryDeleteWorkspaceMember = `
+		WITH tombstone AS (
+			INSERT INTO change_tombstone
+			(entity, entity_id, board_id, member_ids)
+			SELECT 'board', id, id, ARRAY[$2::uuid]
+			FROM board
+			WHERE workspace_id = $1
+		)
+		DELETE FROM workspace_member
+		WHERE workspace_id = $1
+		AND user_id = $2`
+
+	QueryCountWorkspaceOwners = `
+		SELECT COUNT(*)
+		FROM workspace_member
+		WHERE workspace_id = $1
+		AND role = 'owner'`
+
+	QueryGetWorkspaceRole = `
+		SELECT COALESCE((
+			SELECT role FROM workspace_member
+			WHERE workspace_member.workspace_id = workspace.id
+			AND workspace_member.user_id = $2
+		), '')
+		FROM workspace
+		WHERE workspace.id = $1`
+
+	QueryIsPersonalWorkspace = `
+		SELECT personal_user_id IS NOT NULL
+		FROM workspace
+		WHERE id = $1`
+
+	QueryCanManageBoard = `
+		SELECT EXISTS (
+			SELECT 1 FROM workspace_member
+			WHERE workspace_member.workspace_id = board.workspace_id
+			AND workspace_member.user_id = $2
+			AND (workspace_member.role IN ('owner', 'admin') OR board.user_id = $2)
+		)
+		AND (NOT $3 OR EXISTS (
+			SELECT 1 FROM workspace
+			WHERE workspace.id = board.workspace_id
+			AND workspace.personal_user_id = $2
+		) OR EXISTS (
+			SELECT 1 FROM "user"
+			WHERE "user".id = $2
+			AND "user".email_verified_at IS NOT NULL
+		))
+		FROM board
+		WHERE board.id = $1`
+
+	QueryCanShareBoard = `
+		SELECT (EXISTS (
+			SELECT 1 FROM workspace_member
+			WHERE workspace_member.workspace_id = board.workspace_id
+			AND workspace_member.user_id = $2
+			AND (workspace_member.role IN ('owner', 'admin') OR board.user_id = $2)
+		) OR EXISTS (
+			SELECT 1 FROM board_member
+			WHERE board_member.board_id = board.id
+			AND board_member.user_id = $2
+			AND board_member.role = 'admin'
+		))
+		AND (NOT $3 OR EXISTS (
+			SELECT 1 FROM workspace
+			WHERE workspace.id = board.workspace_id
+			AND workspace.personal_user_id = $2
+		) OR EXISTS (
+			SELECT 1 FROM "user"
+			WHERE "user".id = $2
+			AND "user".email_verified_at IS NOT NULL
+		))
+		FROM board
+		WHERE board.id = $1`
+
+	// Board invitation queries
+
+	QueryGetInvitationBoardName = `
+		SELECT name
+		FROM board
+		WHERE id = $1`
+
+	QueryRevokePendingInvitations = `
+		UPDATE board_invitation
+		SET status = 'revoked',
+			responded_at = $1
+		WHERE board_id = $2
+		AND lower(email) = lower($3)
+		AND status = 'pending'`
+
+	QueryCreateInvitation = `
+		INSERT INTO board_invitation
+		(id, board_id, email, role, invited_by, status, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, 'pending', $6, $7)`
+
+	QueryGetBoardInvitations = `
+		SELECT id, board_id, email, role, invited_by,
+			CASE WHEN status = 'pending' AND expires_at <= now() THEN 'expired' ELSE status END,
+			created_at, expires_at, responded_at
+		FROM board_invitation
+		WHERE board_id = $1
+		ORDER BY created_at DESC`
+
+	QueryRevokeInvitation = `
+		UPDATE board_invitation
+		SET status = 'revoked',
+			responded_at = $1
+		WHERE id = $2
+		AND board_id = $3
+		AND status = 'pending'`
+
+	QueryRespondInvitation = `
+		UPDATE board_invitation
+		SET status = $1,
+			responded_at = $2
+		WHERE id = $3
+		AND status = 'pending'
+		AND expires_at > $2
+		RETURNING board_id, role`
+
+	QueryGetInviteeEmail = `
+		SELECT email
+		FROM "user"
+		WHERE id = $1`
+
+	QueryAddBoardMember = `
+		WITH touched_boards AS (
+			UPDATE board
+			SET change_xid = pg_current_xact_id()
+			WHERE id = $1
+		), touched_columns AS (
+			UPDATE "column"
+			SET change_xid = pg_current_xact_id()
+			WHERE board_id = $1
+			RETURNING id
+		), touched_tasks AS (
+			UPDATE task
+			SET change_xid = pg_current_xact_id()
+			WHERE column_id IN (SELECT id FROM touched_columns)
+		)
+		INSERT INTO board_member
+		(board_id, user_id, role, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (board_id, user_id) DO UPDATE
+		SET role = EXCLUDED.role`
+
+	QueryGetBoardMembers = `
+		SELECT "user".id, "user".email, "user".username, board_member.role, board_member.created_at
+		FROM board_member
+		JOIN "user" ON "user".id = board_member.user_id
+		WHERE board_member.board_id = $1
+		ORDER BY board_member.created_at`
+
+	QueryRemoveBoardMember = `
+		WITH tombstone AS (
+			INSERT INTO change_tombstone
+			(entity, entity_id, board_id, member_ids)
+			SELECT 'board', board.id, board.id, ARRAY[$2::uuid]
+			FROM board
+			WHERE board.id = $1
+			AND NOT EXISTS (
+				SELECT 1 FROM workspace_member
+				WHERE workspace_member.workspace_id = board.workspace_id
+				AND workspace_member.user_id = $2
+			)
+		)
+		DELETE FROM board_member
+		WHERE board_id = $1
+		AND user_id = $2`
+
+	QueryGetInviteeByEmail = `
+		SELECT id
+		FROM "user"
+		WHERE lower(email) = lower($1)`
+
+	QueryVerifyInviteeEmail = `
+		UPDATE "user"
+		SET email_verified_at = $1
+		WHERE id = $2
+		AND email_verified_at IS NULL`
+
+	// Board share queries
+
+	QueryCreateShare = `
+		INSERT INTO board_share
+		(id, board_id, token_hash, created_by, created_at, expires_at, redact_descriptions)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	QueryGetBoardShares = `
+		SELECT id, board_id, created_by, created_at, expires_at, redact_descriptions
+		FROM board_share
+		WHERE board_id = $1
+		ORDER BY created_at DESC`
+
+	QueryGetShare = `
+		SELECT id, board_id, created_by, created_at, expires_at, redact_descriptions
+		FROM board_share
+		WHERE id = $1
+		AND board_id = $2`
+
+	QueryDeleteShare = `
+		DELETE FROM board_share
+		WHERE id = $1
+		AND board_id = $2`
+
+	QueryGetSharedBoard = `
+		SELECT board.id, board.created_at, board.updated_at, board.name, board_share.redact_descriptions
+		FROM board_share
+		JOIN board ON board.id = board_share.board_id
+		WHERE board_share.token_hash = $1
+		AND (board_share.expires_at IS NULL OR board_share.expires_at > $2)`
+
+	// Board export queries
+
+	QueryGetExportBoard = `
+		SELECT id, created_at, updated_at, name, enforce_dependencies
+		FROM board
+		WHERE id = $1`
+
+	QueryGetExportTasks = `
+		SELECT task.id, task.column_id, task.created_at, task.updated_at, task.name, task.description,
+			task.position, task.done, task.deadline,
+			ARRAY(SELECT blocker_id::text FROM task_dependency WHERE blocked_id = task.id ORDER BY created_at),
+			task_recurrence.rrule, task_recurrence.dtstart, task_recurrence.column_id,
+			task_recurrence.next_run_at, task_recurrence.occurrences, task_recurrence.paused
+		FROM task
+		JOIN "column" ON "column".id = task.column_id
+		LEFT JOIN task_recurrence ON task_recurrence.task_id = task.id
+		WHERE "column".board_id = $1
+		ORDER BY "column".position, task.position`
+
+	// Board import queries
+
+	QueryImportTask = `
+		INSERT INTO task
+		(id, column_id, created_at, updated_at, name, description, position, done, deadline)
+		VALUES ($1, $2, $3, $3, $4, $5, $6, $7, $8)
+		RETURNING id, column_id, created_at, updated_at, name, description, position, done, deadline,
+			ARRAY(SELECT blocker_id::text FROM task_dependency WHERE blocked_id = task.id ORDER BY created_at),
+			ARRAY(SELECT blocked_id::text FROM task_dependency WHERE blocker_id = task.id ORDER BY created_at)`
+
+	QueryImportTaskRecurrence = `
+		INSERT INTO task_recurrence
+		(task_id, column_id, created_at, updated_at, rrule, dtstart, next_run_at, occurrences, paused)
+		VALUES ($1, $2, $3, $3, $4, $5, $6, $7, $8)`
+
 	// Column queries
 
 	QueryGetMaxColumnPosition = `
@@ -49,45 +710,74 @@ const (
 
 	QueryCreateColumn = `
 		INSERT INTO "column" 
-		(id, board_id, created_at, updated_at, name, position) 
-		VALUES ($1, $2, $3, $4, $5, $6)`
+		(id, board_id, created_at, updated_at, name, position, flag) 
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''))
+		RETURNING id, board_id, created_at, updated_at, name, position, flag`
 
 	QueryGetColumn = `
-		SELECT * 
+		SELECT id, board_id, created_at, updated_at, name, position, flag 
 		FROM "column"
 		WHERE "column".id = $1`
 
+	QueryGetColumnForUpdate = `
+		SELECT id, board_id, created_at, updated_at, name, position, flag 
+		FROM "column"
+		WHERE id = $1
+		FOR UPDATE`
+
 	QueryGetAllColumns = `
-		SELECT * 
+		SELECT id, board_id, created_at, updated_at, name, position, flag 
 		FROM "column"
 		WHERE board_id = $1
 		ORDER BY position`
 	
 	QueryDeleteColumn = `
+		WITH tombstone AS (
+			INSERT INTO change_tombstone
+			(entity, entity_id, board_id, workspace_id, member_ids)
+			SELECT 'column', "column".id, board.id, board.workspace_id,
+				ARRAY(SELECT user_id FROM board_member WHERE board_id = board.id)
+			FROM "column"
+			JOIN board ON board.id = "column".board_id
+			WHERE "column".id = $1
+		), touched_tasks AS (
+			UPDATE task
+			SET change_xid = pg_current_xact_id()
+			WHERE column_id <> $1
+			AND id IN (
+				SELECT task_dependency.blocker_id
+				FROM task_dependency
+				JOIN task AS deleted ON deleted.id = task_dependency.blocked_id
+				WHERE deleted.column_id = $1
+				UNION
+				SELECT task_dependency.blocked_id
+				FROM task_dependency
+				JOIN task AS deleted ON deleted.id = task_dependency.blocker_id
+				WHERE deleted.column_id = $1
+			)
+		)
 		DELETE FROM "column" 
 		WHERE id = $1`
 	
 	QueryDecreaseColumnsPosition = `
 		UPDATE "column" 
-		SET position = position - 1
+		SET position = position - 1,
+			change_xid = pg_current_xact_id()
 		WHERE board_id = $1
 		AND position > $2`
 
-	QueryGetBoardIDAndColumnPos =  `
-		SELECT board_id, position 
-		FROM "column" 
-		WHERE id = $1`
-	
 	QueryMoveColumnsRight = `
 		UPDATE "column"
-		SET position = position + 1
+		SET position = position + 1,
+			change_xid = pg_current_xact_id()
 		WHERE board_id = $1 
 		AND position >= $2 
 		AND position < $3`
 
 	QueryMoveColumnsLeft = `
 		UPDATE "column"
-		SET position = position - 1
+		SET position = position - 1,
+			change_xid = pg_current_xact_id()
 		WHERE board_id = $1 
 		AND position > $2 
 		AND position <= $3`
@@ -96,8 +786,11 @@ const (
 		UPDATE "column"
 		SET name = COALESCE($1, name),
 			position = COALESCE($2, position),
-			updated_at = $3
-		WHERE id = $4`
+			flag = CASE WHEN $3::text IS NULL THEN flag ELSE NULLIF($3, '') END,
+			updated_at = $4,
+			change_xid = pg_current_xact_id()
+		WHERE id = $5
+		RETURNING id, board_id, created_at, updated_at, name, position, flag`
 
 	// Task queries
 
@@ -114,43 +807,79 @@ const (
 	QueryCreateTask = `
 		INSERT INTO task
 		(id, column_id, created_at, updated_at, name, description, position, done)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, column_id, created_at, updated_at, name, description, position, done, deadline,
+			ARRAY(SELECT blocker_id::text FROM task_dependency WHERE blocked_id = task.id ORDER BY created_at),
+			ARRAY(SELECT blocked_id::text FROM task_dependency WHERE blocker_id = task.id ORDER BY created_at)`
 	
 	QueryGetAllTasks = `
-		SELECT * FROM task 
+		SELECT id, column_id, created_at, updated_at, name, description, position, done, deadline,
+			ARRAY(SELECT blocker_id::text FROM task_dependency WHERE blocked_id = task.id ORDER BY created_at),
+			ARRAY(SELECT blocked_id::text FROM task_dependency WHERE blocker_id = task.id ORDER BY created_at)
+		FROM task 
 		WHERE column_id = $1 
 		ORDER BY position`
 
+	QueryGetBoardTasks = `
+		SELECT task.id, task.column_id, task.created_at, task.updated_at, task.name, task.description,
+			task.position, task.done, task.deadline,
+			ARRAY(SELECT blocker_id::text FROM task_dependency WHERE blocked_id = task.id ORDER BY created_at),
+			ARRAY(SELECT blocked_id::text FROM task_dependency WHERE blocker_id = task.id ORDER BY created_at)
+		FROM task
+		JOIN "column" ON "column".id = task.column_id
+		WHERE "column".board_id = $1
+		ORDER BY "column".position, task.position`
+
 	QueryGetTask = `
-		SELECT * 
+		SELECT id, column_id, created_at, updated_at, name, description, position, done, deadline,
+			ARRAY(SELECT blocker_id::text FROM task_dependency WHERE blocked_id = task.id ORDER BY created_at),
+			ARRAY(SELECT blocked_id::text FROM task_dependency WHERE blocker_id = task.id ORDER BY created_at)
 		FROM task 
 		WHERE id = $1`
 
+	QueryGetTaskForUpdate = `
+		SELECT id, column_id, created_at, updated_at, name, description, position, done, deadline,
+			ARRAY(SELECT blocker_id::text FROM task_dependency WHERE blocked_id = task.id ORDER BY created_at),
+			ARRAY(SELECT blocked_id::text FROM task_dependency WHERE blocker_id = task.id ORDER BY created_at)
+		FROM task 
+		WHERE id = $1
+		FOR UPDATE`
+
 	QueryUpdateTaskContent = `
 		UPDATE task 
 		SET name = COALESCE($1, name),
 			description = COALESCE($2, description),
 			done = COALESCE($3, done),
 			deadline = COALESCE($4, deadline),
-			updated_at = $5
-		WHERE id = $6`
+			updated_at = $5,
+			change_xid = pg_current_xact_id()
+		WHERE id = $6
+		RETURNING id, column_id, created_at, updated_at, name, description, position, done, deadline,
+			ARRAY(SELECT blocker_id::text FROM task_dependency WHERE blocked_id = task.id ORDER BY created_at),
+			ARRAY(SELECT blocked_id::text FROM task_dependency WHERE blocker_id = task.id ORDER BY created_at)`
 	
 	QueryUpdateTaskColumn = `
 		UPDATE task 
 		SET column_id = $1,
 			position = $2,
-			updated_at = $3
-		WHERE id = $4`
+			updated_at = $3,
+			change_xid = pg_current_xact_id()
+		WHERE id = $4
+		RETURNING id, column_id, created_at, updated_at, name, description, position, done, deadline,
+			ARRAY(SELECT blocker_id::text FROM task_dependency WHERE blocked_id = task.id ORDER BY created_at),
+			ARRAY(SELECT blocked_id::text FROM task_dependency WHERE blocker_id = task.id ORDER BY created_at)`
 
 	QueryMoveTasksForInsert = `
 		UPDATE task
-		SET position = position + 1
+		SET position = position + 1,
+			change_xid = pg_current_xact_id()
 		WHERE column_id = $1
 		AND position >= $2`
 	
 	QueryMoveTaskForDelete = `
 		UPDATE task
-		SET position = position - 1
+		SET position = position - 1,
+			change_xid = pg_current_xact_id()
 		WHERE column_id = $1
 		AND position > $2`
 	
@@ -161,14 +890,16 @@ const (
 
 	QueryMoveTasksDown = `
 		UPDATE task
-		SET position = position + 1
+		SET position = position + 1,
+			change_xid = pg_current_xact_id()
 		WHERE column_id = $1 
 		AND position >= $2 
 		AND position < $3`
 
 	QueryMoveTasksUp = `
 		UPDATE task
-		SET position = position - 1
+		SET position = position - 1,
+			change_xid = pg_current_xact_id()
 		WHERE column_id = $1 
 		AND position > $2 
 		AND position <= $3`
@@ -176,32 +907,640 @@ const (
 	QueryUpdateTaskPosition = `
 		UPDATE task
 		SET position = $1, 
-			updated_at = $2
-		WHERE id = $3`
+			updated_at = $2,
+			change_xid = pg_current_xact_id()
+		WHERE id = $3
+		RETURNING id, column_id, created_at, updated_at, name, description, position, done, deadline,
+			ARRAY(SELECT blocker_id::text FROM task_dependency WHERE blocked_id = task.id ORDER BY created_at),
+			ARRAY(SELECT blocked_id::text FROM task_dependency WHERE blocker_id = task.id ORDER BY created_at)`
 
 	QueryDeleteTask = `
+		WITH tombstone AS (
+			INSERT INTO change_tombstone
+			(entity, entity_id, board_id, workspace_id, member_ids)
+			SELECT 'task', task.id, board.id, board.workspace_id,
+				ARRAY(SELECT user_id FROM board_member WHERE board_id = board.id)
+			FROM task
+			JOIN "column" ON "column".id = task.column_id
+			JOIN board ON board.id = "column".board_id
+			WHERE task.id = $1
+		), touched_tasks AS (
+			UPDATE task
+			SET change_xid = pg_current_xact_id()
+			WHERE id IN (
+				SELECT blocker_id FROM task_dependency WHERE blocked_id = $1
+				UNION
+				SELECT blocked_id FROM task_dependency WHERE blocker_id = $1
+			)
+		)
 		DELETE FROM task 
 		WHERE id = $1`
 
-	// Queries for checking ownership
+	QueryUpdateTaskDeadline = `
+		UPDATE task
+		SET deadline = $1,
+			updated_at = $2,
+			change_xid = pg_current_xact_id()
+		WHERE id = $3
+		RETURNING id, column_id, created_at, updated_at, name, description, position, done, deadline,
+			ARRAY(SELECT blocker_id::text FROM task_dependency WHERE blocked_id = task.id ORDER BY created_at),
+			ARRAY(SELECT blocked_id::text FROM task_dependency WHERE blocker_id = task.id ORDER BY created_at)`
+
+	QuerySavepointBulkTask = `SAVEPOINT bulk_task`
 
-	QueryGetUserByBoardID = `
-		SELECT user_id 
-		FROM board 
+	QueryRollbackBulkTask = `ROLLBACK TO SAVEPOINT bulk_task`
+
+	QueryReleaseBulkTask = `RELEASE SAVEPOINT bulk_task`
+
+	QueryGetMentionedUsers = `
+		SELECT "user".id, "user".email, "user".username, board.id, board.name,
+			(SELECT username FROM "user" author WHERE author.id = $3)
+		FROM task
+		JOIN "column" ON task.column_id = "column".id
+		JOIN board ON "column".board_id = board.id
+		JOIN "user" ON lower("user".username) = ANY($2)
+		WHERE task.id = $1
+		AND "user".id <> $3
+		AND (EXISTS (
+			SELECT 1 FROM workspace_member
+			WHERE workspace_member.workspace_id = board.workspace_id
+			AND workspace_member.user_id = "user".id
+		) OR EXISTS (
+			SELECT 1 FROM board_member
+			WHERE board_member.board_id = board.id
+			AND board_member.user_id = "user".id
+		))
+		AND (NOT $4 OR EXISTS (
+			SELECT 1 FROM workspace
+			WHERE workspace.id = board.workspace_id
+			AND workspace.personal_user_id = "user".id
+		) OR "user".email_verified_at IS NOT NULL)`
+
+	// Task dependency queries
+
+	QueryLockTaskDependencies = `LOCK TABLE task_dependency IN SHARE ROW EXCLUSIVE MODE`
+
+	QueryCheckDependencyCycle = `
+		WITH RECURSIVE chain(id) AS (
+			SELECT blocked_id FROM task_dependency WHERE blocker_id = $1
+			UNION
+			SELECT d.blocked_id FROM task_dependency d JOIN chain ON d.blocker_id = chain.id
+		)
+		SELECT EXISTS(SELECT 1 FROM chain WHERE id = $2)`
+
+	QueryCreateTaskDependency = `
+		WITH touched_tasks AS (
+			UPDATE task
+			SET change_xid = pg_current_xact_id()
+			WHERE id IN ($1, $2)
+		)
+		INSERT INTO task_dependency
+		(blocker_id, blocked_id, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING`
+
+	QueryDeleteTaskDependency = `
+		WITH touched_tasks AS (
+			UPDATE task
+			SET change_xid = pg_current_xact_id()
+			WHERE id IN ($1, $2)
+		)
+		DELETE FROM task_dependency
+		WHERE blocker_id = $1
+		AND blocked_id = $2`
+
+	QueryGetColumnEnforcement = `
+		SELECT board.enforce_dependencies, "column".flag IS NOT NULL
+		FROM "column"
+		JOIN board ON "column".board_id = board.id
+		WHERE "column".id = $1`
+
+	QueryGetOpenBlockersCount = `
+		SELECT COUNT(*)
+		FROM task_dependency
+		JOIN task ON task_dependency.blocker_id = task.id
+		WHERE task_dependency.blocked_id = $1
+		AND NOT task.done`
+
+	// Task recurrence queries
+
+	QueryUpsertTaskRecurrence = `
+		INSERT INTO task_recurrence
+		(task_id, column_id, created_at, updated_at, rrule, dtstart, next_run_at, occurrences)
+		VALUES ($1, $2, $3, $3, $4, $5, $6, $7)
+		ON CONFLICT (task_id) DO UPDATE
+		SET column_id = EXCLUDED.column_id,
+			updated_at = EXCLUDED.updated_at,
+			rrule = EXCLUDED.rrule,
+			dtstart = EXCLUDED.dtstart,
+			next_run_at = EXCLUDED.next_run_at,
+			occurrences = EXCLUDED.occurrences`
+
+	QueryGetTaskRecurrence = `
+		SELECT task_id, column_id, created_at, updated_at, rrule, dtstart, next_run_at, occurrences, paused
+		FROM task_recurrence
+		WHERE task_id = $1`
+
+	QueryGetTaskRecurrenceForUpdate = `
+		SELECT task_id, column_id, created_at, updated_at, rrule, dtstart, next_run_at, occurrences, paused
+		FROM task_recurrence
+		WHERE task_id = $1
+		FOR UPDATE SKIP LOCKED`
+
+	QueryGetDueTaskRecurrences = `
+		SELECT task_recurrence.task_id
+		FROM task_recurrence
+		JOIN task ON task_recurrence.task_id = task.id
+		WHERE NOT task_recurrence.paused
+		AND (task.done OR task_recurrence.next_run_at <= $1)
+		ORDER BY task_recurrence.next_run_at
+		LIMIT $2`
+
+	QueryUpdateTaskRecurrenceSchedule = `
+		UPDATE task_recurrence
+		SET paused = $1,
+			next_run_at = $2,
+			occurrences = $3,
+			updated_at = $4
+		WHERE task_id = $5`
+
+	QueryMoveTaskRecurrence = `
+		UPDATE task_recurrence
+		SET task_id = $1,
+			next_run_at = $2,
+			occurrences = occurrences + 1,
+			updated_at = $3
+		WHERE task_id = $4`
+
+	QueryDeleteTaskRecurrence = `
+		DELETE FROM task_recurrence
+		WHERE task_id = $1`
+
+	QueryGetTaskNameAndDescription = `
+		SELECT name, description
+		FROM task
 		WHERE id = $1`
 
-	QueryGetUserByColumnID = `
-		SELECT board.user_id
+	QueryCreateTaskWithDeadline = `
+		INSERT INTO task
+		(id, column_id, created_at, updated_at, name, description, position, done, deadline)
+		VALUES ($1, $2, $3, $3, $4, $5, $6, false, $7)`
+
+	// Notification queries
+
+	QueryCreateNotification = `
+		INSERT INTO notification
+		(id, user_id, created_at, kind, title, body, board_id, task_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	QueryGetNotifications = `
+		SELECT id, user_id, created_at, kind, title, body, board_id, task_id, read_at
+		FROM notification
+		WHERE user_id = $1
+		AND (NOT $2 OR read_at IS NULL)
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4`
+
+	QueryGetNotificationsCount = `
+		SELECT COUNT(*)
+		FROM notification
+		WHERE user_id = $1
+		AND (NOT $2 OR read_at IS NULL)`
+
+	QueryMarkNotificationRead = `
+		UPDATE notification
+		SET read_at = COALESCE(read_at, $1)
+		WHERE id = $2`
+
+	QueryMarkAllNotificationsRead = `
+		UPDATE notification
+		SET read_at = $1
+		WHERE user_id = $2
+		AND read_at IS NULL`
+
+	QueryGetUserByNotificationID = `
+		SELECT user_id
+		FROM notification
+		WHERE id = $1`
+
+	QueryGetNotificationPreferences = `
+		SELECT kind, channels
+		FROM notification_preference
+		WHERE user_id = $1`
+
+	QueryGetNotificationChannels = `
+		SELECT channels
+		FROM notification_preference
+		WHERE user_id = $1
+		AND kind = $2`
+
+	QueryUpsertNotificationPreference = `
+		INSERT INTO notification_preference
+		(user_id, kind, updated_at, channels)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, kind) DO UPDATE
+		SET updated_at = EXCLUDED.updated_at,
+			channels = EXCLUDED.channels`
+
+	// Deadline reminder queries
+
+	QueryGetReminderSettings = `
+		SELECT remind_before_minutes, notify_overdue
+		FROM deadline_reminder_setting
+		WHERE user_id = $1`
+
+	QueryUpsertReminderSettings = `
+		INSERT INTO deadline_reminder_setting
+		(user_id, updated_at, remind_before_minutes, notify_overdue)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE
+		SET updated_at = EXCLUDED.updated_at,
+			remind_before_minutes = EXCLUDED.remind_before_minutes,
+			notify_overdue = EXCLUDED.notify_overdue`
+
+	QueryGetDueReminders = `
+		SELECT task.id, task.name, task.deadline, board.id, board.name,
+			"user".id, "user".email, "user".username, reminder.before_minutes
+		FROM task
+		JOIN "column" ON task.column_id = "column".id
+		JOIN board ON "column".board_id = board.id
+		JOIN "user" ON board.user_id = "user".id
+		LEFT JOIN deadline_reminder_setting setting ON setting.user_id = "user".id
+		CROSS JOIN LATERAL unnest(
+			COALESCE(setting.remind_before_minutes, '{1440}') ||
+			CASE WHEN COALESCE(setting.notify_overdue, true) THEN ARRAY[0] ELSE '{}'::integer[] END
+		) AS reminder(before_minutes)
+		WHERE NOT task.done
+		AND task.deadline IS NOT NULL
+		AND task.deadline > $2
+		AND task.deadline - make_interval(mins => reminder.before_minutes) <= $1
+		AND (reminder.before_minutes = 0 OR task.deadline > $1)
+		AND NOT EXISTS (
+			SELECT 1 FROM deadline_reminder_sent sent
+			WHERE sent.task_id = task.id
+			AND sent.user_id = "user".id
+			AND sent.before_minutes = reminder.before_minutes
+			AND sent.deadline = task.deadline
+		)
+		ORDER BY task.deadline, task.id, reminder.before_minutes
+		LIMIT $3`
+
+	QueryMarkReminderSent = `
+		INSERT INTO deadline_reminder_sent
+		(task_id, user_id, before_minutes, deadline, sent_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT DO NOTHING`
+
+	QueryGetOverdueTasks = `
+		SELECT task.id, task.column_id, "column".name, task.name, task.description, task.deadline
+		FROM task
+		JOIN "column" ON task.column_id = "column".id
+		WHERE "column".board_id = $1
+		AND NOT task.done
+		AND task.deadline IS NOT NULL
+		AND task.deadline <= $2
+		ORDER BY task.deadline`
+
+	// Rate limit queries
+
+	QueryGetRateLimit = `
+		SELECT attempts, window_start, failures, lockouts, locked_until, updated_at
+		FROM rate_limit
+		WHERE key = $1`
+
+	QueryPutRateLimit = `
+		INSERT INTO rate_limit
+		(key, attempts, window_start, failures, lockouts, locked_until, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (key) DO UPDATE SET
+		attempts = EXCLUDED.attempts,
+		window_start = EXCLUDED.window_start,
+		failures = EXCLUDED.failures,
+		lockouts = EXCLUDED.lockouts,
+		locked_until = EXCLUDED.locked_until,
+		updated_at = EXCLUDED.updated_at`
+
+	QueryDeleteIdleRateLimits = `
+		DELETE FROM rate_limit
+		WHERE starts_with(key, $1)
+		AND updated_at < $2`
+
+	// Idempotency key queries
+
+	QueryClaimIdempotencyKey = `
+		INSERT INTO idempotency_key
+		(user_id, key, fingerprint, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, key) DO UPDATE SET
+		fingerprint = EXCLUDED.fingerprint,
+		status = NULL,
+		content_type = NULL,
+		location = NULL,
+		resource_id = NULL,
+		body = NULL,
+		created_at = EXCLUDED.created_at,
+		expires_at = EXCLUDED.expires_at
+		WHERE idempotency_key.expires_at <= EXCLUDED.created_at`
+
+	QueryGetIdempotencyKey = `
+		SELECT fingerprint, status, content_type, location, resource_id, body
+		FROM idempotency_key
+		WHERE user_id = $1
+		AND key = $2`
+
+	QuerySaveIdempotentResponse = `
+		UPDATE idempotency_key
+		SET status = $1,
+			content_type = $2,
+			location = NULLIF($3, ''),
+			resource_id = NULLIF($4, ''),
+			body = $5
+		WHERE user_id = $6
+		AND key = $7`
+
+	QueryReleaseIdempotencyKey = `
+		DELETE FROM idempotency_key
+		WHERE user_id = $1
+		AND key = $2
+		AND status IS NULL`
+
+	QueryDeleteExpiredIdempotencyKeys = `
+		DELETE FROM idempotency_key
+		WHERE expires_at <= $1`
+
+	// Sync queries
+
+	QueryGetSyncCursor = `
+		SELECT pg_snapshot_xmin(pg_current_snapshot())::text::bigint`
+
+	QueryGetSyncBoards = `
+		SELECT board.id, board.user_id, board.workspace_id, board.created_at, board.updated_at,
+			board.name, board.enforce_dependencies
+		FROM board
+		WHERE board.change_xid >= $2::text::xid8
+		AND (board.workspace_id IN (SELECT workspace_id FROM workspace_member WHERE user_id = $1)
+		OR board.id IN (SELECT board_id FROM board_member WHERE user_id = $1))
+		AND (NOT $3 OR EXISTS (
+			SELECT 1 FROM workspace
+			WHERE workspace.id = board.workspace_id
+			AND workspace.personal_user_id = $1
+		) OR EXISTS (
+			SELECT 1 FROM "user"
+			WHERE "user".id = $1
+			AND "user".email_verified_at IS NOT NULL
+		))
+		ORDER BY board.created_at`
+
+	QueryGetSyncColumns = `
+		SELECT "column".id, "column".board_id, "column".created_at, "column".updated_at,
+			"column".name, "column".position, "column".flag
+		FROM "column"
+		JOIN board ON board.id = "column".board_id
+		WHERE "column".change_xid >= $2::text::xid8
+		AND (board.workspace_id IN (SELECT workspace_id FROM workspace_member WHERE user_id = $1)
+		OR board.id IN (SELECT board_id FROM board_member WHERE user_id = $1))
+		AND (NOT $3 OR EXISTS (
+			SELECT 1 FROM workspace
+			WHERE workspace.id = board.workspace_id
+			AND workspace.personal_user_id = $1
+		) OR EXISTS (
+			SELECT 1 FROM "user"
+			WHERE "user".id = $1
+			AND "user".email_verified_at IS NOT NULL
+		))
+		ORDER BY "column".board_id, "column".position`
+
+	QueryGetSyncTasks = `
+		SELECT task.id, task.column_id, task.created_at, task.updated_at, task.name, task.description,
+			task.position, task.done, task.deadline,
+			ARRAY(SELECT blocker_id::text FROM task_dependency WHERE blocked_id = task.id ORDER BY created_at),
+			ARRAY(SELECT blocked_id::text FROM task_dependency WHERE blocker_id = task.id ORDER BY created_at)
+		FROM task
+		JOIN "column" ON "column".id = task.column_id
+		JOIN board ON board.id = "column".board_id
+		WHERE task.change_xid >= $2::text::xid8
+		AND (board.workspace_id IN (SELECT workspace_id FROM workspace_member WHERE user_id = $1)
+		OR board.id IN (SELECT board_id FROM board_member WHERE user_id = $1))
+		AND (NOT $3 OR EXISTS (
+			SELECT 1 FROM workspace
+			WHERE workspace.id = board.workspace_id
+			AND workspace.personal_user_id = $1
+		) OR EXISTS (
+			SELECT 1 FROM "user"
+			WHERE "user".id = $1
+			AND "user".email_verified_at IS NOT NULL
+		))
+		ORDER BY task.column_id, task.position`
+
+	QueryGetSyncTombstones = `
+		WITH visible_board AS (
+			SELECT id
+			FROM board
+			WHERE workspace_id IN (SELECT workspace_id FROM workspace_member WHERE user_id = $1)
+			OR id IN (SELECT board_id FROM board_member WHERE user_id = $1)
+		)
+		SELECT DISTINCT change_tombstone.entity, change_tombstone.entity_id, change_tombstone.board_id
+		FROM change_tombstone
+		WHERE change_tombstone.change_xid >= $2::text::xid8
+		AND (change_tombstone.workspace_id IN (SELECT workspace_id FROM workspace_member WHERE user_id = $1)
+		OR $1 = ANY(change_tombstone.member_ids))
+		AND (NOT $3 OR EXISTS (
+			SELECT 1 FROM workspace
+			WHERE workspace.id = change_tombstone.workspace_id
+			AND workspace.personal_user_id = $1
+		) OR EXISTS (
+			SELECT 1 FROM "user"
+			WHERE "user".id = $1
+			AND "user".email_verified_at IS NOT NULL
+		))
+		AND NOT CASE change_tombstone.entity
+			WHEN 'board' THEN change_tombstone.entity_id IN (SELECT id FROM visible_board)
+			WHEN 'column' THEN EXISTS (
+				SELECT 1 FROM "column"
+				WHERE "column".id = change_tombstone.entity_id
+				AND "column".board_id IN (SELECT id FROM visible_board)
+			)
+			ELSE EXISTS (
+				SELECT 1 FROM task
+				JOIN "column" ON "column".id = task.column_id
+				WHERE task.id = change_tombstone.entity_id
+				AND "column".board_id IN (SELECT id FROM visible_board)
+			)
+		END`
+
+	// Activity queries
+
+	QueryCreateActivity = `
+		INSERT INTO activity
+		(id, user_id, board_id, entity, entity_id, action, before, after, reverts_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	QueryGetBoardActivity = `
+		SELECT id, user_id, board_id, entity, entity_id, action, before, after, reverts_id, created_at, reverted_at
+		FROM activity
+		WHERE board_id = $1
+		ORDER BY created_at DESC, seq DESC
+		LIMIT $2 OFFSET $3`
+
+	QueryGetBoardActivityCount = `
+		SELECT COUNT(*)
+		FROM activity
+		WHERE board_id = $1`
+
+	QueryGetActivity = `
+		SELECT id, user_id, board_id, entity, entity_id, action, before, after, reverts_id, created_at, reverted_at
+		FROM activity
+		WHERE id = $1`
+
+	QueryGetActivityForUpdate = `
+		SELECT id, user_id, board_id, entity, entity_id, action, before, after, reverts_id, created_at, reverted_at
+		FROM activity
+		WHERE id = $1
+		FOR UPDATE`
+
+	QueryGetUndoableActivity = `
+		SELECT activity.id, activity.user_id, activity.board_id, activity.entity, activity.entity_id,
+			activity.action, activity.before, activity.after, activity.reverts_id, activity.created_at,
+			activity.reverted_at
+		FROM activity
+		JOIN board ON board.id = activity.board_id
+		WHERE activity.user_id = $1
+		AND activity.reverted_at IS NULL
+		AND activity.reverts_id IS NULL
+		AND (board.workspace_id IN (SELECT workspace_id FROM workspace_member WHERE user_id = $1)
+		OR board.id IN (SELECT board_id FROM board_member WHERE user_id = $1))
+		AND (NOT $3 OR EXISTS (
+			SELECT 1 FROM workspace
+			WHERE workspace.id = board.workspace_id
+			AND workspace.personal_user_id = $1
+		) OR EXISTS (
+			SELECT 1 FROM "user"
+			WHERE "user".id = $1
+			AND "user".email_verified_at IS NOT NULL
+		))
+		ORDER BY activity.created_at DESC, activity.seq DESC
+		LIMIT $2
+		FOR UPDATE OF activity`
+
+	QueryMarkActivityReverted = `
+		UPDATE activity
+		SET reverted_at = $1
+		WHERE id = $2`
+
+	QueryGetActivityBoardID = `
+		SELECT board_id
+		FROM activity
+		WHERE id = $1`
+
+	QueryRestoreTask = `
+		INSERT INTO task
+		(id, column_id, created_at, updated_at, name, description, position, done, deadline)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	QueryRestoreTaskContent = `
+		UPDATE task
+		SET name = $1,
+			description = $2,
+			done = $3,
+			deadline = $4,
+			updated_at = $5,
+			change_xid = pg_current_xact_id()
+		WHERE id = $6`
+
+	// Queries for checking access
+
+	QueryHasBoardAccess = `
+		SELECT (EXISTS (
+			SELECT 1 FROM workspace_member
+			WHERE workspace_member.workspace_id = board.workspace_id
+			AND workspace_member.user_id = $2
+		) OR EXISTS (
+			SELECT 1 FROM board_member
+			WHERE board_member.board_id = board.id
+			AND board_member.user_id = $2
+		))
+		AND (NOT $3 OR EXISTS (
+			SELECT 1 FROM workspace
+			WHERE workspace.id = board.workspace_id
+			AND workspace.personal_user_id = $2
+		) OR EXISTS (
+			SELECT 1 FROM "user"
+			WHERE "user".id = $2
+			AND "user".email_verified_at IS NOT NULL
+		))
+		FROM board
+		WHERE board.id = $1`
+
+	QueryHasColumnAccess = `
+		SELECT (EXISTS (
+			SELECT 1 FROM workspace_member
+			WHERE workspace_member.workspace_id = board.workspace_id
+			AND workspace_member.user_id = $2
+		) OR EXISTS (
+			SELECT 1 FROM board_member
+			WHERE board_member.board_id = board.id
+			AND board_member.user_id = $2
+		))
+		AND (NOT $3 OR EXISTS (
+			SELECT 1 FROM workspace
+			WHERE workspace.id = board.workspace_id
+			AND workspace.personal_user_id = $2
+		) OR EXISTS (
+			SELECT 1 FROM "user"
+			WHERE "user".id = $2
+			AND "user".email_verified_at IS NOT NULL
+		))
 		FROM board
 		JOIN "column"
 		ON "column".board_id = board.id
 		WHERE "column".id = $1`
-	
-	QueryGetUserByTaskID = `
-		SELECT board.user_id
+
+	QueryGetAccessibleTasks = `
+		SELECT task.id
 		FROM task
 		JOIN "column" ON task.column_id = "column".id
 		JOIN board ON "column".board_id = board.id
-		WHERE task.id = $1`
+		WHERE task.id = ANY($1)
+		AND (EXISTS (
+			SELECT 1 FROM workspace_member
+			WHERE workspace_member.workspace_id = board.workspace_id
+			AND workspace_member.user_id = $2
+		) OR EXISTS (
+			SELECT 1 FROM board_member
+			WHERE board_member.board_id = board.id
+			AND board_member.user_id = $2
+		))
+		AND (NOT $3 OR EXISTS (
+			SELECT 1 FROM workspace
+			WHERE workspace.id = board.workspace_id
+			AND workspace.personal_user_id = $2
+		) OR EXISTS (
+			SELECT 1 FROM "user"
+			WHERE "user".id = $2
+			AND "user".email_verified_at IS NOT NULL
+		))`
 
+	QueryHasTaskAccess = `
+		SELECT (EXISTS (
+			SELECT 1 FROM workspace_member
+			WHERE workspace_member.workspace_id = board.workspace_id
+			AND workspace_member.user_id = $2
+		) OR EXISTS (
+			SELECT 1 FROM board_member
+			WHERE board_member.board_id = board.id
+			AND board_member.user_id = $2
+		))
+		AND (NOT $3 OR EXISTS (
+			SELECT 1 FROM workspace
+			WHERE workspace.id = board.workspace_id
+			AND workspace.personal_user_id = $2
+		) OR EXISTS (
+			SELECT 1 FROM "user"
+			WHERE "user".id = $2
+			AND "user".email_verified_at IS NOT NULL
+		))
+		FROM task
+		JOIN "column" ON task.column_id = "column".id
+		JOIN board ON "column".board_id = board.id
+		WHERE task.id = $1`
 )
\ No newline at end of file
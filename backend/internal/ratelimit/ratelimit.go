@@ -0,0 +1,181 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// Policy describes how many attempts a single key may make and how it is
+// locked out after repeated failures.
+type Policy struct {
+	// MaxAttempts is the number of attempts allowed per Window.
+	MaxAttempts int
+	Window      time.Duration
+	// MaxFailures consecutive failures lock the key for BaseLockout, doubling
+	// with every further lockout up to MaxLockout.
+	MaxFailures int
+	BaseLockout time.Duration
+	MaxLockout  time.Duration
+	// Entries untouched for IdleTTL are forgotten, including their lockout history.
+	IdleTTL time.Duration
+}
+
+type Entry struct {
+	Attempts    int
+	WindowStart time.Time
+	Failures    int
+	Lockouts    int
+	LockedUntil *time.Time
+	UpdatedAt   time.Time
+}
+
+// Store keeps limiter entries. Get returns nil for unknown keys.
+type Store interface {
+	Get(key string) (*Entry, error)
+	Put(key string, entry Entry) error
+	DeleteIdle(prefix string, before time.Time) (int, error)
+}
+
+// LimitedError is returned when a key has to wait before its next attempt.
+type LimitedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LimitedError) Error() string {
+	return fmt.Sprintf("too many attempts, retry after %s", e.RetryAfter)
+}
+
+// RetryAfterSeconds rounds the wait up to whole seconds for the Retry-After header.
+func (e *LimitedError) RetryAfterSeconds() int {
+	return int(math.Ceil(e.RetryAfter.Seconds()))
+}
+
+// Limiter applies a Policy to keys in a Store. Updates are serialised within
+// the process only, so instances sharing a Postgres store may let a few extra
+// attempts through under concurrent load.
+type Limiter struct {
+	mu     sync.Mutex
+	store  Store
+	prefix string
+	policy Policy
+	now    func() time.Time
+}
+
+func New(store Store, name string, policy Policy) *Limiter {
+	return &Limiter{
+		store:  store,
+		prefix: name + ":",
+		policy: policy,
+		now:    func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Allow records an attempt for key, or returns a *LimitedError without
+// recording it when the key is locked out or has used up its window.
+func (l *Limiter) Allow(key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	entry, err := l.get(key, now)
+	if err != nil {
+		return fmt.Errorf("ratelimit.Allow: %w", err)
+	}
+
+	if entry.LockedUntil != nil && entry.LockedUntil.After(now) {
+		return &LimitedError{RetryAfter: entry.LockedUntil.Sub(now)}
+	}
+	if now.Sub(entry.WindowStart) >= l.policy.Window {
+		entry.Attempts = 0
+		entry.WindowStart = now
+	}
+	if entry.Attempts >= l.policy.MaxAttempts {
+		return &LimitedError{RetryAfter: entry.WindowStart.Add(l.policy.Window).Sub(now)}
+	}
+
+	entry.Attempts++
+	entry.UpdatedAt = now
+	if err = l.store.Put(l.prefix+key, *entry); err != nil {
+		return fmt.Errorf("ratelimit.Allow: %w", err)
+	}
+	return nil
+}
+
+// Fail records a failed attempt and locks the key out once it reaches
+// MaxFailures consecutive failures.
+func (l *Limiter) Fail(key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	entry, err := l.get(key, now)
+	if err != nil {
+		return fmt.Errorf("ratelimit.Fail: %w", err)
+	}
+
+	entry.Failures++
+	if entry.Failures >= l.policy.MaxFailures {
+		entry.Failures = 0
+		entry.Lockouts++
+		lockedUntil := now.Add(l.lockout(entry.Lockouts))
+		entry.LockedUntil = &lockedUntil
+	}
+
+	entry.UpdatedAt = now
+	if err = l.store.Put(l.prefix+key, *entry); err != nil {
+		return fmt.Errorf("ratelimit.Fail: %w", err)
+	}
+	return nil
+}
+
+// Reset clears the failures and lockout history of key after a success.
+// The attempts of the current window still count.
+func (l *Limiter) Reset(key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	entry, err := l.get(key, now)
+	if err != nil {
+		return fmt.Errorf("ratelimit.Reset: %w", err)
+	}
+
+	entry.Failures = 0
+	entry.Lockouts = 0
+	entry.LockedUntil = nil
+	entry.UpdatedAt = now
+	if err = l.store.Put(l.prefix+key, *entry); err != nil {
+		return fmt.Errorf("ratelimit.Reset: %w", err)
+	}
+	return nil
+}
+
+// Cleanup forgets entries idle for longer than the policy's IdleTTL.
+func (l *Limiter) Cleanup(now time.Time) (int, error) {
+	deleted, err := l.store.DeleteIdle(l.prefix, now.Add(-l.policy.IdleTTL))
+	if err != nil {
+		return 0, fmt.Errorf("ratelimit.Cleanup: %w", err)
+	}
+	return deleted, nil
+}
+
+func (l *Limiter) get(key string, now time.Time) (*Entry, error) {
+	entry, err := l.store.Get(l.prefix + key)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil || now.Sub(entry.UpdatedAt) >= l.policy.IdleTTL {
+		return &Entry{WindowStart: now}, nil
+	}
+	return entry, nil
+}
+
+func (l *Limiter) lockout(lockouts int) time.Duration {
+	lockout := l.policy.BaseLockout
+	for i := 1; i < lockouts && lockout < l.policy.MaxLockout; i++ {
+		lockout *= 2
+	}
+	return min(lockout, l.policy.MaxLockout)
+}
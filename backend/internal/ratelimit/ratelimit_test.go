@@ -0,0 +1,181 @@
+package ratelimit
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var testPolicy = Policy{
+	MaxAttempts: 3,
+	Window:      time.Minute,
+	MaxFailures: 2,
+	BaseLockout: time.Minute,
+	MaxLockout:  4 * time.Minute,
+	IdleTTL:     time.Hour,
+}
+
+// step advances the clock by wait and applies op. For allow, retryAfter is
+// the expected wait, zero when the attempt goes through.
+type step struct {
+	wait       time.Duration
+	op         string
+	retryAfter time.Duration
+}
+
+func allow(wait, retryAfter time.Duration) step {
+	return step{wait: wait, op: "allow", retryAfter: retryAfter}
+}
+
+func fail(wait time.Duration) step  { return step{wait: wait, op: "fail"} }
+func reset(wait time.Duration) step { return step{wait: wait, op: "reset"} }
+
+func TestLimiter(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "attempts within the window",
+			steps: []step{
+				allow(0, 0), allow(0, 0), allow(0, 0),
+				allow(0, time.Minute),
+				allow(40*time.Second, 20*time.Second),
+			},
+		},
+		{
+			name: "window reset",
+			steps: []step{
+				allow(0, 0), allow(0, 0), allow(0, 0),
+				allow(time.Minute, 0), allow(0, 0), allow(0, 0),
+				allow(0, time.Minute),
+			},
+		},
+		{
+			name: "refused attempts do not count",
+			steps: []step{
+				allow(0, 0), allow(0, 0), allow(0, 0),
+				allow(30*time.Second, 30*time.Second), allow(0, 30*time.Second),
+				allow(30*time.Second, 0),
+			},
+		},
+		{
+			name: "lockout after MaxFailures",
+			steps: []step{
+				fail(0), allow(0, 0),
+				fail(0), allow(0, time.Minute),
+				allow(30*time.Second, 30*time.Second),
+				allow(30*time.Second, 0),
+			},
+		},
+		{
+			name: "lockout doubles up to MaxLockout",
+			steps: []step{
+				fail(0), fail(0), allow(0, time.Minute),
+				fail(time.Minute), fail(0), allow(0, 2*time.Minute),
+				fail(2 * time.Minute), fail(0), allow(0, 4*time.Minute),
+				fail(4 * time.Minute), fail(0), allow(0, 4*time.Minute),
+			},
+		},
+		{
+			name: "reset clears failures and lockouts",
+			steps: []step{
+				fail(0), fail(0), fail(time.Minute), fail(0), allow(0, 2*time.Minute),
+				reset(0), allow(0, 0),
+				fail(0), allow(0, 0),
+				fail(0), allow(0, time.Minute),
+			},
+		},
+		{
+			name: "reset keeps the attempts of the window",
+			steps: []step{
+				allow(0, 0), allow(0, 0), allow(0, 0),
+				reset(0), allow(0, time.Minute),
+			},
+		},
+		{
+			name: "idle entry is forgotten",
+			steps: []step{
+				fail(0), fail(0), fail(time.Minute), fail(0), allow(0, 2*time.Minute),
+				fail(time.Hour), fail(0), allow(0, time.Minute),
+			},
+		},
+		{
+			name: "activity keeps the entry",
+			steps: []step{
+				fail(0), fail(0),
+				fail(59 * time.Minute), fail(59 * time.Minute), allow(0, 2*time.Minute),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2025, time.January, 10, 12, 0, 0, 0, time.UTC)
+			l := New(NewMemoryStore(), "login", testPolicy)
+			l.now = func() time.Time { return now }
+
+			for i, s := range tt.steps {
+				now = now.Add(s.wait)
+
+				var err error
+				switch s.op {
+				case "allow":
+					err = l.Allow("key")
+				case "fail":
+					err = l.Fail("key")
+				case "reset":
+					err = l.Reset("key")
+				}
+
+				var limited *LimitedError
+				switch {
+				case errors.As(err, &limited):
+					if s.op != "allow" || limited.RetryAfter != s.retryAfter {
+						t.Fatalf("step %d %s: limited for %s, want %s", i, s.op, limited.RetryAfter, s.retryAfter)
+					}
+				case err != nil:
+					t.Fatalf("step %d %s: %v", i, s.op, err)
+				case s.retryAfter != 0:
+					t.Fatalf("step %d %s: allowed, want limited for %s", i, s.op, s.retryAfter)
+				}
+			}
+		})
+	}
+}
+
+func TestCleanup(t *testing.T) {
+	now := time.Date(2025, time.January, 10, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	l := New(store, "login", testPolicy)
+	other := New(store, "other", testPolicy)
+	l.now = func() time.Time { return now }
+	other.now = l.now
+
+	for _, key := range []string{"idle", "active"} {
+		if err := l.Fail(key); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := other.Fail("idle"); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(30 * time.Minute)
+	if err := l.Fail("active"); err != nil {
+		t.Fatal(err)
+	}
+
+	deleted, err := l.Cleanup(now.Add(40 * time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted != 1 {
+		t.Errorf("deleted: got %d, want 1", deleted)
+	}
+	for key, want := range map[string]bool{"login:idle": false, "login:active": true, "other:idle": true} {
+		entry, _ := store.Get(key)
+		if (entry != nil) != want {
+			t.Errorf("%s kept: got %v, want %v", key, entry != nil, want)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package ratelimit
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"kanban/internal/postgres"
+	"strings"
+	"sync"
+	"time"
+)
+
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]Entry
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]Entry)}
+}
+
+func (s *MemoryStore) Get(key string) (*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, nil
+	}
+	return &entry, nil
+}
+
+func (s *MemoryStore) Put(key string, entry Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[key] = entry
+	return nil
+}
+
+func (s *MemoryStore) DeleteIdle(prefix string, before time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deleted := 0
+	for key, entry := range s.entries {
+		if strings.HasPrefix(key, prefix) && entry.UpdatedAt.Before(before) {
+			delete(s.entries, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// PostgresStore keeps entries in the rate_limit table so limits survive
+// restarts and are shared between instances.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+func (s *PostgresStore) Get(key string) (*Entry, error) {
+	var entry Entry
+	err := s.db.QueryRow(postgres.QueryGetRateLimit, key).Scan(
+		&entry.Attempts,
+		&entry.WindowStart,
+		&entry.Failures,
+		&entry.Lockouts,
+		&entry.LockedUntil,
+		&entry.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("ratelimit.PostgresStore.Get: %w", err)
+	}
+	return &entry, nil
+}
+
+func (s *PostgresStore) Put(key string, entry Entry) error {
+	_, err := s.db.Exec(
+		postgres.QueryPutRateLimit,
+		key,
+		entry.Attempts,
+		entry.WindowStart,
+		entry.Failures,
+		entry.Lockouts,
+		entry.LockedUntil,
+		entry.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("ratelimit.PostgresStore.Put: %w", err)
+	}
+	return nil
+}
+
+func (s *PostgresStore) DeleteIdle(prefix string, before time.Time) (int, error) {
+	result, err := s.db.Exec(postgres.QueryDeleteIdleRateLimits, prefix, before)
+	if err != nil {
+		return 0, fmt.Errorf("ratelimit.PostgresStore.DeleteIdle: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("ratelimit.PostgresStore.DeleteIdle: %w", err)
+	}
+	return int(deleted), nil
+}
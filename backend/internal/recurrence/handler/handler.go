@@ -0,0 +1,186 @@
+package recurrenceHandler
+
+import (
+	"errors"
+	authctx "kanban/internal/auth/context"
+	recurrenceModel "kanban/internal/recurrence/model"
+	recurrenceProxy "kanban/internal/recurrence/proxy"
+	recurrenceService "kanban/internal/recurrence/service"
+	"kanban/internal/rrule"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Proxy interface {
+	SetRecurrence(taskID, userID string, req recurrenceModel.Request) error
+	GetRecurrence(taskID, userID string) (*recurrenceModel.Recurrence, error)
+	PauseRecurrence(taskID, userID string) error
+	ResumeRecurrence(taskID, userID string) error
+	DeleteRecurrence(taskID, userID string) error
+}
+
+type Handler struct {
+	proxy Proxy
+}
+
+func NewHandler(proxy Proxy) *Handler {
+	return &Handler{proxy: proxy}
+}
+
+func (h *Handler) SetRecurrenceHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req recurrenceModel.Request
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid request body",
+			})
+			return
+		}
+
+		taskID := ctx.Param("id")
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		err := h.proxy.SetRecurrence(taskID, userID, req)
+		if err != nil {
+			log.Printf("Failed to set recurrence: %v", err)
+			h.handleError(ctx, err, "Failed to set recurrence")
+			return
+		}
+
+		ctx.Status(http.StatusOK)
+	}
+}
+
+func (h *Handler) GetRecurrenceHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		taskID := ctx.Param("id")
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		rec, err := h.proxy.GetRecurrence(taskID, userID)
+		if err != nil {
+			log.Printf("Failed to get recurrence: %v", err)
+			h.handleError(ctx, err, "Failed to get recurrence")
+			return
+		}
+
+		ctx.JSON(http.StatusOK, rec)
+	}
+}
+
+func (h *Handler) PauseRecurrenceHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		taskID := ctx.Param("id")
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		err := h.proxy.PauseRecurrence(taskID, userID)
+		if err != nil {
+			log.Printf("Failed to pause recurrence: %v", err)
+			h.handleError(ctx, err, "Failed to pause recurrence")
+			return
+		}
+
+		ctx.Status(http.StatusOK)
+	}
+}
+
+func (h *Handler) ResumeRecurrenceHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		taskID := ctx.Param("id")
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		err := h.proxy.ResumeRecurrence(taskID, userID)
+		if err != nil {
+			log.Printf("Failed to resume recurrence: %v", err)
+			h.handleError(ctx, err, "Failed to resume recurrence")
+			return
+		}
+
+		ctx.Status(http.StatusOK)
+	}
+}
+
+func (h *Handler) DeleteRecurrenceHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		taskID := ctx.Param("id")
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		err := h.proxy.DeleteRecurrence(taskID, userID)
+		if err != nil {
+			log.Printf("Failed to delete recurrence: %v", err)
+			h.handleError(ctx, err, "Failed to delete recurrence")
+			return
+		}
+
+		ctx.Status(http.StatusOK)
+	}
+}
+
+func (h *Handler) handleError(ctx *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, recurrenceProxy.ErrForbidden):
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"detail": "Access denied",
+		})
+	case errors.Is(err, recurrenceService.ErrTaskNotFound):
+		ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+			"detail": "Task not found",
+		})
+	case errors.Is(err, recurrenceService.ErrColumnNotFound):
+		ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+			"detail": "Column not found",
+		})
+	case errors.Is(err, recurrenceService.ErrRecurrenceNotFound):
+		ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+			"detail": "Recurrence not found",
+		})
+	case errors.Is(err, rrule.ErrInvalidRule):
+		ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{
+			"detail": "Invalid recurrence rule",
+		})
+	case errors.Is(err, recurrenceService.ErrRecurrenceExhausted):
+		ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{
+			"detail": "Recurrence has no future occurrences",
+		})
+	default:
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"detail": message,
+		})
+	}
+}
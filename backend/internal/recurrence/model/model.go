@@ -0,0 +1,21 @@
+package recurrenceModel
+
+import "time"
+
+type Recurrence struct {
+	TaskID      string    `json:"task_id"`
+	ColumnID    string    `json:"column_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	RRule       string    `json:"rrule"`
+	DTStart     time.Time `json:"dtstart"`
+	NextRunAt   time.Time `json:"next_run_at"`
+	Occurrences int       `json:"occurrences"`
+	Paused      bool      `json:"paused"`
+}
+
+type Request struct {
+	RRule    string     `json:"rrule"     binding:"required"`
+	ColumnID *string    `json:"column_id" binding:"omitempty,uuid"`
+	DTStart  *time.Time `json:"dtstart"`
+}
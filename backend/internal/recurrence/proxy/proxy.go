@@ -0,0 +1,117 @@
+package recurrenceProxy
+
+import (
+	"errors"
+	"fmt"
+	recurrenceModel "kanban/internal/recurrence/model"
+)
+
+var ErrForbidden = errors.New("access denied")
+
+type Service interface {
+	SetRecurrence(taskID string, req recurrenceModel.Request) error
+	GetRecurrence(taskID string) (*recurrenceModel.Recurrence, error)
+	PauseRecurrence(taskID string) error
+	ResumeRecurrence(taskID string) error
+	DeleteRecurrence(taskID string) error
+	HasColumnAccess(columnID, userID string) (bool, error)
+	HasTaskAccess(taskID, userID string) (bool, error)
+}
+
+type Proxy struct {
+	service Service
+}
+
+func NewProxy(service Service) *Proxy {
+	return &Proxy{service: service}
+}
+
+func (p *Proxy) SetRecurrence(taskID, userID string, req recurrenceModel.Request) error {
+	hasAccess, err := p.checkTaskAccess(taskID, userID)
+	if err != nil {
+		return fmt.Errorf("recurrenceProxy.SetRecurrence: %w", err)
+	}
+
+	if hasAccess && req.ColumnID != nil {
+		hasAccess, err = p.checkColumnAccess(*req.ColumnID, userID)
+		if err != nil {
+			return fmt.Errorf("recurrenceProxy.SetRecurrence: %w", err)
+		}
+	}
+
+	if hasAccess {
+		return p.service.SetRecurrence(taskID, req)
+	} else {
+		return fmt.Errorf("recurrenceProxy.SetRecurrence: %w", ErrForbidden)
+	}
+}
+
+func (p *Proxy) GetRecurrence(taskID, userID string) (*recurrenceModel.Recurrence, error) {
+	hasAccess, err := p.checkTaskAccess(taskID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("recurrenceProxy.GetRecurrence: %w", err)
+	}
+
+	if hasAccess {
+		return p.service.GetRecurrence(taskID)
+	} else {
+		return nil, fmt.Errorf("recurrenceProxy.GetRecurrence: %w", ErrForbidden)
+	}
+}
+
+func (p *Proxy) PauseRecurrence(taskID, userID string) error {
+	hasAccess, err := p.checkTaskAccess(taskID, userID)
+	if err != nil {
+		return fmt.Errorf("recurrenceProxy.PauseRecurrence: %w", err)
+	}
+
+	if hasAccess {
+		return p.service.PauseRecurrence(taskID)
+	} else {
+		return fmt.Errorf("recurrenceProxy.PauseRecurrence: %w", ErrForbidden)
+	}
+}
+
+func (p *Proxy) ResumeRecurrence(taskID, userID string) error {
+	hasAccess, err := p.checkTaskAccess(taskID, userID)
+	if err != nil {
+		return fmt.Errorf("recurrenceProxy.ResumeRecurrence: %w", err)
+	}
+
+	if hasAccess {
+		return p.service.ResumeRecurrence(taskID)
+	} else {
+		return fmt.Errorf("recurrenceProxy.ResumeRecurrence: %w", ErrForbidden)
+	}
+}
+
+func (p *Proxy) DeleteRecurrence(taskID, userID string) error {
+	hasAccess, err := p.checkTaskAccess(taskID, userID)
+	if err != nil {
+		return fmt.Errorf("recurrenceProxy.DeleteRecurrence: %w", err)
+	}
+
+	if hasAccess {
+		return p.service.DeleteRecurrence(taskID)
+	} else {
+		return fmt.Errorf("recurrenceProxy.DeleteRecurrence: %w", ErrForbidden)
+	}
+}
+
+func (p *Proxy) checkColumnAccess(columnID, userID string) (bool, error) {
+	hasAccess, err := p.service.HasColumnAccess(columnID, userID)
+	if err != nil {
+		return false, fmt.Errorf("recurrenceProxy.checkColumnAccess: %w", err)
+	}
+
+	return hasAccess, nil
+}
+
+func (p *Proxy) checkTaskAccess(taskID, userID string) (bool, error) {
+	hasAccess, err := p.service.HasTaskAccess(taskID, userID)
+	if err != nil {
+		return false, fmt.Errorf("recurrenceProxy.checkTaskAccess: %w", err)
+	}
+
+	return hasAccess, nil
+}
@@ -0,0 +1,28 @@
+package recurrence
+
+import (
+	"database/sql"
+	"kanban/internal/config"
+	recurrenceHandler "kanban/internal/recurrence/handler"
+	recurrenceProxy "kanban/internal/recurrence/proxy"
+	recurrenceRepo "kanban/internal/recurrence/repo"
+	recurrenceService "kanban/internal/recurrence/service"
+	"kanban/internal/scheduler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Init(db *sql.DB, grp *gin.RouterGroup) {
+	repo := recurrenceRepo.NewRepository(db)
+	service := recurrenceService.NewService(repo)
+	proxy := recurrenceProxy.NewProxy(service)
+	handler := recurrenceHandler.NewHandler(proxy)
+
+	grp.GET("/tasks/:id/recurrence", handler.GetRecurrenceHandler())
+	grp.PUT("/tasks/:id/recurrence", handler.SetRecurrenceHandler())
+	grp.DELETE("/tasks/:id/recurrence", handler.DeleteRecurrenceHandler())
+	grp.POST("/tasks/:id/recurrence/pause", handler.PauseRecurrenceHandler())
+	grp.POST("/tasks/:id/recurrence/resume", handler.ResumeRecurrenceHandler())
+
+	scheduler.New("Recurrence", config.Get().SchedulerInterval, service.SpawnDue).Start()
+}
@@ -0,0 +1,240 @@
+package recurrenceRepo
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"kanban/internal/history"
+	"kanban/internal/postgres"
+	recurrenceModel "kanban/internal/recurrence/model"
+	taskRepo "kanban/internal/task/repo"
+	"kanban/internal/utils"
+	"time"
+)
+
+var ErrRecurrenceBusy = errors.New("recurrence is being processed")
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Upsert(rec recurrenceModel.Recurrence) error {
+	_, err := r.db.Exec(
+		postgres.QueryUpsertTaskRecurrence,
+		rec.TaskID,
+		rec.ColumnID,
+		utils.GenerateTimestamp(),
+		rec.RRule,
+		rec.DTStart,
+		rec.NextRunAt,
+		rec.Occurrences,
+	)
+	if err != nil {
+		return fmt.Errorf("recurrenceRepo.Upsert: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) Get(taskID string) (*recurrenceModel.Recurrence, error) {
+	rec, err := scanRecurrence(r.db.QueryRow(postgres.QueryGetTaskRecurrence, taskID))
+	if err != nil {
+		return nil, fmt.Errorf("recurrenceRepo.Get: %w", err)
+	}
+	return rec, nil
+}
+
+func (r *Repository) UpdateSchedule(taskID string, paused bool, nextRunAt time.Time, occurrences int) error {
+	res, err := r.db.Exec(
+		postgres.QueryUpdateTaskRecurrenceSchedule,
+		paused,
+		nextRunAt,
+		occurrences,
+		utils.GenerateTimestamp(),
+		taskID,
+	)
+	if err != nil {
+		return fmt.Errorf("recurrenceRepo.UpdateSchedule: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("recurrenceRepo.UpdateSchedule: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("recurrenceRepo.UpdateSchedule: %w", sql.ErrNoRows)
+	}
+	return nil
+}
+
+func (r *Repository) Delete(taskID string) error {
+	res, err := r.db.Exec(postgres.QueryDeleteTaskRecurrence, taskID)
+	if err != nil {
+		return fmt.Errorf("recurrenceRepo.Delete: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("recurrenceRepo.Delete: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("recurrenceRepo.Delete: %w", sql.ErrNoRows)
+	}
+	return nil
+}
+
+func (r *Repository) GetDue(now time.Time, limit int) ([]string, error) {
+	rows, err := r.db.Query(postgres.QueryGetDueTaskRecurrences, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("recurrenceRepo.GetDue: %w", err)
+	}
+	defer rows.Close()
+
+	var taskIDs []string
+	for rows.Next() {
+		var taskID string
+		if err := rows.Scan(&taskID); err != nil {
+			return nil, fmt.Errorf("recurrenceRepo.GetDue: %w", err)
+		}
+		taskIDs = append(taskIDs, taskID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("recurrenceRepo.GetDue: %w", err)
+	}
+
+	return taskIDs, nil
+}
+
+// Spawn creates the next instance of a recurring task and moves the
+// recurrence onto it. next computes the schedule after the spawned
+// occurrence; when it reports the series as exhausted the recurrence is
+// removed instead of moved.
+func (r *Repository) Spawn(taskID string, next func(rec recurrenceModel.Recurrence) (time.Time, bool)) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("recurrenceRepo.Spawn: %w", err)
+	}
+	defer tx.Rollback()
+
+	rec, err := scanRecurrence(tx.QueryRow(postgres.QueryGetTaskRecurrenceForUpdate, taskID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("recurrenceRepo.Spawn: %w", ErrRecurrenceBusy)
+		}
+		return fmt.Errorf("recurrenceRepo.Spawn: %w", err)
+	}
+
+	var count int
+	err = tx.QueryRow(postgres.QueryGetTasksCount, rec.ColumnID).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("recurrenceRepo.Spawn: %w", err)
+	}
+	if count >= taskRepo.MaxTasks {
+		return fmt.Errorf("recurrenceRepo.Spawn: %w", taskRepo.ErrTaskLimitReached)
+	}
+
+	var position int
+	err = tx.QueryRow(postgres.QueryGetMaxTaskPosition, rec.ColumnID).Scan(&position)
+	if err != nil {
+		return fmt.Errorf("recurrenceRepo.Spawn: %w", err)
+	}
+
+	var name, description string
+	err = tx.QueryRow(postgres.QueryGetTaskNameAndDescription, taskID).Scan(&name, &description)
+	if err != nil {
+		return fmt.Errorf("recurrenceRepo.Spawn: %w", err)
+	}
+
+	newTaskID := utils.NewUUID()
+	now := utils.GenerateTimestamp()
+	_, err = tx.Exec(
+		postgres.QueryCreateTaskWithDeadline,
+		newTaskID,
+		rec.ColumnID,
+		now,
+		name,
+		description,
+		position,
+		rec.NextRunAt,
+	)
+	if err != nil {
+		return fmt.Errorf("recurrenceRepo.Spawn: %w", err)
+	}
+
+	spawned, err := taskRepo.LockTask(tx, newTaskID)
+	if err != nil {
+		return fmt.Errorf("recurrenceRepo.Spawn: %w", err)
+	}
+
+	// Spawned tasks are recorded without a user, undo does not take them.
+	err = history.RecordTask(tx, "", history.ActionCreate, nil, spawned)
+	if err != nil {
+		return fmt.Errorf("recurrenceRepo.Spawn: %w", err)
+	}
+
+	nextRunAt, ok := next(*rec)
+	if ok {
+		_, err = tx.Exec(postgres.QueryMoveTaskRecurrence, newTaskID, nextRunAt, now, taskID)
+	} else {
+		_, err = tx.Exec(postgres.QueryDeleteTaskRecurrence, taskID)
+	}
+	if err != nil {
+		return fmt.Errorf("recurrenceRepo.Spawn: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("recurrenceRepo.Spawn: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) GetTaskColumn(taskID string) (*string, error) {
+	var columnID string
+	var pos int
+	err := r.db.QueryRow(postgres.QueryGetColumnIDAndPosition, taskID).Scan(&columnID, &pos)
+	if err != nil {
+		return nil, fmt.Errorf("recurrenceRepo.GetTaskColumn: %w", err)
+	}
+	return &columnID, nil
+}
+
+func (r *Repository) HasColumnAccess(columnID, userID string) (bool, error) {
+	var hasAccess bool
+	err := r.db.QueryRow(postgres.QueryHasColumnAccess, columnID, userID, postgres.OwnBoardsOnly()).Scan(&hasAccess)
+	if err != nil {
+		return false, fmt.Errorf("recurrenceRepo.HasColumnAccess: %w", err)
+	}
+	return hasAccess, nil
+}
+
+func (r *Repository) HasTaskAccess(taskID, userID string) (bool, error) {
+	var hasAccess bool
+	err := r.db.QueryRow(postgres.QueryHasTaskAccess, taskID, userID, postgres.OwnBoardsOnly()).Scan(&hasAccess)
+	if err != nil {
+		return false, fmt.Errorf("recurrenceRepo.HasTaskAccess: %w", err)
+	}
+	return hasAccess, nil
+}
+
+func scanRecurrence(row *sql.Row) (*recurrenceModel.Recurrence, error) {
+	var rec recurrenceModel.Recurrence
+	err := row.Scan(
+		&rec.TaskID,
+		&rec.ColumnID,
+		&rec.CreatedAt,
+		&rec.UpdatedAt,
+		&rec.RRule,
+		&rec.DTStart,
+		&rec.NextRunAt,
+		&rec.Occurrences,
+		&rec.Paused,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
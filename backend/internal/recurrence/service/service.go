@@ -0,0 +1,208 @@
+package recurrenceService
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	recurrenceModel "kanban/internal/recurrence/model"
+	recurrenceRepo "kanban/internal/recurrence/repo"
+	"kanban/internal/rrule"
+	"kanban/internal/utils"
+	"log"
+	"time"
+)
+
+const dueBatchSize int = 100
+
+var ErrRecurrenceNotFound = errors.New("recurrence not found")
+var ErrTaskNotFound = errors.New("task not found")
+var ErrColumnNotFound = errors.New("column not found")
+var ErrRecurrenceExhausted = errors.New("recurrence has no future occurrences")
+
+type Repository interface {
+	Upsert(rec recurrenceModel.Recurrence) error
+	Get(taskID string) (*recurrenceModel.Recurrence, error)
+	UpdateSchedule(taskID string, paused bool, nextRunAt time.Time, occurrences int) error
+	Delete(taskID string) error
+	GetDue(now time.Time, limit int) ([]string, error)
+	Spawn(taskID string, next func(rec recurrenceModel.Recurrence) (time.Time, bool)) error
+	GetTaskColumn(taskID string) (*string, error)
+	HasColumnAccess(columnID, userID string) (bool, error)
+	HasTaskAccess(taskID, userID string) (bool, error)
+}
+
+type Service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+func (s *Service) SetRecurrence(taskID string, req recurrenceModel.Request) error {
+	rule, err := rrule.Parse(req.RRule)
+	if err != nil {
+		return fmt.Errorf("recurrenceService.SetRecurrence: %w", err)
+	}
+
+	columnID := req.ColumnID
+	if columnID == nil {
+		columnID, err = s.repo.GetTaskColumn(taskID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("recurrenceService.SetRecurrence: %w", ErrTaskNotFound)
+			}
+			return fmt.Errorf("recurrenceService.SetRecurrence: %w", err)
+		}
+	}
+
+	now := utils.GenerateTimestamp()
+	dtstart := now
+	if req.DTStart != nil {
+		dtstart = *req.DTStart
+	}
+
+	nextRunAt, index, ok := rule.Next(dtstart, now)
+	if !ok {
+		return fmt.Errorf("recurrenceService.SetRecurrence: %w", ErrRecurrenceExhausted)
+	}
+
+	rec := recurrenceModel.Recurrence{
+		TaskID:      taskID,
+		ColumnID:    *columnID,
+		RRule:       req.RRule,
+		DTStart:     dtstart,
+		NextRunAt:   nextRunAt,
+		Occurrences: index - 1,
+	}
+
+	err = s.repo.Upsert(rec)
+	if err != nil {
+		return fmt.Errorf("recurrenceService.SetRecurrence: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetRecurrence(taskID string) (*recurrenceModel.Recurrence, error) {
+	rec, err := s.repo.Get(taskID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("recurrenceService.GetRecurrence: %w", ErrRecurrenceNotFound)
+		}
+		return nil, fmt.Errorf("recurrenceService.GetRecurrence: %w", err)
+	}
+
+	return rec, nil
+}
+
+func (s *Service) PauseRecurrence(taskID string) error {
+	rec, err := s.GetRecurrence(taskID)
+	if err != nil {
+		return fmt.Errorf("recurrenceService.PauseRecurrence: %w", err)
+	}
+
+	err = s.repo.UpdateSchedule(taskID, true, rec.NextRunAt, rec.Occurrences)
+	if err != nil {
+		return fmt.Errorf("recurrenceService.PauseRecurrence: %w", err)
+	}
+
+	return nil
+}
+
+// ResumeRecurrence skips occurrences missed while the recurrence was paused
+// and schedules the first one after the current time.
+func (s *Service) ResumeRecurrence(taskID string) error {
+	rec, err := s.GetRecurrence(taskID)
+	if err != nil {
+		return fmt.Errorf("recurrenceService.ResumeRecurrence: %w", err)
+	}
+
+	rule, err := rrule.Parse(rec.RRule)
+	if err != nil {
+		return fmt.Errorf("recurrenceService.ResumeRecurrence: %w", err)
+	}
+
+	nextRunAt, index, ok := rule.Next(rec.DTStart, utils.GenerateTimestamp())
+	if !ok {
+		return fmt.Errorf("recurrenceService.ResumeRecurrence: %w", ErrRecurrenceExhausted)
+	}
+
+	err = s.repo.UpdateSchedule(taskID, false, nextRunAt, index-1)
+	if err != nil {
+		return fmt.Errorf("recurrenceService.ResumeRecurrence: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteRecurrence(taskID string) error {
+	err := s.repo.Delete(taskID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("recurrenceService.DeleteRecurrence: %w", ErrRecurrenceNotFound)
+		}
+		return fmt.Errorf("recurrenceService.DeleteRecurrence: %w", err)
+	}
+
+	return nil
+}
+
+// SpawnDue creates the next instance for every recurrence whose current
+// task is done or whose next occurrence has come, and returns how many
+// instances were created.
+func (s *Service) SpawnDue(now time.Time) (int, error) {
+	taskIDs, err := s.repo.GetDue(now, dueBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("recurrenceService.SpawnDue: %w", err)
+	}
+
+	spawned := 0
+	for _, taskID := range taskIDs {
+		err := s.repo.Spawn(taskID, nextOccurrence)
+		if err != nil {
+			if errors.Is(err, recurrenceRepo.ErrRecurrenceBusy) {
+				continue
+			}
+			log.Printf("Failed to spawn recurring task %s: %v", taskID, err)
+			continue
+		}
+		spawned++
+	}
+
+	return spawned, nil
+}
+
+func (s *Service) HasColumnAccess(columnID, userID string) (bool, error) {
+	hasAccess, err := s.repo.HasColumnAccess(columnID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, fmt.Errorf("recurrenceService.HasColumnAccess: %w", ErrColumnNotFound)
+		}
+		return false, fmt.Errorf("recurrenceService.HasColumnAccess: %w", err)
+	}
+
+	return hasAccess, nil
+}
+
+func (s *Service) HasTaskAccess(taskID, userID string) (bool, error) {
+	hasAccess, err := s.repo.HasTaskAccess(taskID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, fmt.Errorf("recurrenceService.HasTaskAccess: %w", ErrTaskNotFound)
+		}
+		return false, fmt.Errorf("recurrenceService.HasTaskAccess: %w", err)
+	}
+
+	return hasAccess, nil
+}
+
+func nextOccurrence(rec recurrenceModel.Recurrence) (time.Time, bool) {
+	rule, err := rrule.Parse(rec.RRule)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	next, _, ok := rule.Next(rec.DTStart, rec.NextRunAt)
+	return next, ok
+}
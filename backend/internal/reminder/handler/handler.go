@@ -0,0 +1,117 @@
+package reminderHandler
+
+import (
+	"errors"
+	authctx "kanban/internal/auth/context"
+	reminderModel "kanban/internal/reminder/model"
+	reminderProxy "kanban/internal/reminder/proxy"
+	reminderService "kanban/internal/reminder/service"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Proxy interface {
+	GetSettings(userID string) (*reminderModel.Settings, error)
+	UpdateSettings(userID string, req reminderModel.SettingsRequest) error
+	GetOverdueTasks(boardID, userID string) ([]reminderModel.OverdueTask, error)
+}
+
+type Handler struct {
+	proxy Proxy
+}
+
+func NewHandler(proxy Proxy) *Handler {
+	return &Handler{proxy: proxy}
+}
+
+func (h *Handler) GetSettingsHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		settings, err := h.proxy.GetSettings(userID)
+		if err != nil {
+			log.Printf("Failed to get reminder settings: %v", err)
+			h.handleError(ctx, err, "Failed to get reminder settings")
+			return
+		}
+
+		ctx.JSON(http.StatusOK, settings)
+	}
+}
+
+func (h *Handler) UpdateSettingsHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req reminderModel.SettingsRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid request body",
+			})
+			return
+		}
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		err := h.proxy.UpdateSettings(userID, req)
+		if err != nil {
+			log.Printf("Failed to update reminder settings: %v", err)
+			h.handleError(ctx, err, "Failed to update reminder settings")
+			return
+		}
+
+		ctx.Status(http.StatusOK)
+	}
+}
+
+func (h *Handler) GetOverdueTasksHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		boardID := ctx.Param("id")
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		tasks, err := h.proxy.GetOverdueTasks(boardID, userID)
+		if err != nil {
+			log.Printf("Failed to get overdue tasks: %v", err)
+			h.handleError(ctx, err, "Failed to get overdue tasks")
+			return
+		}
+
+		ctx.JSON(http.StatusOK, tasks)
+	}
+}
+
+func (h *Handler) handleError(ctx *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, reminderProxy.ErrForbidden):
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"detail": "Access denied",
+		})
+	case errors.Is(err, reminderService.ErrBoardNotFound):
+		ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+			"detail": "Board not found",
+		})
+	default:
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"detail": message,
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package reminderModel
+
+import "time"
+
+type Settings struct {
+	RemindBeforeMinutes []int `json:"remind_before_minutes"`
+	NotifyOverdue       bool  `json:"notify_overdue"`
+}
+
+type SettingsRequest struct {
+	RemindBeforeMinutes []int `json:"remind_before_minutes" binding:"required,max=10,dive,min=1,max=43200"`
+	NotifyOverdue       *bool `json:"notify_overdue"`
+}
+
+type OverdueTask struct {
+	ID          string    `json:"id"`
+	ColumnID    string    `json:"column_id"`
+	ColumnName  string    `json:"column_name"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Deadline    time.Time `json:"deadline"`
+}
+
+// Reminder is a pending deadline notification for one task and recipient.
+// BeforeMinutes is zero for the overdue notification.
+type Reminder struct {
+	TaskID        string
+	TaskName      string
+	Deadline      time.Time
+	BoardID       string
+	BoardName     string
+	UserID        string
+	Email         string
+	Username      string
+	BeforeMinutes int
+}
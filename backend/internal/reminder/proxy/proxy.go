@@ -0,0 +1,54 @@
+package reminderProxy
+
+import (
+	"errors"
+	"fmt"
+	reminderModel "kanban/internal/reminder/model"
+)
+
+var ErrForbidden = errors.New("access denied")
+
+type Service interface {
+	GetSettings(userID string) (*reminderModel.Settings, error)
+	UpdateSettings(userID string, req reminderModel.SettingsRequest) error
+	GetOverdueTasks(boardID string) ([]reminderModel.OverdueTask, error)
+	HasBoardAccess(boardID, userID string) (bool, error)
+}
+
+type Proxy struct {
+	service Service
+}
+
+func NewProxy(service Service) *Proxy {
+	return &Proxy{service: service}
+}
+
+func (p *Proxy) GetSettings(userID string) (*reminderModel.Settings, error) {
+	return p.service.GetSettings(userID)
+}
+
+func (p *Proxy) UpdateSettings(userID string, req reminderModel.SettingsRequest) error {
+	return p.service.UpdateSettings(userID, req)
+}
+
+func (p *Proxy) GetOverdueTasks(boardID, userID string) ([]reminderModel.OverdueTask, error) {
+	hasAccess, err := p.checkBoardAccess(boardID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("reminderProxy.GetOverdueTasks: %w", err)
+	}
+
+	if hasAccess {
+		return p.service.GetOverdueTasks(boardID)
+	} else {
+		return nil, fmt.Errorf("reminderProxy.GetOverdueTasks: %w", ErrForbidden)
+	}
+}
+
+func (p *Proxy) checkBoardAccess(boardID, userID string) (bool, error) {
+	hasAccess, err := p.service.HasBoardAccess(boardID, userID)
+	if err != nil {
+		return false, fmt.Errorf("reminderProxy.checkBoardAccess: %w", err)
+	}
+
+	return hasAccess, nil
+}
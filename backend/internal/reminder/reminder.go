@@ -0,0 +1,27 @@
+package reminder
+
+import (
+	"database/sql"
+	"kanban/internal/config"
+	"kanban/internal/notify"
+	reminderHandler "kanban/internal/reminder/handler"
+	reminderProxy "kanban/internal/reminder/proxy"
+	reminderRepo "kanban/internal/reminder/repo"
+	reminderService "kanban/internal/reminder/service"
+	"kanban/internal/scheduler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Init(db *sql.DB, grp *gin.RouterGroup) {
+	repo := reminderRepo.NewRepository(db)
+	service := reminderService.NewService(repo, notify.New(db))
+	proxy := reminderProxy.NewProxy(service)
+	handler := reminderHandler.NewHandler(proxy)
+
+	grp.GET("/boards/:id/overdue", handler.GetOverdueTasksHandler())
+	grp.GET("/me/reminders", handler.GetSettingsHandler())
+	grp.PUT("/me/reminders", handler.UpdateSettingsHandler())
+
+	scheduler.New("Deadline reminder", config.Get().SchedulerInterval, service.SendDue).Start()
+}
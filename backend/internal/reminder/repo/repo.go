@@ -0,0 +1,144 @@
+package reminderRepo
+
+import (
+	"database/sql"
+	"fmt"
+	"kanban/internal/postgres"
+	reminderModel "kanban/internal/reminder/model"
+	"kanban/internal/utils"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) GetSettings(userID string) (*reminderModel.Settings, error) {
+	var settings reminderModel.Settings
+	var minutes pq.Int64Array
+	err := r.db.QueryRow(postgres.QueryGetReminderSettings, userID).Scan(
+		&minutes,
+		&settings.NotifyOverdue,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("reminderRepo.GetSettings: %w", err)
+	}
+
+	settings.RemindBeforeMinutes = make([]int, 0, len(minutes))
+	for _, m := range minutes {
+		settings.RemindBeforeMinutes = append(settings.RemindBeforeMinutes, int(m))
+	}
+	return &settings, nil
+}
+
+func (r *Repository) UpsertSettings(userID string, settings reminderModel.Settings) error {
+	minutes := make(pq.Int64Array, 0, len(settings.RemindBeforeMinutes))
+	for _, m := range settings.RemindBeforeMinutes {
+		minutes = append(minutes, int64(m))
+	}
+
+	_, err := r.db.Exec(
+		postgres.QueryUpsertReminderSettings,
+		userID,
+		utils.GenerateTimestamp(),
+		minutes,
+		settings.NotifyOverdue,
+	)
+	if err != nil {
+		return fmt.Errorf("reminderRepo.UpsertSettings: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) GetDue(now, since time.Time, limit int) ([]reminderModel.Reminder, error) {
+	rows, err := r.db.Query(postgres.QueryGetDueReminders, now, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("reminderRepo.GetDue: %w", err)
+	}
+	defer rows.Close()
+
+	var reminders []reminderModel.Reminder
+	for rows.Next() {
+		var reminder reminderModel.Reminder
+		if err := rows.Scan(
+			&reminder.TaskID,
+			&reminder.TaskName,
+			&reminder.Deadline,
+			&reminder.BoardID,
+			&reminder.BoardName,
+			&reminder.UserID,
+			&reminder.Email,
+			&reminder.Username,
+			&reminder.BeforeMinutes,
+		); err != nil {
+			return nil, fmt.Errorf("reminderRepo.GetDue: %w", err)
+		}
+		reminders = append(reminders, reminder)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reminderRepo.GetDue: %w", err)
+	}
+
+	return reminders, nil
+}
+
+func (r *Repository) MarkSent(reminder reminderModel.Reminder) error {
+	_, err := r.db.Exec(
+		postgres.QueryMarkReminderSent,
+		reminder.TaskID,
+		reminder.UserID,
+		reminder.BeforeMinutes,
+		reminder.Deadline,
+		utils.GenerateTimestamp(),
+	)
+	if err != nil {
+		return fmt.Errorf("reminderRepo.MarkSent: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) GetOverdue(boardID string, now time.Time) ([]reminderModel.OverdueTask, error) {
+	rows, err := r.db.Query(postgres.QueryGetOverdueTasks, boardID, now)
+	if err != nil {
+		return nil, fmt.Errorf("reminderRepo.GetOverdue: %w", err)
+	}
+	defer rows.Close()
+
+	tasks := []reminderModel.OverdueTask{}
+	for rows.Next() {
+		var task reminderModel.OverdueTask
+		if err := rows.Scan(
+			&task.ID,
+			&task.ColumnID,
+			&task.ColumnName,
+			&task.Name,
+			&task.Description,
+			&task.Deadline,
+		); err != nil {
+			return nil, fmt.Errorf("reminderRepo.GetOverdue: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reminderRepo.GetOverdue: %w", err)
+	}
+
+	return tasks, nil
+}
+
+func (r *Repository) HasBoardAccess(boardID, userID string) (bool, error) {
+	var hasAccess bool
+	err := r.db.QueryRow(postgres.QueryHasBoardAccess, boardID, userID, postgres.OwnBoardsOnly()).Scan(&hasAccess)
+	if err != nil {
+		return false, fmt.Errorf("reminderRepo.HasBoardAccess: %w", err)
+	}
+	return hasAccess, nil
+}
@@ -0,0 +1,161 @@
+package reminderService
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"kanban/internal/notify"
+	reminderModel "kanban/internal/reminder/model"
+	"kanban/internal/utils"
+	"log"
+	"time"
+)
+
+const (
+	dueBatchSize int = 500
+
+	// overdueLookback keeps the scheduler from notifying about tasks that
+	// were already long overdue when reminders were enabled.
+	overdueLookback = 7 * 24 * time.Hour
+)
+
+var ErrBoardNotFound = errors.New("board not found")
+
+var defaultSettings = reminderModel.Settings{
+	RemindBeforeMinutes: []int{24 * 60},
+	NotifyOverdue:       true,
+}
+
+type Repository interface {
+	GetSettings(userID string) (*reminderModel.Settings, error)
+	UpsertSettings(userID string, settings reminderModel.Settings) error
+	GetDue(now, since time.Time, limit int) ([]reminderModel.Reminder, error)
+	MarkSent(reminder reminderModel.Reminder) error
+	GetOverdue(boardID string, now time.Time) ([]reminderModel.OverdueTask, error)
+	HasBoardAccess(boardID, userID string) (bool, error)
+}
+
+type Service struct {
+	repo     Repository
+	notifier notify.Notifier
+}
+
+func NewService(repo Repository, notifier notify.Notifier) *Service {
+	return &Service{repo: repo, notifier: notifier}
+}
+
+func (s *Service) GetSettings(userID string) (*reminderModel.Settings, error) {
+	settings, err := s.repo.GetSettings(userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			settings := defaultSettings
+			return &settings, nil
+		}
+		return nil, fmt.Errorf("reminderService.GetSettings: %w", err)
+	}
+
+	return settings, nil
+}
+
+func (s *Service) UpdateSettings(userID string, req reminderModel.SettingsRequest) error {
+	settings := reminderModel.Settings{
+		RemindBeforeMinutes: req.RemindBeforeMinutes,
+		NotifyOverdue:       defaultSettings.NotifyOverdue,
+	}
+	if req.NotifyOverdue != nil {
+		settings.NotifyOverdue = *req.NotifyOverdue
+	}
+
+	err := s.repo.UpsertSettings(userID, settings)
+	if err != nil {
+		return fmt.Errorf("reminderService.UpdateSettings: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetOverdueTasks(boardID string) ([]reminderModel.OverdueTask, error) {
+	tasks, err := s.repo.GetOverdue(boardID, utils.GenerateTimestamp())
+	if err != nil {
+		return nil, fmt.Errorf("reminderService.GetOverdueTasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// SendDue notifies recipients about approaching and passed deadlines.
+// When several reminders for the same task are due at once, only the most
+// urgent one is delivered and the rest are marked as sent.
+func (s *Service) SendDue(now time.Time) (int, error) {
+	reminders, err := s.repo.GetDue(now, now.Add(-overdueLookback), dueBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("reminderService.SendDue: %w", err)
+	}
+
+	sent := 0
+	delivered := make(map[string]bool)
+	failed := make(map[string]bool)
+	for _, reminder := range reminders {
+		key := reminder.TaskID + "/" + reminder.UserID
+		if failed[key] {
+			continue
+		}
+		if !delivered[key] {
+			err := s.notifier.Notify(buildNotification(reminder, now))
+			if err != nil {
+				log.Printf("Failed to deliver deadline reminder for task %s: %v", reminder.TaskID, err)
+				failed[key] = true
+				continue
+			}
+			delivered[key] = true
+			sent++
+		}
+
+		err := s.repo.MarkSent(reminder)
+		if err != nil {
+			return sent, fmt.Errorf("reminderService.SendDue: %w", err)
+		}
+	}
+
+	return sent, nil
+}
+
+func (s *Service) HasBoardAccess(boardID, userID string) (bool, error) {
+	hasAccess, err := s.repo.HasBoardAccess(boardID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, fmt.Errorf("reminderService.HasBoardAccess: %w", ErrBoardNotFound)
+		}
+		return false, fmt.Errorf("reminderService.HasBoardAccess: %w", err)
+	}
+
+	return hasAccess, nil
+}
+
+func buildNotification(reminder reminderModel.Reminder, now time.Time) notify.Notification {
+	n := notify.Notification{
+		UserID:  reminder.UserID,
+		Email:   reminder.Email,
+		BoardID: &reminder.BoardID,
+		TaskID:  &reminder.TaskID,
+	}
+
+	deadline := reminder.Deadline.UTC().Format(time.RFC1123)
+	if reminder.BeforeMinutes == 0 {
+		n.Kind = notify.KindTaskOverdue
+		n.Title = fmt.Sprintf("Task %q is overdue", reminder.TaskName)
+		n.Body = fmt.Sprintf(
+			"Hi %s,\n\ntask %q on board %q was due at %s.",
+			reminder.Username, reminder.TaskName, reminder.BoardName, deadline,
+		)
+	} else {
+		left := reminder.Deadline.Sub(now).Round(time.Minute)
+		n.Kind = notify.KindDeadlineReminder
+		n.Title = fmt.Sprintf("Task %q is due soon", reminder.TaskName)
+		n.Body = fmt.Sprintf(
+			"Hi %s,\n\ntask %q on board %q is due at %s (in %s).",
+			reminder.Username, reminder.TaskName, reminder.BoardName, deadline, left,
+		)
+	}
+	return n
+}
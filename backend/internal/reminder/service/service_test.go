@@ -0,0 +1,198 @@
+package reminderService
+
+import (
+	"errors"
+	"kanban/internal/notify"
+	reminderModel "kanban/internal/reminder/model"
+	"strings"
+	"testing"
+	"time"
+)
+
+var errMailDown = errors.New("mail server is down")
+
+// stubRepo serves the due reminders, the rest of Repository is left nil.
+type stubRepo struct {
+	Repository
+	due     []reminderModel.Reminder
+	marked  []reminderModel.Reminder
+	markErr error
+	since   time.Time
+	limit   int
+}
+
+func (r *stubRepo) GetDue(now, since time.Time, limit int) ([]reminderModel.Reminder, error) {
+	r.since, r.limit = since, limit
+	return r.due, nil
+}
+
+func (r *stubRepo) MarkSent(reminder reminderModel.Reminder) error {
+	if r.markErr != nil {
+		return r.markErr
+	}
+	r.marked = append(r.marked, reminder)
+	return nil
+}
+
+// stubNotifier records notifications and fails for the given users.
+type stubNotifier struct {
+	sent    []notify.Notification
+	failFor map[string]bool
+}
+
+func (n *stubNotifier) Notify(notification notify.Notification) error {
+	if n.failFor[notification.UserID] {
+		return errMailDown
+	}
+	n.sent = append(n.sent, notification)
+	return nil
+}
+
+func TestSendDue(t *testing.T) {
+	now := time.Date(2025, time.January, 10, 12, 0, 0, 0, time.UTC)
+	reminder := func(taskID, userID string, beforeMinutes int) reminderModel.Reminder {
+		return reminderModel.Reminder{
+			TaskID:        taskID,
+			TaskName:      "Release",
+			Deadline:      now.Add(time.Hour),
+			BoardID:       "board",
+			BoardName:     "Roadmap",
+			UserID:        userID,
+			Email:         userID + "@example.org",
+			Username:      userID,
+			BeforeMinutes: beforeMinutes,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		due        []reminderModel.Reminder
+		failFor    map[string]bool
+		markErr    error
+		wantSent   int
+		wantKinds  []string
+		wantMarked int
+		wantErr    bool
+	}{
+		{
+			name: "nothing due",
+		},
+		{
+			name:       "one reminder",
+			due:        []reminderModel.Reminder{reminder("a", "alice", 60)},
+			wantSent:   1,
+			wantKinds:  []string{notify.KindDeadlineReminder},
+			wantMarked: 1,
+		},
+		{
+			name:       "most urgent reminder of a task wins",
+			due:        []reminderModel.Reminder{reminder("a", "alice", 0), reminder("a", "alice", 60), reminder("a", "alice", 1440)},
+			wantSent:   1,
+			wantKinds:  []string{notify.KindTaskOverdue},
+			wantMarked: 3,
+		},
+		{
+			name:       "every recipient is notified",
+			due:        []reminderModel.Reminder{reminder("a", "alice", 60), reminder("a", "bob", 60)},
+			wantSent:   2,
+			wantKinds:  []string{notify.KindDeadlineReminder, notify.KindDeadlineReminder},
+			wantMarked: 2,
+		},
+		{
+			name:       "failed delivery is retried later",
+			due:        []reminderModel.Reminder{reminder("a", "alice", 0), reminder("a", "alice", 60), reminder("b", "bob", 60)},
+			failFor:    map[string]bool{"alice": true},
+			wantSent:   1,
+			wantKinds:  []string{notify.KindDeadlineReminder},
+			wantMarked: 1,
+		},
+		{
+			name:      "marking fails",
+			due:       []reminderModel.Reminder{reminder("a", "alice", 60)},
+			markErr:   errors.New("connection reset"),
+			wantSent:  1,
+			wantKinds: []string{notify.KindDeadlineReminder},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubRepo{due: tt.due, markErr: tt.markErr}
+			notifier := &stubNotifier{failFor: tt.failFor}
+			s := NewService(repo, notifier)
+
+			sent, err := s.SendDue(now)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("error: got %v, want error %v", err, tt.wantErr)
+			}
+			if sent != tt.wantSent || len(notifier.sent) != tt.wantSent {
+				t.Errorf("sent: got %d (%d delivered), want %d", sent, len(notifier.sent), tt.wantSent)
+			}
+			for i, n := range notifier.sent {
+				if i < len(tt.wantKinds) && n.Kind != tt.wantKinds[i] {
+					t.Errorf("notification %d kind: got %s, want %s", i, n.Kind, tt.wantKinds[i])
+				}
+			}
+			if len(repo.marked) != tt.wantMarked {
+				t.Errorf("marked: got %d, want %d", len(repo.marked), tt.wantMarked)
+			}
+			if !repo.since.Equal(now.Add(-overdueLookback)) || repo.limit != dueBatchSize {
+				t.Errorf("due query: got since %s, limit %d", repo.since, repo.limit)
+			}
+		})
+	}
+}
+
+func TestBuildNotification(t *testing.T) {
+	now := time.Date(2025, time.January, 10, 12, 0, 0, 0, time.UTC)
+	reminder := reminderModel.Reminder{
+		TaskID:    "task",
+		TaskName:  "Release",
+		Deadline:  now.Add(90 * time.Minute),
+		BoardID:   "board",
+		BoardName: "Roadmap",
+		UserID:    "alice",
+		Email:     "alice@example.org",
+		Username:  "Alice",
+	}
+
+	tests := []struct {
+		name          string
+		beforeMinutes int
+		wantKind      string
+		wantTitle     string
+		wantBody      string
+	}{
+		{
+			name:          "before the deadline",
+			beforeMinutes: 120,
+			wantKind:      notify.KindDeadlineReminder,
+			wantTitle:     `Task "Release" is due soon`,
+			wantBody:      "(in 1h30m0s)",
+		},
+		{
+			name:      "overdue",
+			wantKind:  notify.KindTaskOverdue,
+			wantTitle: `Task "Release" is overdue`,
+			wantBody:  "was due at Fri, 10 Jan 2025 13:30:00 UTC",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reminder.BeforeMinutes = tt.beforeMinutes
+			n := buildNotification(reminder, now)
+
+			if n.Kind != tt.wantKind || n.Title != tt.wantTitle {
+				t.Errorf("got %s %q, want %s %q", n.Kind, n.Title, tt.wantKind, tt.wantTitle)
+			}
+			if !strings.Contains(n.Body, tt.wantBody) {
+				t.Errorf("body %q does not contain %q", n.Body, tt.wantBody)
+			}
+			if n.UserID != "alice" || n.Email != "alice@example.org" || *n.BoardID != "board" || *n.TaskID != "task" {
+				t.Errorf("recipient or links: got %+v", n)
+			}
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidRule = errors.New("invalid rrule")
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+// maxPeriods bounds the search for the next occurrence, so rules that can
+// never match (e.g. BYMONTH=2;BYMONTHDAY=30) do not loop forever.
+const maxPeriods int = 10000
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Rule is the subset of an RFC 5545 RRULE supported by the scheduler:
+// FREQ, INTERVAL, COUNT, UNTIL, BYDAY (without ordinals), BYMONTHDAY and BYMONTH.
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      *time.Time
+	ByDay      []time.Weekday
+	ByMonthDay []int
+	ByMonth    []time.Month
+}
+
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return nil, fmt.Errorf("%w: empty rule", ErrInvalidRule)
+	}
+
+	rule := &Rule{Interval: 1}
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Freq = Frequency(strings.ToUpper(value))
+			switch rule.Freq {
+			case Daily, Weekly, Monthly, Yearly:
+			default:
+				err = fmt.Errorf("unsupported FREQ %q", value)
+			}
+		case "INTERVAL":
+			rule.Interval, err = parsePositive(value)
+		case "COUNT":
+			rule.Count, err = parsePositive(value)
+		case "UNTIL":
+			var until time.Time
+			until, err = parseUntil(value)
+			rule.Until = &until
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				wd, ok := weekdays[strings.ToUpper(day)]
+				if !ok {
+					err = fmt.Errorf("unsupported BYDAY value %q", day)
+					break
+				}
+				rule.ByDay = append(rule.ByDay, wd)
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(value, ",") {
+				n, convErr := strconv.Atoi(day)
+				if convErr != nil || n == 0 || n < -31 || n > 31 {
+					err = fmt.Errorf("bad BYMONTHDAY value %q", day)
+					break
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, n)
+			}
+		case "BYMONTH":
+			for _, month := range strings.Split(value, ",") {
+				n, convErr := strconv.Atoi(month)
+				if convErr != nil || n < 1 || n > 12 {
+					err = fmt.Errorf("bad BYMONTH value %q", month)
+					break
+				}
+				rule.ByMonth = append(rule.ByMonth, time.Month(n))
+			}
+		case "WKST":
+			if strings.ToUpper(value) != "MO" {
+				err = fmt.Errorf("only WKST=MO is supported")
+			}
+		default:
+			err = fmt.Errorf("unsupported part %q", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRule, err)
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return nil, fmt.Errorf("%w: COUNT and UNTIL are mutually exclusive", ErrInvalidRule)
+	}
+
+	return rule, nil
+}
+
+// Next returns the first occurrence strictly after `after` for a series
+// starting at dtstart, together with its 1-based index in the series.
+// The last return value is false when the series is exhausted.
+func (r *Rule) Next(dtstart, after time.Time) (time.Time, int, bool) {
+	index := 0
+	for period := 0; period < maxPeriods; period++ {
+		for _, candidate := range r.candidates(dtstart, period) {
+			if candidate.Before(dtstart) {
+				continue
+			}
+			index++
+			if r.Until != nil && candidate.After(*r.Until) {
+				return time.Time{}, index, false
+			}
+			if r.Count > 0 && index > r.Count {
+				return time.Time{}, index, false
+			}
+			if candidate.After(after) {
+				return candidate, index, true
+			}
+		}
+	}
+	return time.Time{}, index, false
+}
+
+func (r *Rule) candidates(dtstart time.Time, period int) []time.Time {
+	h, m, s := dtstart.Clock()
+	loc := dtstart.Location()
+	step := period * r.Interval
+
+	var days []time.Time
+	switch r.Freq {
+	case Daily:
+		y, mo, d := dtstart.Date()
+		days = []time.Time{time.Date(y, mo, d+step, h, m, s, 0, loc)}
+	case Weekly:
+		y, mo, d := dtstart.Date()
+		offset := (int(dtstart.Weekday()) + 6) % 7
+		monday := time.Date(y, mo, d-offset+7*step, h, m, s, 0, loc)
+		weekly := r.ByDay
+		if len(weekly) == 0 {
+			weekly = []time.Weekday{dtstart.Weekday()}
+		}
+		for _, wd := range weekly {
+			days = append(days, monday.AddDate(0, 0, (int(wd)+6)%7))
+		}
+	case Monthly:
+		first := time.Date(dtstart.Year(), dtstart.Month()+time.Month(step), 1, h, m, s, 0, loc)
+		days = r.daysInMonth(dtstart, first)
+	case Yearly:
+		year := dtstart.Year() + step
+		months := r.ByMonth
+		if len(months) == 0 {
+			months = []time.Month{dtstart.Month()}
+		}
+		for _, month := range months {
+			days = append(days, r.daysInMonth(dtstart, time.Date(year, month, 1, h, m, s, 0, loc))...)
+		}
+	}
+
+	var result []time.Time
+	for _, day := range days {
+		if r.matches(day) {
+			result = append(result, day)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Before(result[j]) })
+
+	return result
+}
+
+func (r *Rule) daysInMonth(dtstart, first time.Time) []time.Time {
+	last := first.AddDate(0, 1, -1).Day()
+
+	var days []time.Time
+	switch {
+	case len(r.ByMonthDay) > 0:
+		for _, n := range r.ByMonthDay {
+			if n < 0 {
+				n = last + n + 1
+			}
+			if n >= 1 && n <= last {
+				days = append(days, first.AddDate(0, 0, n-1))
+			}
+		}
+	case len(r.ByDay) > 0:
+		for n := 0; n < last; n++ {
+			days = append(days, first.AddDate(0, 0, n))
+		}
+	default:
+		if dtstart.Day() <= last {
+			days = append(days, first.AddDate(0, 0, dtstart.Day()-1))
+		}
+	}
+	return days
+}
+
+func (r *Rule) matches(day time.Time) bool {
+	if len(r.ByMonth) > 0 && !slices.Contains(r.ByMonth, day.Month()) {
+		return false
+	}
+	if len(r.ByDay) > 0 && !slices.Contains(r.ByDay, day.Weekday()) {
+		return false
+	}
+	if len(r.ByMonthDay) > 0 && (r.Freq == Daily || r.Freq == Weekly) {
+		last := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, day.Location()).Day()
+		found := false
+		for _, n := range r.ByMonthDay {
+			if n == day.Day() || last+n+1 == day.Day() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func parsePositive(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("bad positive integer %q", value)
+	}
+	return n, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			if layout == "20060102" {
+				t = t.Add(24*time.Hour - time.Second)
+			}
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("bad UNTIL value %q", value)
+}
@@ -0,0 +1,203 @@
+package rrule
+
+import (
+	"errors"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		wantErr bool
+	}{
+		{name: "prefixed", rule: "RRULE:FREQ=WEEKLY;BYDAY=MO,FR"},
+		{name: "lower case", rule: "freq=daily;interval=2"},
+		{name: "empty", rule: "", wantErr: true},
+		{name: "no FREQ", rule: "INTERVAL=2", wantErr: true},
+		{name: "hourly", rule: "FREQ=HOURLY", wantErr: true},
+		{name: "zero interval", rule: "FREQ=DAILY;INTERVAL=0", wantErr: true},
+		{name: "COUNT and UNTIL", rule: "FREQ=DAILY;COUNT=2;UNTIL=20250101", wantErr: true},
+		{name: "BYDAY ordinal", rule: "FREQ=MONTHLY;BYDAY=1MO", wantErr: true},
+		{name: "BYMONTHDAY zero", rule: "FREQ=MONTHLY;BYMONTHDAY=0", wantErr: true},
+		{name: "BYMONTHDAY out of range", rule: "FREQ=MONTHLY;BYMONTHDAY=32", wantErr: true},
+		{name: "BYMONTH out of range", rule: "FREQ=YEARLY;BYMONTH=13", wantErr: true},
+		{name: "WKST other than MO", rule: "FREQ=WEEKLY;WKST=SU", wantErr: true},
+		{name: "malformed part", rule: "FREQ=DAILY;COUNT", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.rule)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("error: got %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidRule) {
+				t.Errorf("error %v is not ErrInvalidRule", err)
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	utc := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 9, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		limit   int
+		want    []time.Time
+	}{
+		{
+			name:    "monthly on the 31st skips short months",
+			rule:    "FREQ=MONTHLY",
+			dtstart: utc(2025, time.January, 31),
+			limit:   4,
+			want:    []time.Time{utc(2025, time.January, 31), utc(2025, time.March, 31), utc(2025, time.May, 31), utc(2025, time.July, 31)},
+		},
+		{
+			name:    "last day of the month",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=-1",
+			dtstart: utc(2024, time.January, 31),
+			limit:   4,
+			want:    []time.Time{utc(2024, time.January, 31), utc(2024, time.February, 29), utc(2024, time.March, 31), utc(2024, time.April, 30)},
+		},
+		{
+			name:    "30th and last day do not repeat in February",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=30,-1",
+			dtstart: utc(2025, time.January, 30),
+			limit:   4,
+			want:    []time.Time{utc(2025, time.January, 30), utc(2025, time.January, 31), utc(2025, time.February, 28), utc(2025, time.March, 30)},
+		},
+		{
+			name:    "leap day every leap year",
+			rule:    "FREQ=YEARLY",
+			dtstart: utc(2024, time.February, 29),
+			limit:   2,
+			want:    []time.Time{utc(2024, time.February, 29), utc(2028, time.February, 29)},
+		},
+		{
+			name:    "impossible date ends the series",
+			rule:    "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30",
+			dtstart: utc(2025, time.January, 1),
+			limit:   1,
+			want:    nil,
+		},
+		{
+			name:    "weekly on several days",
+			rule:    "FREQ=WEEKLY;BYDAY=MO,WE,FR",
+			dtstart: utc(2025, time.January, 1),
+			limit:   4,
+			want:    []time.Time{utc(2025, time.January, 1), utc(2025, time.January, 3), utc(2025, time.January, 6), utc(2025, time.January, 8)},
+		},
+		{
+			name:    "every other week starts from the week of dtstart",
+			rule:    "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH",
+			dtstart: utc(2025, time.January, 6),
+			limit:   4,
+			want:    []time.Time{utc(2025, time.January, 7), utc(2025, time.January, 9), utc(2025, time.January, 21), utc(2025, time.January, 23)},
+		},
+		{
+			name:    "weekdays across a month",
+			rule:    "FREQ=MONTHLY;BYDAY=FR",
+			dtstart: utc(2025, time.January, 1),
+			limit:   6,
+			want: []time.Time{
+				utc(2025, time.January, 3), utc(2025, time.January, 10), utc(2025, time.January, 17),
+				utc(2025, time.January, 24), utc(2025, time.January, 31), utc(2025, time.February, 7),
+			},
+		},
+		{
+			name:    "Friday the 13th",
+			rule:    "FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13",
+			dtstart: utc(2025, time.January, 1),
+			limit:   3,
+			want:    []time.Time{utc(2025, time.June, 13), utc(2026, time.February, 13), utc(2026, time.March, 13)},
+		},
+		{
+			name:    "COUNT",
+			rule:    "FREQ=DAILY;COUNT=3",
+			dtstart: utc(2025, time.January, 1),
+			limit:   10,
+			want:    []time.Time{utc(2025, time.January, 1), utc(2025, time.January, 2), utc(2025, time.January, 3)},
+		},
+		{
+			name:    "COUNT with an interval",
+			rule:    "FREQ=WEEKLY;INTERVAL=2;COUNT=2",
+			dtstart: utc(2025, time.January, 1),
+			limit:   10,
+			want:    []time.Time{utc(2025, time.January, 1), utc(2025, time.January, 15)},
+		},
+		{
+			name:    "UNTIL is inclusive",
+			rule:    "FREQ=DAILY;UNTIL=20250103T090000Z",
+			dtstart: utc(2025, time.January, 1),
+			limit:   10,
+			want:    []time.Time{utc(2025, time.January, 1), utc(2025, time.January, 2), utc(2025, time.January, 3)},
+		},
+		{
+			name:    "UNTIL date covers the whole day",
+			rule:    "FREQ=DAILY;UNTIL=20250103",
+			dtstart: utc(2025, time.January, 1),
+			limit:   10,
+			want:    []time.Time{utc(2025, time.January, 1), utc(2025, time.January, 2), utc(2025, time.January, 3)},
+		},
+		{
+			name:    "UNTIL before dtstart",
+			rule:    "FREQ=DAILY;UNTIL=20241231",
+			dtstart: utc(2025, time.January, 1),
+			limit:   10,
+			want:    nil,
+		},
+		{
+			name:    "wall clock kept over a DST change",
+			rule:    "FREQ=DAILY;COUNT=3",
+			dtstart: time.Date(2025, time.March, 29, 9, 0, 0, 0, berlin),
+			limit:   10,
+			want: []time.Time{
+				time.Date(2025, time.March, 29, 9, 0, 0, 0, berlin),
+				time.Date(2025, time.March, 30, 9, 0, 0, 0, berlin),
+				time.Date(2025, time.March, 31, 9, 0, 0, 0, berlin),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+
+			var got []time.Time
+			after := tt.dtstart.Add(-time.Second)
+			for len(got) < tt.limit {
+				next, index, ok := rule.Next(tt.dtstart, after)
+				if !ok {
+					break
+				}
+				if index != len(got)+1 {
+					t.Errorf("index of %s: got %d, want %d", next, index, len(got)+1)
+				}
+				got = append(got, next)
+				after = next
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("occurrences: got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("occurrence %d: got %s, want %s", i+1, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
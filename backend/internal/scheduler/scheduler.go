@@ -0,0 +1,38 @@
+package scheduler
+
+import (
+	"kanban/internal/utils"
+	"log"
+	"time"
+)
+
+// Job processes whatever is due at now and reports how many items it handled.
+type Job func(now time.Time) (int, error)
+
+type Scheduler struct {
+	name     string
+	interval time.Duration
+	job      Job
+}
+
+func New(name string, interval time.Duration, job Job) *Scheduler {
+	return &Scheduler{name: name, interval: interval, job: job}
+}
+
+func (s *Scheduler) Start() {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			processed, err := s.job(utils.GenerateTimestamp())
+			if err != nil {
+				log.Printf("%s scheduler failed: %v", s.name, err)
+				continue
+			}
+			if processed > 0 {
+				log.Printf("%s scheduler processed %d items", s.name, processed)
+			}
+		}
+	}()
+}
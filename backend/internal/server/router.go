@@ -2,11 +2,27 @@ package server
 
 import (
 	"database/sql"
+	"kanban/internal/accesstoken"
+	"kanban/internal/account"
+	"kanban/internal/activity"
 	"kanban/internal/auth"
-	authMiddleware "kanban/internal/auth/middleware"
+	"kanban/internal/batch"
 	"kanban/internal/board"
+	"kanban/internal/boardimport"
+	"kanban/internal/boardsync"
 	"kanban/internal/column"
+	"kanban/internal/config"
+	"kanban/internal/export"
+	"kanban/internal/idempotency"
+	"kanban/internal/invitation"
+	"kanban/internal/notification"
+	"kanban/internal/recurrence"
+	"kanban/internal/reminder"
+	"kanban/internal/scheduler"
+	"kanban/internal/share"
 	"kanban/internal/task"
+	"kanban/internal/workspace"
+	"log"
 
 	"github.com/gin-gonic/gin"
 )
@@ -16,24 +32,48 @@ type Server struct {
 	engine *gin.Engine
 }
 
-func New(host string) *Server {
+func New(host string, trustedProxies []string) *Server {
 	s := &Server{
 		host:   host,
 		engine: gin.New(),
 	}
 
+	if err := s.engine.SetTrustedProxies(trustedProxies); err != nil {
+		log.Fatalf("Invalid trusted proxies: %v", err)
+	}
+
 	return s
 }
 
 func (r *Server) NewAPI(db *sql.DB) {
 	authGroup := r.engine.Group("/auth")
-	protectedGroup := r.engine.Group("/", authMiddleware.Middleware())
+	wellKnownGroup := r.engine.Group("/.well-known")
+	publicGroup := r.engine.Group("/")
+
+	// Retries of writes with an Idempotency-Key are answered from the
+	// store, so it has to run after authentication.
+	idempotencyStore := idempotency.NewPostgresStore(db)
+	scheduler.New("Idempotency key cleanup", config.Get().SchedulerInterval, idempotencyStore.DeleteExpired).Start()
+	protectedGroup := r.engine.Group("/", auth.Middleware(db), idempotency.Middleware(idempotencyStore, config.Get().IdempotencyTTL))
 
-	auth.Init(db, authGroup)
+	auth.Init(db, authGroup, wellKnownGroup)
 
+	account.Init(db, protectedGroup)
+	accesstoken.Init(db, protectedGroup)
+	workspace.Init(db, protectedGroup)
 	board.Init(db, protectedGroup)
+	invitation.Init(db, protectedGroup, publicGroup)
+	share.Init(db, protectedGroup, publicGroup)
+	export.Init(db, protectedGroup)
+	boardimport.Init(db, protectedGroup)
 	column.Init(db, protectedGroup)
 	task.Init(db, protectedGroup)
+	batch.Init(db, protectedGroup)
+	boardsync.Init(db, protectedGroup)
+	activity.Init(db, protectedGroup)
+	recurrence.Init(db, protectedGroup)
+	reminder.Init(db, protectedGroup)
+	notification.Init(db, protectedGroup)
 }
 
 func (r *Server) Start() {
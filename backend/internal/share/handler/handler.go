@@ -0,0 +1,164 @@
+package shareHandler
+
+import (
+	"errors"
+	authctx "kanban/internal/auth/context"
+	"kanban/internal/idempotency"
+	shareModel "kanban/internal/share/model"
+	shareProxy "kanban/internal/share/proxy"
+	shareService "kanban/internal/share/service"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Proxy interface {
+	CreateShare(boardID, userID string, req shareModel.CreateRequest) (*shareModel.CreateResponse, error)
+	GetShares(boardID, userID string) ([]shareModel.Share, error)
+	GetShare(boardID, shareID, userID string) (*shareModel.Share, error)
+	RevokeShare(boardID, shareID, userID string) error
+	GetSnapshot(token string) (*shareModel.Snapshot, error)
+}
+
+type Handler struct {
+	proxy Proxy
+}
+
+func NewHandler(proxy Proxy) *Handler {
+	return &Handler{proxy: proxy}
+}
+
+func (h *Handler) CreateShareHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req shareModel.CreateRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid request body",
+			})
+			return
+		}
+
+		boardID := ctx.Param("id")
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		// The token is shown once, a retry gets the link without it.
+		if status, shareID, ok := idempotency.Replayed(ctx); ok {
+			share, err := h.proxy.GetShare(boardID, shareID, userID)
+			if err != nil {
+				log.Printf("Failed to get share link: %v", err)
+				h.handleError(ctx, err, "Failed to get share link")
+				return
+			}
+
+			ctx.JSON(status, share)
+			return
+		}
+
+		share, err := h.proxy.CreateShare(boardID, userID, req)
+		if err != nil {
+			log.Printf("Failed to create share link: %v", err)
+			h.handleError(ctx, err, "Failed to create share link")
+			return
+		}
+
+		idempotency.SetResource(ctx, share.ID)
+		ctx.JSON(http.StatusCreated, share)
+	}
+}
+
+func (h *Handler) GetSharesHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		boardID := ctx.Param("id")
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		shares, err := h.proxy.GetShares(boardID, userID)
+		if err != nil {
+			log.Printf("Failed to get share links: %v", err)
+			h.handleError(ctx, err, "Failed to get share links")
+			return
+		}
+
+		ctx.JSON(http.StatusOK, shares)
+	}
+}
+
+func (h *Handler) RevokeShareHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		boardID := ctx.Param("id")
+		shareID := ctx.Param("shareID")
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		if err := h.proxy.RevokeShare(boardID, shareID, userID); err != nil {
+			log.Printf("Failed to revoke share link: %v", err)
+			h.handleError(ctx, err, "Failed to revoke share link")
+			return
+		}
+
+		ctx.Status(http.StatusOK)
+	}
+}
+
+// GetSnapshotHandler serves a shared board without authentication. The
+// response is not cached, so revoking a link takes effect at once.
+func (h *Handler) GetSnapshotHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		token := ctx.Param("token")
+
+		snapshot, err := h.proxy.GetSnapshot(token)
+		if err != nil {
+			log.Printf("Failed to get shared board: %v", err)
+			h.handleError(ctx, err, "Failed to get board")
+			return
+		}
+
+		ctx.Header("Cache-Control", "no-store")
+		ctx.JSON(http.StatusOK, snapshot)
+	}
+}
+
+func (h *Handler) handleError(ctx *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, shareProxy.ErrForbidden):
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"detail": "Access denied",
+		})
+	case errors.Is(err, shareService.ErrBoardNotFound):
+		ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+			"detail": "Board not found",
+		})
+	case errors.Is(err, shareService.ErrShareNotFound):
+		ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+			"detail": "Share link not found",
+		})
+	case errors.Is(err, shareService.ErrExpiresInPast):
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"detail": "Expiry is in the past",
+		})
+	default:
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"detail": message,
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package shareModel
+
+import "time"
+
+type Share struct {
+	ID                 string     `json:"id"`
+	BoardID            string     `json:"board_id"`
+	CreatedBy          *string    `json:"created_by"`
+	CreatedAt          time.Time  `json:"created_at"`
+	ExpiresAt          *time.Time `json:"expires_at"`
+	RedactDescriptions bool       `json:"redact_descriptions"`
+}
+
+type CreateRequest struct {
+	ExpiresAt          *time.Time `json:"expires_at"`
+	RedactDescriptions bool       `json:"redact_descriptions"`
+}
+
+// CreateResponse carries the token itself, which is only stored hashed and
+// cannot be shown again.
+type CreateResponse struct {
+	Share
+	Token string `json:"token"`
+}
+
+// Snapshot is the read-only view of a board served by a share link. It
+// leaves out who owns the board and where it lives.
+type Snapshot struct {
+	ID                   string           `json:"id"`
+	CreatedAt            time.Time        `json:"created_at"`
+	UpdatedAt            time.Time        `json:"updated_at"`
+	Name                 string           `json:"name"`
+	DescriptionsRedacted bool             `json:"descriptions_redacted"`
+	Columns              []SnapshotColumn `json:"columns"`
+}
+
+type SnapshotColumn struct {
+	ID       string         `json:"id"`
+	Name     string         `json:"name"`
+	Position int            `json:"position"`
+	Flag     *string        `json:"flag"`
+	Tasks    []SnapshotTask `json:"tasks"`
+}
+
+type SnapshotTask struct {
+	ID          string     `json:"id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	Name        string     `json:"name"`
+	Description *string    `json:"description,omitempty"`
+	Position    int        `json:"position"`
+	Done        bool       `json:"done"`
+	Deadline    *time.Time `json:"deadline"`
+	BlockedBy   []string   `json:"blocked_by"`
+	Blocks      []string   `json:"blocks"`
+}
@@ -0,0 +1,76 @@
+package shareProxy
+
+import (
+	"errors"
+	"fmt"
+	shareModel "kanban/internal/share/model"
+)
+
+var ErrForbidden = errors.New("access denied")
+
+type Service interface {
+	CreateShare(boardID, userID string, req shareModel.CreateRequest) (*shareModel.CreateResponse, error)
+	GetShares(boardID string) ([]shareModel.Share, error)
+	GetShare(boardID, shareID string) (*shareModel.Share, error)
+	RevokeShare(boardID, shareID string) error
+	GetSnapshot(token string) (*shareModel.Snapshot, error)
+	CanShareBoard(boardID, userID string) (bool, error)
+}
+
+type Proxy struct {
+	service Service
+}
+
+func NewProxy(service Service) *Proxy {
+	return &Proxy{service: service}
+}
+
+func (p *Proxy) CreateShare(boardID, userID string, req shareModel.CreateRequest) (*shareModel.CreateResponse, error) {
+	if err := p.checkAdmin(boardID, userID); err != nil {
+		return nil, fmt.Errorf("shareProxy.CreateShare: %w", err)
+	}
+
+	return p.service.CreateShare(boardID, userID, req)
+}
+
+func (p *Proxy) GetShares(boardID, userID string) ([]shareModel.Share, error) {
+	if err := p.checkAdmin(boardID, userID); err != nil {
+		return nil, fmt.Errorf("shareProxy.GetShares: %w", err)
+	}
+
+	return p.service.GetShares(boardID)
+}
+
+func (p *Proxy) GetShare(boardID, shareID, userID string) (*shareModel.Share, error) {
+	if err := p.checkAdmin(boardID, userID); err != nil {
+		return nil, fmt.Errorf("shareProxy.GetShare: %w", err)
+	}
+
+	return p.service.GetShare(boardID, shareID)
+}
+
+func (p *Proxy) RevokeShare(boardID, shareID, userID string) error {
+	if err := p.checkAdmin(boardID, userID); err != nil {
+		return fmt.Errorf("shareProxy.RevokeShare: %w", err)
+	}
+
+	return p.service.RevokeShare(boardID, shareID)
+}
+
+// GetSnapshot is public, the token is the only credential.
+func (p *Proxy) GetSnapshot(token string) (*shareModel.Snapshot, error) {
+	return p.service.GetSnapshot(token)
+}
+
+// checkAdmin lets those who can manage the board publish it, as well as
+// users invited to the board as admins, see CanShareBoard.
+func (p *Proxy) checkAdmin(boardID, userID string) error {
+	canShare, err := p.service.CanShareBoard(boardID, userID)
+	if err != nil {
+		return fmt.Errorf("shareProxy.checkAdmin: %w", err)
+	}
+	if !canShare {
+		return ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package shareRepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"kanban/internal/postgres"
+	shareModel "kanban/internal/share/model"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Create(tokenHash string, share shareModel.Share) error {
+	_, err := r.db.Exec(
+		postgres.QueryCreateShare,
+		share.ID,
+		share.BoardID,
+		tokenHash,
+		share.CreatedBy,
+		share.CreatedAt,
+		share.ExpiresAt,
+		share.RedactDescriptions,
+	)
+	if err != nil {
+		return fmt.Errorf("shareRepo.Create: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) GetByBoard(boardID string) ([]shareModel.Share, error) {
+	rows, err := r.db.Query(postgres.QueryGetBoardShares, boardID)
+	if err != nil {
+		return nil, fmt.Errorf("shareRepo.GetByBoard: %w", err)
+	}
+	defer rows.Close()
+
+	shares := []shareModel.Share{}
+	for rows.Next() {
+		var share shareModel.Share
+		if err := rows.Scan(
+			&share.ID,
+			&share.BoardID,
+			&share.CreatedBy,
+			&share.CreatedAt,
+			&share.ExpiresAt,
+			&share.RedactDescriptions,
+		); err != nil {
+			return nil, fmt.Errorf("shareRepo.GetByBoard: %w", err)
+		}
+		shares = append(shares, share)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("shareRepo.GetByBoard: %w", err)
+	}
+	return shares, nil
+}
+
+func (r *Repository) Get(boardID, shareID string) (*shareModel.Share, error) {
+	var share shareModel.Share
+	err := r.db.QueryRow(postgres.QueryGetShare, shareID, boardID).Scan(
+		&share.ID,
+		&share.BoardID,
+		&share.CreatedBy,
+		&share.CreatedAt,
+		&share.ExpiresAt,
+		&share.RedactDescriptions,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("shareRepo.Get: %w", err)
+	}
+	return &share, nil
+}
+
+func (r *Repository) Delete(boardID, shareID string) error {
+	res, err := r.db.Exec(postgres.QueryDeleteShare, shareID, boardID)
+	if err != nil {
+		return fmt.Errorf("shareRepo.Delete: %w", err)
+	}
+	return checkAffected("shareRepo.Delete", res)
+}
+
+// GetSnapshot reads the shared board with its columns and tasks. It runs
+// in a read-only transaction, so the public route cannot write even by
+// mistake, and sees the board at a single point in time.
+func (r *Repository) GetSnapshot(tokenHash string, now time.Time) (*shareModel.Snapshot, error) {
+	tx, err := r.db.BeginTx(context.Background(), &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("shareRepo.GetSnapshot: %w", err)
+	}
+	defer tx.Rollback()
+
+	var snapshot shareModel.Snapshot
+	err = tx.QueryRow(postgres.QueryGetSharedBoard, tokenHash, now).Scan(
+		&snapshot.ID,
+		&snapshot.CreatedAt,
+		&snapshot.UpdatedAt,
+		&snapshot.Name,
+		&snapshot.DescriptionsRedacted,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("shareRepo.GetSnapshot: %w", err)
+	}
+
+	snapshot.Columns, err = getColumns(tx, snapshot.ID)
+	if err != nil {
+		return nil, fmt.Errorf("shareRepo.GetSnapshot: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("shareRepo.GetSnapshot: %w", err)
+	}
+	return &snapshot, nil
+}
+
+func getColumns(tx *sql.Tx, boardID string) ([]shareModel.SnapshotColumn, error) {
+	rows, err := tx.Query(postgres.QueryGetAllColumns, boardID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := []shareModel.SnapshotColumn{}
+	index := map[string]int{}
+	for rows.Next() {
+		var column shareModel.SnapshotColumn
+		var columnBoardID string
+		var createdAt, updatedAt time.Time
+		if err = rows.Scan(
+			&column.ID,
+			&columnBoardID,
+			&createdAt,
+			&updatedAt,
+			&column.Name,
+			&column.Position,
+			&column.Flag,
+		); err != nil {
+			return nil, err
+		}
+		column.Tasks = []shareModel.SnapshotTask{}
+		index[column.ID] = len(columns)
+		columns = append(columns, column)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	taskRows, err := tx.Query(postgres.QueryGetBoardTasks, boardID)
+	if err != nil {
+		return nil, err
+	}
+	defer taskRows.Close()
+
+	for taskRows.Next() {
+		var task shareModel.SnapshotTask
+		var columnID, description string
+		if err = taskRows.Scan(
+			&task.ID,
+			&columnID,
+			&task.CreatedAt,
+			&task.UpdatedAt,
+			&task.Name,
+			&description,
+			&task.Position,
+			&task.Done,
+			&task.Deadline,
+			pq.Array(&task.BlockedBy),
+			pq.Array(&task.Blocks),
+		); err != nil {
+			return nil, err
+		}
+		task.Description = &description
+		i := index[columnID]
+		columns[i].Tasks = append(columns[i].Tasks, task)
+	}
+	if err = taskRows.Err(); err != nil {
+		return nil, err
+	}
+
+	return columns, nil
+}
+
+func checkAffected(op string, res sql.Result) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, sql.ErrNoRows)
+	}
+	return nil
+}
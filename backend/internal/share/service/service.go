@@ -0,0 +1,133 @@
+package shareService
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	shareModel "kanban/internal/share/model"
+	"kanban/internal/utils"
+	"time"
+)
+
+var ErrBoardNotFound = errors.New("board not found")
+var ErrShareNotFound = errors.New("share link not found")
+var ErrExpiresInPast = errors.New("expiry is in the past")
+
+type Repository interface {
+	Create(tokenHash string, share shareModel.Share) error
+	GetByBoard(boardID string) ([]shareModel.Share, error)
+	Get(boardID, shareID string) (*shareModel.Share, error)
+	Delete(boardID, shareID string) error
+	GetSnapshot(tokenHash string, now time.Time) (*shareModel.Snapshot, error)
+}
+
+// Access is the lookup of board permissions shared with other packages,
+// implemented by the workspace repository.
+type Access interface {
+	CanShareBoard(boardID, userID string) (bool, error)
+}
+
+type Service struct {
+	repo   Repository
+	access Access
+}
+
+func NewService(repo Repository, access Access) *Service {
+	return &Service{repo: repo, access: access}
+}
+
+func (s *Service) CreateShare(boardID, userID string, req shareModel.CreateRequest) (*shareModel.CreateResponse, error) {
+	now := utils.GenerateTimestamp()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, fmt.Errorf("shareService.CreateShare: %w", ErrExpiresInPast)
+	}
+
+	token, err := utils.GenerateToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("shareService.CreateShare: %w", err)
+	}
+
+	share := shareModel.Share{
+		ID:                 utils.NewUUID(),
+		BoardID:            boardID,
+		CreatedBy:          &userID,
+		CreatedAt:          now,
+		ExpiresAt:          req.ExpiresAt,
+		RedactDescriptions: req.RedactDescriptions,
+	}
+
+	if err = s.repo.Create(utils.HashToken(token), share); err != nil {
+		return nil, fmt.Errorf("shareService.CreateShare: %w", err)
+	}
+
+	return &shareModel.CreateResponse{Share: share, Token: token}, nil
+}
+
+func (s *Service) GetShares(boardID string) ([]shareModel.Share, error) {
+	shares, err := s.repo.GetByBoard(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("shareService.GetShares: %w", err)
+	}
+
+	return shares, nil
+}
+
+// GetShare returns a share link of the board without its token.
+func (s *Service) GetShare(boardID, shareID string) (*shareModel.Share, error) {
+	share, err := s.repo.Get(boardID, shareID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("shareService.GetShare: %w", ErrShareNotFound)
+		}
+		return nil, fmt.Errorf("shareService.GetShare: %w", err)
+	}
+
+	return share, nil
+}
+
+func (s *Service) RevokeShare(boardID, shareID string) error {
+	if err := s.repo.Delete(boardID, shareID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("shareService.RevokeShare: %w", ErrShareNotFound)
+		}
+		return fmt.Errorf("shareService.RevokeShare: %w", err)
+	}
+
+	return nil
+}
+
+// GetSnapshot returns the board behind a share token. Unknown, revoked and
+// expired tokens are all reported as ErrShareNotFound.
+func (s *Service) GetSnapshot(token string) (*shareModel.Snapshot, error) {
+	snapshot, err := s.repo.GetSnapshot(utils.HashToken(token), utils.GenerateTimestamp())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("shareService.GetSnapshot: %w", ErrShareNotFound)
+		}
+		return nil, fmt.Errorf("shareService.GetSnapshot: %w", err)
+	}
+
+	if snapshot.DescriptionsRedacted {
+		for i := range snapshot.Columns {
+			for j := range snapshot.Columns[i].Tasks {
+				snapshot.Columns[i].Tasks[j].Description = nil
+			}
+		}
+	}
+
+	return snapshot, nil
+}
+
+// CanShareBoard reports whether the user may invite others to the board and
+// publish it.
+func (s *Service) CanShareBoard(boardID, userID string) (bool, error) {
+	canShare, err := s.access.CanShareBoard(boardID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, fmt.Errorf("shareService.CanShareBoard: %w", ErrBoardNotFound)
+		}
+		return false, fmt.Errorf("shareService.CanShareBoard: %w", err)
+	}
+
+	return canShare, nil
+}
@@ -0,0 +1,27 @@
+package share
+
+import (
+	"database/sql"
+	shareHandler "kanban/internal/share/handler"
+	shareProxy "kanban/internal/share/proxy"
+	shareRepo "kanban/internal/share/repo"
+	shareService "kanban/internal/share/service"
+	workspaceRepo "kanban/internal/workspace/repo"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Init registers the share link routes. Only reads are served without
+// authentication, never add a mutating route under /public.
+func Init(db *sql.DB, grp *gin.RouterGroup, public *gin.RouterGroup) {
+	repo := shareRepo.NewRepository(db)
+	service := shareService.NewService(repo, workspaceRepo.NewRepository(db))
+	proxy := shareProxy.NewProxy(service)
+	handler := shareHandler.NewHandler(proxy)
+
+	grp.POST("/boards/:id/shares", handler.CreateShareHandler())
+	grp.GET("/boards/:id/shares", handler.GetSharesHandler())
+	grp.DELETE("/boards/:id/shares/:shareID", handler.RevokeShareHandler())
+
+	public.GET("/public/boards/:token", handler.GetSnapshotHandler())
+}
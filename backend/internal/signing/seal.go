@@ -0,0 +1,87 @@
+package signing
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+var ErrUnknownSecret = errors.New("key is encrypted with an unknown secret")
+
+// sealer encrypts private keys at rest with AES-256-GCM. The first secret
+// encrypts, the others only decrypt keys stored before it was added. The
+// key ID is authenticated too, so a sealed key cannot be moved to another row.
+type sealer struct {
+	secrets []secret
+}
+
+type secret struct {
+	id   string
+	aead cipher.AEAD
+}
+
+func newSealer(secrets [][]byte) (*sealer, error) {
+	if len(secrets) == 0 {
+		return nil, errors.New("no secret to encrypt signing keys with")
+	}
+
+	s := &sealer{}
+	for _, raw := range secrets {
+		block, err := aes.NewCipher(raw)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		// The ID names the secret without revealing it.
+		sum := sha256.Sum256(raw)
+		s.secrets = append(s.secrets, secret{id: hex.EncodeToString(sum[:8]), aead: aead})
+	}
+	return s, nil
+}
+
+// current returns the ID of the secret new keys are encrypted with.
+func (s *sealer) current() string {
+	return s.secrets[0].id
+}
+
+// seal encrypts the key with the current secret and returns the secret's ID
+// with the nonce-prefixed ciphertext.
+func (s *sealer) seal(kid string, der []byte) (string, []byte, error) {
+	current := s.secrets[0]
+	nonce := make([]byte, current.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", nil, err
+	}
+	return current.id, current.aead.Seal(nonce, nonce, der, []byte(kid)), nil
+}
+
+// open decrypts a key sealed with any of the secrets. Keys stored before
+// encryption was added have no secret ID and are returned as they are.
+func (s *sealer) open(kid string, secretID *string, sealed []byte) ([]byte, error) {
+	if secretID == nil {
+		return sealed, nil
+	}
+
+	for _, secret := range s.secrets {
+		if secret.id != *secretID {
+			continue
+		}
+		size := secret.aead.NonceSize()
+		if len(sealed) < size {
+			return nil, fmt.Errorf("key %s: ciphertext too short", kid)
+		}
+		der, err := secret.aead.Open(nil, sealed[:size], sealed[size:], []byte(kid))
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", kid, err)
+		}
+		return der, nil
+	}
+	return nil, fmt.Errorf("key %s: %w", kid, ErrUnknownSecret)
+}
@@ -0,0 +1,56 @@
+package signing
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSealer(t *testing.T) {
+	oldSecret := bytes.Repeat([]byte{1}, 32)
+	newSecret := bytes.Repeat([]byte{2}, 32)
+	der := []byte("private key")
+
+	old, err := newSealer([][]byte{oldSecret})
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldID, sealed, err := old.seal("kid", der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		secrets  [][]byte
+		kid      string
+		secretID *string
+		sealed   []byte
+		wantErr  bool
+	}{
+		{name: "same secret", secrets: [][]byte{oldSecret}, kid: "kid", secretID: &oldID, sealed: sealed},
+		{name: "secret kept for decryption", secrets: [][]byte{newSecret, oldSecret}, kid: "kid", secretID: &oldID, sealed: sealed},
+		{name: "plain text key", secrets: [][]byte{newSecret}, kid: "kid", sealed: der},
+		{name: "secret dropped", secrets: [][]byte{newSecret}, kid: "kid", secretID: &oldID, sealed: sealed, wantErr: true},
+		{name: "moved to another key", secrets: [][]byte{oldSecret}, kid: "other", secretID: &oldID, sealed: sealed, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := newSealer(tt.secrets)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := s.open(tt.kid, tt.secretID, tt.sealed)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("opened")
+				}
+				return
+			}
+			if err != nil || !bytes.Equal(got, der) {
+				t.Fatalf("got %q, %v", got, err)
+			}
+		})
+	}
+}
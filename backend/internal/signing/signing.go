@@ -0,0 +1,403 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"database/sql"
+	"errors"
+	"fmt"
+	"kanban/internal/jwk"
+	"kanban/internal/postgres"
+	"kanban/internal/utils"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Supported signing algorithms.
+const (
+	RS256 = "RS256"
+	EdDSA = "EdDSA"
+)
+
+// Retention is how long a key stays valid for verification after it stops
+// signing. It has to outlast the longest-lived token we issue.
+const Retention = 72 * time.Hour
+
+// reloadInterval bounds how often an unknown kid makes the keyring reload
+// keys created by other instances.
+const reloadInterval = 10 * time.Second
+
+var ErrNoSigningKey = errors.New("no active signing key")
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// Key is a signing key pair. A key signs new tokens between ActivatesAt and
+// RetiresAt and verifies them until ExpiresAt.
+type Key struct {
+	ID          string
+	Algorithm   string
+	Private     crypto.Signer
+	ActivatesAt time.Time
+	RetiresAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// Keyring keeps the signing keys in Postgres, shared by all instances, and
+// rotates them on a schedule. New keys are published ahead of their
+// activation, so services caching the JWKS know them before they are used.
+// Private keys are stored encrypted with the configured secrets.
+type Keyring struct {
+	db        *sql.DB
+	algorithm string
+	rotation  time.Duration
+	sealer    *sealer
+
+	mu       sync.RWMutex
+	keys     []Key
+	loadedAt time.Time
+}
+
+var (
+	keyring *Keyring
+	once    sync.Once
+)
+
+// Load sets up the application keyring and makes sure a signing key exists.
+func Load(db *sql.DB, algorithm string, rotation time.Duration, secrets [][]byte) {
+	once.Do(func() {
+		var err error
+		keyring, err = New(db, algorithm, rotation, secrets)
+		if err != nil {
+			log.Fatalf("Failed to load signing keys: %v", err)
+		}
+		if _, err = keyring.Rotate(utils.GenerateTimestamp()); err != nil {
+			log.Fatalf("Failed to load signing keys: %v", err)
+		}
+	})
+}
+
+func Get() *Keyring {
+	if keyring == nil {
+		log.Fatal("signing keys not loaded: call signing.Load() first")
+	}
+	return keyring
+}
+
+func New(db *sql.DB, algorithm string, rotation time.Duration, secrets [][]byte) (*Keyring, error) {
+	sealer, err := newSealer(secrets)
+	if err != nil {
+		return nil, fmt.Errorf("signing.New: %w", err)
+	}
+	return &Keyring{db: db, algorithm: algorithm, rotation: rotation, sealer: sealer}, nil
+}
+
+// Rotate drops expired keys, re-encrypts keys stored with an older secret
+// and creates the next key once the current one is close to retirement. It
+// is meant to run on every instance from the scheduler and reports how many
+// keys it created, dropped or re-encrypted.
+func (k *Keyring) Rotate(now time.Time) (int, error) {
+	tx, err := k.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("signing.Rotate: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Instances rotate concurrently, the lock keeps them from creating a key each.
+	if _, err = tx.Exec(postgres.QueryLockSigningKeys); err != nil {
+		return 0, fmt.Errorf("signing.Rotate: %w", err)
+	}
+
+	res, err := tx.Exec(postgres.QueryDeleteExpiredSigningKeys, now)
+	if err != nil {
+		return 0, fmt.Errorf("signing.Rotate: %w", err)
+	}
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("signing.Rotate: %w", err)
+	}
+
+	resealed, err := k.reseal(tx)
+	if err != nil {
+		return 0, fmt.Errorf("signing.Rotate: %w", err)
+	}
+
+	var latest sql.NullTime
+	err = tx.QueryRow(postgres.QueryGetLatestSigningKeyRetirement, k.algorithm).Scan(&latest)
+	if err != nil {
+		return 0, fmt.Errorf("signing.Rotate: %w", err)
+	}
+
+	created := 0
+	switch {
+	case !latest.Valid || !latest.Time.After(now):
+		err = k.create(tx, now, now)
+		created++
+	case latest.Time.Sub(now) < k.prepublish():
+		err = k.create(tx, now, latest.Time)
+		created++
+	}
+	if err != nil {
+		return 0, fmt.Errorf("signing.Rotate: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("signing.Rotate: %w", err)
+	}
+
+	if err = k.reload(now); err != nil {
+		return 0, fmt.Errorf("signing.Rotate: %w", err)
+	}
+	return created + int(deleted) + resealed, nil
+}
+
+// Current returns the key new tokens are signed with.
+func (k *Keyring) Current() (*Key, error) {
+	now := utils.GenerateTimestamp()
+	if key := k.current(now); key != nil {
+		return key, nil
+	}
+
+	if err := k.reload(now); err != nil {
+		return nil, fmt.Errorf("signing.Current: %w", err)
+	}
+	if key := k.current(now); key != nil {
+		return key, nil
+	}
+	return nil, ErrNoSigningKey
+}
+
+// Keyfunc resolves the verification key of a token by its kid header and
+// checks that the token uses the key's algorithm.
+func (k *Keyring) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, ErrUnknownKey
+	}
+
+	now := utils.GenerateTimestamp()
+	key := k.find(kid, now)
+	if key == nil && k.stale(now) {
+		if err := k.reload(now); err != nil {
+			return nil, err
+		}
+		key = k.find(kid, now)
+	}
+	if key == nil {
+		return nil, ErrUnknownKey
+	}
+
+	if token.Method.Alg() != key.Algorithm {
+		return nil, fmt.Errorf("token algorithm %s does not match key %s", token.Method.Alg(), kid)
+	}
+	return key.Private.Public(), nil
+}
+
+// JWKS returns the public keys that are or will soon be in use.
+func (k *Keyring) JWKS() (jwk.Set, error) {
+	now := utils.GenerateTimestamp()
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	set := jwk.Set{Keys: []jwk.Key{}}
+	for _, key := range k.keys {
+		if !key.ExpiresAt.After(now) {
+			continue
+		}
+		public, err := jwk.FromPublicKey(key.ID, key.Algorithm, key.Private.Public())
+		if err != nil {
+			return jwk.Set{}, fmt.Errorf("signing.JWKS: %w", err)
+		}
+		set.Keys = append(set.Keys, public)
+	}
+	return set, nil
+}
+
+func (k *Keyring) current(now time.Time) *Key {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	var current *Key
+	for i := range k.keys {
+		key := &k.keys[i]
+		if key.Algorithm != k.algorithm || key.ActivatesAt.After(now) || !key.RetiresAt.After(now) {
+			continue
+		}
+		if current == nil || key.ActivatesAt.After(current.ActivatesAt) {
+			current = key
+		}
+	}
+	return current
+}
+
+func (k *Keyring) find(kid string, now time.Time) *Key {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	for i := range k.keys {
+		if k.keys[i].ID == kid && k.keys[i].ExpiresAt.After(now) {
+			return &k.keys[i]
+		}
+	}
+	return nil
+}
+
+func (k *Keyring) stale(now time.Time) bool {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return now.Sub(k.loadedAt) >= reloadInterval
+}
+
+func (k *Keyring) reload(now time.Time) error {
+	rows, err := k.db.Query(postgres.QueryGetSigningKeys, now)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var keys []Key
+	for rows.Next() {
+		var key Key
+		var secretID *string
+		var sealed []byte
+		err = rows.Scan(&key.ID, &key.Algorithm, &secretID, &sealed, &key.ActivatesAt, &key.RetiresAt, &key.ExpiresAt)
+		if err != nil {
+			return err
+		}
+
+		der, err := k.sealer.open(key.ID, secretID, sealed)
+		if errors.Is(err, ErrUnknownSecret) {
+			// Another instance already has a newer secret, this one keeps
+			// using the keys it can read.
+			log.Printf("Skipping signing key: %v", err)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		private, err := x509.ParsePKCS8PrivateKey(der)
+		if err != nil {
+			return fmt.Errorf("key %s: %w", key.ID, err)
+		}
+		signer, ok := private.(crypto.Signer)
+		if !ok {
+			return fmt.Errorf("key %s: unsupported key type %T", key.ID, private)
+		}
+		key.Private = signer
+		keys = append(keys, key)
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	k.mu.Lock()
+	k.keys = keys
+	k.loadedAt = now
+	k.mu.Unlock()
+	return nil
+}
+
+func (k *Keyring) create(tx *sql.Tx, now, activatesAt time.Time) error {
+	var private crypto.Signer
+	var err error
+	switch k.algorithm {
+	case RS256:
+		private, err = rsa.GenerateKey(rand.Reader, 2048)
+	case EdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		err = fmt.Errorf("unsupported algorithm %q", k.algorithm)
+	}
+	if err != nil {
+		return err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return err
+	}
+
+	kid, err := utils.GenerateToken(16)
+	if err != nil {
+		return err
+	}
+
+	secretID, sealed, err := k.sealer.seal(kid, der)
+	if err != nil {
+		return err
+	}
+
+	retiresAt := activatesAt.Add(k.rotation)
+	_, err = tx.Exec(
+		postgres.QueryCreateSigningKey,
+		kid,
+		k.algorithm,
+		secretID,
+		sealed,
+		now,
+		activatesAt,
+		retiresAt,
+		retiresAt.Add(Retention),
+	)
+	return err
+}
+
+// reseal encrypts keys stored in plain text or with an older secret with
+// the current one, so the older secret can be dropped afterwards.
+func (k *Keyring) reseal(tx *sql.Tx) (int, error) {
+	rows, err := tx.Query(postgres.QueryGetSigningKeysToReseal, k.sealer.current())
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	type stored struct {
+		kid      string
+		secretID *string
+		sealed   []byte
+	}
+	var keys []stored
+	for rows.Next() {
+		var key stored
+		if err = rows.Scan(&key.kid, &key.secretID, &key.sealed); err != nil {
+			return 0, err
+		}
+		keys = append(keys, key)
+	}
+	if err = rows.Err(); err != nil {
+		return 0, err
+	}
+	rows.Close()
+
+	resealed := 0
+	for _, key := range keys {
+		der, err := k.sealer.open(key.kid, key.secretID, key.sealed)
+		if errors.Is(err, ErrUnknownSecret) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		secretID, sealed, err := k.sealer.seal(key.kid, der)
+		if err != nil {
+			return 0, err
+		}
+		if _, err = tx.Exec(postgres.QueryResealSigningKey, secretID, sealed, key.kid); err != nil {
+			return 0, err
+		}
+		resealed++
+	}
+	return resealed, nil
+}
+
+// prepublish is how long before activation a new key shows up in the JWKS.
+func (k *Keyring) prepublish() time.Duration {
+	return min(time.Hour, k.rotation/2)
+}
@@ -2,7 +2,9 @@ package taskHandler
 
 import (
 	"errors"
+	"io"
 	authctx "kanban/internal/auth/context"
+	"kanban/internal/config"
 	taskModel "kanban/internal/task/model"
 	taskProxy "kanban/internal/task/proxy"
 	taskRepo "kanban/internal/task/repo"
@@ -14,11 +16,15 @@ import (
 )
 
 type Proxy interface {
-	CreateTask(columnID, userID string, req taskModel.CreateRequest) error
+	CreateTask(columnID, userID string, req taskModel.CreateRequest) (*taskModel.Task, error)
+	ImportTasks(columnID, userID string, query taskModel.ImportQuery, r io.Reader) (*taskModel.ImportResult, error)
 	GetAllTasks(columnID, userID string) ([]taskModel.Task, error)
 	GetTask(taskID, userID string) (*taskModel.Task, error)
-	UpdateTask(taskID, userID string, req taskModel.UpdateRequest) error
+	UpdateTask(taskID, userID string, req taskModel.UpdateRequest) (*taskModel.Task, error)
 	DeleteTask(taskID, userID string) error
+	AddDependency(taskID, blockerID, userID string) (*taskModel.Task, error)
+	DeleteDependency(taskID, blockerID, userID string) error
+	BulkUpdateTasks(userID string, req taskModel.BulkRequest) (*taskModel.BulkResult, error)
 }
 
 type Handler struct {
@@ -49,14 +55,54 @@ func (h *Handler) CreateTaskHandler() gin.HandlerFunc {
 
 		columnID := ctx.Param("id")
 
-		err := h.proxy.CreateTask(columnID, userID, req); 
+		task, err := h.proxy.CreateTask(columnID, userID, req)
 		if err != nil {
 			log.Printf("Failed to create task: %v", err)
 			h.handleError(ctx, err, "Failed to create task")
 			return
 		} 
 
-		ctx.Status(http.StatusCreated)
+		ctx.Header("Location", config.Get().APIPrefix+"/tasks/"+task.ID)
+		ctx.JSON(http.StatusCreated, task)
+	}
+}
+
+// maxImportSize bounds the uploaded CSV, a full column fits many times.
+const maxImportSize = 1 << 20
+
+func (h *Handler) ImportTasksHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var query taskModel.ImportQuery
+		if err := ctx.ShouldBindQuery(&query); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid query parameters",
+			})
+			return
+		}
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		columnID := ctx.Param("id")
+
+		body := http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImportSize)
+		result, err := h.proxy.ImportTasks(columnID, userID, query, body)
+		if err != nil {
+			log.Printf("Failed to import tasks: %v", err)
+			h.handleError(ctx, err, "Failed to import tasks")
+			return
+		}
+
+		if result.Created == 0 {
+			ctx.JSON(http.StatusUnprocessableEntity, result)
+			return
+		}
+		ctx.JSON(http.StatusCreated, result)
 	}
 }
 
@@ -126,14 +172,14 @@ func (h *Handler) UpdateTaskHandler() gin.HandlerFunc {
 			return
 		}
 
-		err := h.proxy.UpdateTask(taskID, userID, req)
+		task, err := h.proxy.UpdateTask(taskID, userID, req)
 		if err != nil {
 			log.Printf("Failed to update task: %v", err)
 			h.handleError(ctx, err, "Failed to update task")
 			return
 		}
 		
-		ctx.Status(http.StatusOK)
+		ctx.JSON(http.StatusOK, task)
 	}
 }
 
@@ -160,7 +206,96 @@ func (h *Handler) DeleteTaskHandler() gin.HandlerFunc {
 	}
 }
 
+func (h *Handler) AddDependencyHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req taskModel.DependencyRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid request body",
+			})
+			return
+		}
+
+		taskID := ctx.Param("id")
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		task, err := h.proxy.AddDependency(taskID, req.BlockerID, userID)
+		if err != nil {
+			log.Printf("Failed to add dependency: %v", err)
+			h.handleError(ctx, err, "Failed to add dependency")
+			return
+		}
+
+		ctx.JSON(http.StatusCreated, task)
+	}
+}
+
+func (h *Handler) DeleteDependencyHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		taskID := ctx.Param("id")
+		blockerID := ctx.Param("blockerID")
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		err := h.proxy.DeleteDependency(taskID, blockerID, userID)
+		if err != nil {
+			log.Printf("Failed to delete dependency: %v", err)
+			h.handleError(ctx, err, "Failed to delete dependency")
+			return
+		}
+
+		ctx.Status(http.StatusOK)
+	}
+}
+
+func (h *Handler) BulkUpdateTasksHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req taskModel.BulkRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid request body",
+			})
+			return
+		}
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		result, err := h.proxy.BulkUpdateTasks(userID, req)
+		if err != nil {
+			log.Printf("Failed to update tasks: %v", err)
+			h.handleError(ctx, err, "Failed to update tasks")
+			return
+		}
+
+		if req.Strict && result.Applied < len(req.TaskIDs) {
+			ctx.JSON(http.StatusConflict, result)
+			return
+		}
+		ctx.JSON(http.StatusOK, result)
+	}
+}
+
 func (h *Handler) handleError(ctx *gin.Context, err error, message string) {
+	var tooLarge *http.MaxBytesError
 	switch {
 	case errors.Is(err, taskProxy.ErrForbidden):
 		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
@@ -170,10 +305,30 @@ func (h *Handler) handleError(ctx *gin.Context, err error, message string) {
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
 			"detail": "Invalid combination of fields",
 		})
+	case errors.Is(err, taskService.ErrBadBulkRequest):
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"detail": "Missing field for bulk action",
+		})
 	case errors.Is(err, taskService.ErrTaskNotFound):
 		ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{
 			"detail": "Task not found",
 		})
+	case errors.As(err, &tooLarge):
+		ctx.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
+			"detail": "File is too large",
+		})
+	case errors.Is(err, taskService.ErrInvalidCSV):
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"detail": err.Error(),
+		})
+	case errors.Is(err, taskService.ErrMissingCSVColumn):
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"detail": err.Error(),
+		})
+	case errors.Is(err, taskService.ErrEmptyCSV):
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"detail": "CSV has no rows",
+		})
 	case errors.Is(err, taskRepo.ErrTaskLimitReached):
 		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
 			"detail": "Task limit reached",
@@ -182,6 +337,18 @@ func (h *Handler) handleError(ctx *gin.Context, err error, message string) {
 		ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{
 			"detail": "Task position is greater than possible or not positive",
 		})
+	case errors.Is(err, taskService.ErrDependencyNotFound):
+		ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+			"detail": "Dependency not found",
+		})
+	case errors.Is(err, taskRepo.ErrDependencyCycle):
+		ctx.AbortWithStatusJSON(http.StatusConflict, gin.H{
+			"detail": "Dependency would create a cycle",
+		})
+	case errors.Is(err, taskRepo.ErrTaskBlocked):
+		ctx.AbortWithStatusJSON(http.StatusConflict, gin.H{
+			"detail": "Task is blocked by unfinished tasks",
+		})
 	default:
 		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
 			"detail": message,
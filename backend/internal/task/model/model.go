@@ -12,6 +12,8 @@ type Task struct {
 	Position    int        `json:"position"`
 	Done        bool       `json:"done"`
 	Deadline    *time.Time `json:"deadline"`
+	BlockedBy   []string   `json:"blocked_by"`
+	Blocks      []string   `json:"blocks"`
 }
 
 type CreateRequest struct {
@@ -25,4 +27,80 @@ type UpdateRequest struct {
 	Position    *int        `json:"position"`
 	Done        *bool       `json:"done"`
 	Deadline    *time.Time  `json:"deadline"`
-}
\ No newline at end of file
+}
+
+type DependencyRequest struct {
+	BlockerID string `json:"blocker_id" binding:"required,uuid"`
+}
+
+// Mention is a user mentioned in a task who can see its board.
+type Mention struct {
+	UserID    string
+	Email     string
+	Username  string
+	BoardID   string
+	BoardName string
+	Author    string
+}
+
+// ImportQuery maps task fields to CSV header names. Unset fields are
+// looked up under their own name.
+type ImportQuery struct {
+	Name        string `form:"name"`
+	Description string `form:"description"`
+	Deadline    string `form:"deadline"`
+	Done        string `form:"done"`
+	Delimiter   string `form:"delimiter" binding:"omitempty,len=1"`
+}
+
+// ImportRowError describes why a CSV row was not imported. Row is the line
+// the record starts on, the header being line 1.
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type ImportResult struct {
+	Created int              `json:"created"`
+	Errors  []ImportRowError `json:"errors"`
+}
+
+const (
+	BulkMove        string = "move"
+	BulkSetDone     string = "set_done"
+	BulkSetDeadline string = "set_deadline"
+	BulkDelete      string = "delete"
+)
+
+const (
+	BulkStatusOK         string = "ok"
+	BulkStatusFailed     string = "failed"
+	BulkStatusRolledBack string = "rolled_back"
+	BulkStatusSkipped    string = "skipped"
+)
+
+// BulkRequest applies one action to every listed task. ColumnID is
+// required for move and Done for set_done, a null Deadline clears it.
+// In strict mode a single failure rolls back the whole request.
+type BulkRequest struct {
+	TaskIDs  []string   `json:"task_ids" binding:"required,min=1,max=100,unique,dive,uuid"`
+	Action   string     `json:"action" binding:"required,oneof=move set_done set_deadline delete"`
+	ColumnID *string    `json:"column_id" binding:"omitempty,uuid"`
+	Done     *bool      `json:"done"`
+	Deadline *time.Time `json:"deadline"`
+	Strict   bool       `json:"strict"`
+}
+
+type BulkTaskResult struct {
+	TaskID string `json:"task_id"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type BulkResult struct {
+	Action  string           `json:"action"`
+	Strict  bool             `json:"strict"`
+	Applied int              `json:"applied"`
+	Results []BulkTaskResult `json:"results"`
+}
@@ -3,19 +3,25 @@ package taskProxy
 import (
 	"errors"
 	"fmt"
+	"io"
 	taskModel "kanban/internal/task/model"
 )
 
 var ErrForbidden = errors.New("access denied")
 
 type Service interface {
-	CreateTask(columnID string, req taskModel.CreateRequest) error
+	CreateTask(columnID, userID string, req taskModel.CreateRequest) (*taskModel.Task, error)
+	ImportTasks(columnID, userID string, query taskModel.ImportQuery, r io.Reader) (*taskModel.ImportResult, error)
 	GetAllTasks(columnID string) ([]taskModel.Task, error)
 	GetTask(taskID string) (*taskModel.Task, error)
-	UpdateTask(taskID string, req taskModel.UpdateRequest) error
-	DeleteTask(taskID string) error
-	GetUserByColumn(columnID string) (*string, error)
-	GetUserByTask(taskID string) (*string, error)
+	UpdateTask(taskID, userID string, req taskModel.UpdateRequest) (*taskModel.Task, error)
+	DeleteTask(taskID, userID string) error
+	AddDependency(taskID, blockerID string) (*taskModel.Task, error)
+	DeleteDependency(taskID, blockerID string) error
+	BulkUpdateTasks(userID string, req taskModel.BulkRequest, allowed map[string]bool) (*taskModel.BulkResult, error)
+	GetAccessibleTasks(taskIDs []string, userID string) (map[string]bool, error)
+	HasColumnAccess(columnID, userID string) (bool, error)
+	HasTaskAccess(taskID, userID string) (bool, error)
 }
 
 type Proxy struct {
@@ -26,26 +32,39 @@ func NewProxy(service Service) *Proxy {
 	return &Proxy{service: service}
 }
 
-func (p *Proxy) CreateTask(columnID, userID string, req taskModel.CreateRequest) error {
-	isOwner, err := p.checkColumnOwnership(columnID, userID)
+func (p *Proxy) CreateTask(columnID, userID string, req taskModel.CreateRequest) (*taskModel.Task, error) {
+	hasAccess, err := p.checkColumnAccess(columnID, userID)
 	if err != nil {
-		return fmt.Errorf("taskProxy.CreateTask: %w", err)
+		return nil, fmt.Errorf("taskProxy.CreateTask: %w", err)
 	}
  
-	if isOwner {
-		return p.service.CreateTask(columnID, req)
+	if hasAccess {
+		return p.service.CreateTask(columnID, userID, req)
 	} else {
-		return fmt.Errorf("taskProxy.CreateTask: %w", ErrForbidden)
+		return nil, fmt.Errorf("taskProxy.CreateTask: %w", ErrForbidden)
+	}
+}
+
+func (p *Proxy) ImportTasks(columnID, userID string, query taskModel.ImportQuery, r io.Reader) (*taskModel.ImportResult, error) {
+	hasAccess, err := p.checkColumnAccess(columnID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("taskProxy.ImportTasks: %w", err)
+	}
+
+	if hasAccess {
+		return p.service.ImportTasks(columnID, userID, query, r)
+	} else {
+		return nil, fmt.Errorf("taskProxy.ImportTasks: %w", ErrForbidden)
 	}
 }
 
 func (p *Proxy) GetAllTasks(columnID, userID string) ([]taskModel.Task, error) {
-	isOwner, err := p.checkColumnOwnership(columnID, userID)
+	hasAccess, err := p.checkColumnAccess(columnID, userID)
 	if err != nil {
 		return nil, fmt.Errorf("taskProxy.GetAllTasks: %w", err)
 	}
 
-	if isOwner {
+	if hasAccess {
 		return p.service.GetAllTasks(columnID)
 	} else {
 		return nil, fmt.Errorf("taskProxy.GetAllTasks: %w", ErrForbidden)
@@ -53,58 +72,127 @@ func (p *Proxy) GetAllTasks(columnID, userID string) ([]taskModel.Task, error) {
 }
 
 func (p *Proxy) GetTask(taskID, userID string) (*taskModel.Task, error) {
-	isOwner, err := p.checkTaskOwnership(taskID, userID)
+	hasAccess, err := p.checkTaskAccess(taskID, userID)
 	if err != nil {
 		return nil, fmt.Errorf("taskProxy.GetTask: %w", err)
 	}
 
-	if isOwner {
+	if hasAccess {
 		return p.service.GetTask(taskID)
 	} else {
 		return nil, fmt.Errorf("taskProxy.GetTask: %w", ErrForbidden)
 	}
 }
 
-func (p *Proxy) UpdateTask(taskID, userID string, req taskModel.UpdateRequest) error {
-	isOwner, err := p.checkTaskOwnership(taskID, userID)
+func (p *Proxy) UpdateTask(taskID, userID string, req taskModel.UpdateRequest) (*taskModel.Task, error) {
+	hasAccess, err := p.checkTaskAccess(taskID, userID)
 	if err != nil {
-		return fmt.Errorf("taskProxy.UpdateTask: %w", err)
+		return nil, fmt.Errorf("taskProxy.UpdateTask: %w", err)
 	}
 
-	if isOwner {
-		return p.service.UpdateTask(taskID, req)
+	if hasAccess {
+		return p.service.UpdateTask(taskID, userID, req)
 	} else {
-		return fmt.Errorf("taskProxy.UpdateTask: %w", ErrForbidden)
+		return nil, fmt.Errorf("taskProxy.UpdateTask: %w", ErrForbidden)
 	}
 }
 
 func (p *Proxy) DeleteTask(taskID, userID string) error {
-	isOwner, err := p.checkTaskOwnership(taskID, userID)
+	hasAccess, err := p.checkTaskAccess(taskID, userID)
 	if err != nil {
 		return fmt.Errorf("taskProxy.DeleteTask: %w", err)
 	}
 
-	if isOwner {
-		return p.service.DeleteTask(taskID)
+	if hasAccess {
+		return p.service.DeleteTask(taskID, userID)
 	} else {
 		return fmt.Errorf("taskProxy.DeleteTask: %w", ErrForbidden)
 	}
 }
 
-func (p *Proxy) checkColumnOwnership(columnID, userID string) (bool, error) {
-	realUserID, err := p.service.GetUserByColumn(columnID)
+func (p *Proxy) AddDependency(taskID, blockerID, userID string) (*taskModel.Task, error) {
+	hasAccess, err := p.checkTasksAccess(userID, taskID, blockerID)
 	if err != nil {
-		return false, fmt.Errorf("taskProxy.checkColumnOwnership: %w", err)
+		return nil, fmt.Errorf("taskProxy.AddDependency: %w", err)
 	}
 
-	return *realUserID == userID, nil
+	if hasAccess {
+		return p.service.AddDependency(taskID, blockerID)
+	} else {
+		return nil, fmt.Errorf("taskProxy.AddDependency: %w", ErrForbidden)
+	}
 }
 
-func (p *Proxy) checkTaskOwnership(taskID, userID string) (bool, error) {
-	realUserID, err := p.service.GetUserByTask(taskID)
+func (p *Proxy) DeleteDependency(taskID, blockerID, userID string) error {
+	hasAccess, err := p.checkTaskAccess(taskID, userID)
 	if err != nil {
-		return false, fmt.Errorf("taskProxy.checkTaskOwnership: %w", err)
+		return fmt.Errorf("taskProxy.DeleteDependency: %w", err)
+	}
+
+	if hasAccess {
+		return p.service.DeleteDependency(taskID, blockerID)
+	} else {
+		return fmt.Errorf("taskProxy.DeleteDependency: %w", ErrForbidden)
+	}
+}
+
+// BulkUpdateTasks checks access to all tasks with one query. Outside of
+// strict mode inaccessible tasks are reported in the result instead of
+// failing the request.
+func (p *Proxy) BulkUpdateTasks(userID string, req taskModel.BulkRequest) (*taskModel.BulkResult, error) {
+	if req.ColumnID != nil && req.Action == taskModel.BulkMove {
+		hasAccess, err := p.checkColumnAccess(*req.ColumnID, userID)
+		if err != nil {
+			return nil, fmt.Errorf("taskProxy.BulkUpdateTasks: %w", err)
+		}
+		if !hasAccess {
+			return nil, fmt.Errorf("taskProxy.BulkUpdateTasks: %w", ErrForbidden)
+		}
+	}
+
+	allowed, err := p.service.GetAccessibleTasks(req.TaskIDs, userID)
+	if err != nil {
+		return nil, fmt.Errorf("taskProxy.BulkUpdateTasks: %w", err)
+	}
+	if req.Strict {
+		for _, taskID := range req.TaskIDs {
+			if !allowed[taskID] {
+				return nil, fmt.Errorf("taskProxy.BulkUpdateTasks: %w", ErrForbidden)
+			}
+		}
+	}
+
+	return p.service.BulkUpdateTasks(userID, req, allowed)
+}
+
+func (p *Proxy) checkColumnAccess(columnID, userID string) (bool, error) {
+	hasAccess, err := p.service.HasColumnAccess(columnID, userID)
+	if err != nil {
+		return false, fmt.Errorf("taskProxy.checkColumnAccess: %w", err)
+	}
+
+	return hasAccess, nil
+}
+
+func (p *Proxy) checkTaskAccess(taskID, userID string) (bool, error) {
+	hasAccess, err := p.service.HasTaskAccess(taskID, userID)
+	if err != nil {
+		return false, fmt.Errorf("taskProxy.checkTaskAccess: %w", err)
+	}
+
+	return hasAccess, nil
+}
+
+func (p *Proxy) checkTasksAccess(userID string, taskIDs ...string) (bool, error) {
+	for _, taskID := range taskIDs {
+		hasAccess, err := p.checkTaskAccess(taskID, userID)
+		if err != nil {
+			return false, fmt.Errorf("taskProxy.checkTasksAccess: %w", err)
+		}
+		if !hasAccess {
+			return false, nil
+		}
 	}
 
-	return *realUserID == userID, nil
+	return true, nil
 }
\ No newline at end of file
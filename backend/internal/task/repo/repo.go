@@ -3,15 +3,22 @@ package taskRepo
 import (
 	"database/sql"
 	"errors"
+	"fmt"
+	"kanban/internal/history"
 	"kanban/internal/postgres"
 	taskModel "kanban/internal/task/model"
 	"kanban/internal/utils"
+	"time"
+
+	"github.com/lib/pq"
 )
 
-const maxTasks int = 52
+const MaxTasks int = 52
 
 var ErrTaskLimitReached error = errors.New("task limit reached")
 var ErrIncorrectPosition error = errors.New("task position is greater than possible or not positive")
+var ErrDependencyCycle error = errors.New("dependency would create a cycle")
+var ErrTaskBlocked error = errors.New("task has unfinished blockers")
 
 type Repository struct {
 	db *sql.DB
@@ -21,7 +28,29 @@ func NewRepository(db *sql.DB) *Repository {
 	return &Repository{db: db}
 }
 
-func (r *Repository) Create(task taskModel.Task) error {
+func (r *Repository) Create(task taskModel.Task, userID string) (*taskModel.Task, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	created, err := CreateTask(tx, task, utils.GenerateTimestamp())
+	if err != nil {
+		return nil, err
+	}
+
+	err = history.RecordTask(tx, userID, history.ActionCreate, nil, created)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, tx.Commit()
+}
+
+// CreateMany appends the tasks to the end of the column in one
+// transaction. Nothing is created if they do not all fit.
+func (r *Repository) CreateMany(columnID, userID string, tasks []taskModel.Task) error {
 	tx, err := r.db.Begin()
 	if err != nil {
 		return err
@@ -29,32 +58,41 @@ func (r *Repository) Create(task taskModel.Task) error {
 	defer tx.Rollback()
 
 	var count int
-	err = tx.QueryRow(postgres.QueryGetTasksCount, task.ColumnID).Scan(&count)
+	err = tx.QueryRow(postgres.QueryGetTasksCount, columnID).Scan(&count)
 	if err != nil {
 		return err
 	}
-	if count >= maxTasks {
+	if count+len(tasks) > MaxTasks {
 		return ErrTaskLimitReached
 	}
 
-	err = tx.QueryRow(postgres.QueryGetMaxTaskPosition, task.ColumnID).Scan(&task.Position)
-	if err != nil{
+	var position int
+	err = tx.QueryRow(postgres.QueryGetMaxTaskPosition, columnID).Scan(&position)
+	if err != nil {
 		return err
 	}
 
-	_, err = tx.Exec(
-		postgres.QueryCreateTask,
-		utils.NewUUID(),
-		task.ColumnID,
-		utils.GenerateTimestamp(),
-		utils.GenerateTimestamp(),
-		task.Name,
-		task.Description,
-		task.Position,
-		false,
-	)
-	if err != nil {
-		return err
+	now := utils.GenerateTimestamp()
+	for i, task := range tasks {
+		created, err := scanTask(tx.QueryRow(
+			postgres.QueryImportTask,
+			utils.NewUUID(),
+			columnID,
+			now,
+			task.Name,
+			task.Description,
+			position+i,
+			task.Done,
+			task.Deadline,
+		))
+		if err != nil {
+			return err
+		}
+
+		err = history.RecordTask(tx, userID, history.ActionCreate, nil, created)
+		if err != nil {
+			return err
+		}
 	}
 
 	return tx.Commit()
@@ -67,229 +105,383 @@ func (r *Repository) GetAll(columnID string) ([]taskModel.Task, error) {
 	}
 	defer tx.Rollback()
 
-	rows, err := tx.Query(postgres.QueryGetAllTasks, columnID)
+	tasks, err := GetColumnTasks(tx, columnID)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	var tasks []taskModel.Task
-	for rows.Next() {
-		var task taskModel.Task
-		if err = rows.Scan(
-			&task.ID,
-			&task.ColumnID,
-			&task.CreatedAt,
-			&task.UpdatedAt,
-			&task.Name,
-			&task.Description,
-			&task.Position,
-			&task.Done,
-			&task.Deadline,
-		); err != nil {
-			return nil, err
-		}
-		tasks = append(tasks, task)
+	if err = tx.Commit(); err != nil {
+		return nil, err
 	}
 
-	if err = rows.Err(); err != nil {
+	return tasks, nil
+}
+
+func (r *Repository) Get(taskID string) (*taskModel.Task, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
 		return nil, err
 	}
+	defer tx.Rollback()
 
-	if err = tx.Commit(); err != nil {
+	task, err := scanTask(tx.QueryRow(postgres.QueryGetTask, taskID))
+	if err != nil {
 		return nil, err
 	}
 
-	return tasks, nil
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
 }
 
-func (r *Repository) Get(taskID string) (*taskModel.Task, error) {
+func (r *Repository) UpdateContent(taskID, userID string, req taskModel.UpdateRequest) (*taskModel.Task, error) {
 	tx, err := r.db.Begin()
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback()
 
-	var task taskModel.Task
-	err = tx.QueryRow(postgres.QueryGetTask, taskID).Scan(
-		&task.ID,
-		&task.ColumnID,
-		&task.CreatedAt,
-		&task.UpdatedAt,
-		&task.Name,
-		&task.Description,
-		&task.Position,
-		&task.Done,
-		&task.Deadline,
-	)
+	before, err := LockTask(tx, taskID)
 	if err != nil {
 		return nil, err
 	}
 
-	err = tx.Commit()
+	task, err := UpdateTaskContent(tx, taskID, req, utils.GenerateTimestamp())
 	if err != nil {
 		return nil, err
 	}
 
-	return &task, nil
+	err = history.RecordTask(tx, userID, history.ActionUpdate, before, task)
+	if err != nil {
+		return nil, err
+	}
+
+	return task, tx.Commit()
 }
 
-func (r *Repository) UpdateContent(taskID string, req taskModel.UpdateRequest) error {
+// UpdateColumn moves the task to the position in the column, which may be
+// the column it is already in.
+func (r *Repository) UpdateColumn(taskID, userID string, req taskModel.UpdateRequest) (*taskModel.Task, error) {
 	tx, err := r.db.Begin()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer tx.Rollback()
 
-	_, err = tx.Exec(
-		postgres.QueryUpdateTaskContent,
-		req.Name,
-		req.Description,
-		req.Done,
-		req.Deadline,
-		utils.GenerateTimestamp(),
-		taskID,
-	)
+	before, err := LockTask(tx, taskID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	
-	return tx.Commit()
+
+	var task *taskModel.Task
+	if *req.ColumnID == before.ColumnID {
+		task, err = RepositionTask(tx, before, *req.Position, utils.GenerateTimestamp())
+	} else {
+		task, err = MoveTask(tx, before, *req.ColumnID, req.Position, utils.GenerateTimestamp())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = history.RecordTask(tx, userID, history.ActionMove, before, task)
+	if err != nil {
+		return nil, err
+	}
+
+	return task, tx.Commit()
 }
 
-func (r *Repository) UpdateColumn(taskID string, req taskModel.UpdateRequest) error {
+func (r *Repository) UpdatePosition(taskID, userID string, req taskModel.UpdateRequest) (*taskModel.Task, error) {
 	tx, err := r.db.Begin()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer tx.Rollback()
 
-	columnID, oldPos, err := getColumnIDAndPosition(tx, taskID)
+	before, err := LockTask(tx, taskID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	err = checkPosition(tx, columnID, *req.Position)
+	task, err := RepositionTask(tx, before, *req.Position, utils.GenerateTimestamp())
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = tx.Exec(postgres.QueryMoveTasksForInsert, *req.ColumnID, *req.Position)
+	err = history.RecordTask(tx, userID, history.ActionMove, before, task)
+	if err != nil {
+		return nil, err
+	}
+
+	return task, tx.Commit()
+}
+
+func (r *Repository) Delete(taskID, userID string) error {
+	tx, err := r.db.Begin()
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	_, err = tx.Exec(
-		postgres.QueryUpdateTaskColumn, 
-		req.ColumnID,
-		req.Position,
-		utils.GenerateTimestamp(),
-		taskID,
-	)
+	before, err := LockTask(tx, taskID)
 	if err != nil {
 		return err
 	}
 
-	_, err = tx.Exec(postgres.QueryMoveTaskForDelete, columnID, oldPos)
+	err = DeleteTask(tx, before)
 	if err != nil {
 		return err
 	}
 
+	err = history.RecordTask(tx, userID, history.ActionDelete, before, nil)
+	if err != nil {
+		return err
+	}
 
 	return tx.Commit()
 }
 
-func (r *Repository) UpdatePosition(taskID string, req taskModel.UpdateRequest) error {
+// AddDependency returns the blocked task with the new blocker.
+func (r *Repository) AddDependency(blockerID, blockedID string) (*taskModel.Task, error) {
 	tx, err := r.db.Begin()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer tx.Rollback()
 
-	columnID, oldPos, err := getColumnIDAndPosition(tx, taskID)
+	_, err = tx.Exec(postgres.QueryLockTaskDependencies)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	err = checkPosition(tx, columnID, *req.Position + 1)
+	var cycle bool
+	err = tx.QueryRow(postgres.QueryCheckDependencyCycle, blockedID, blockerID).Scan(&cycle)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if cycle || blockerID == blockedID {
+		return nil, ErrDependencyCycle
 	}
 
-	if *req.Position > oldPos {
-		_, err = tx.Exec(postgres.QueryMoveTasksUp, columnID, oldPos, *req.Position)
-	} else if *req.Position < oldPos {
-		_, err = tx.Exec(postgres.QueryMoveTasksDown, columnID, *req.Position, oldPos)
+	_, err = tx.Exec(
+		postgres.QueryCreateTaskDependency,
+		blockerID,
+		blockedID,
+		utils.GenerateTimestamp(),
+	)
+	if err != nil {
+		return nil, err
 	}
+
+	task, err := scanTask(tx.QueryRow(postgres.QueryGetTask, blockedID))
+	if err != nil {
+		return nil, err
+	}
+
+	return task, tx.Commit()
+}
+
+func (r *Repository) DeleteDependency(blockerID, blockedID string) error {
+	res, err := r.db.Exec(postgres.QueryDeleteTaskDependency, blockerID, blockedID)
 	if err != nil {
 		return err
 	}
 
-	_, err = tx.Exec(
-		postgres.QueryUpdateTaskPosition, 
-		req.Position, 
-		utils.GenerateTimestamp(), 
-		taskID,
-	)
+	affected, err := res.RowsAffected()
 	if err != nil {
 		return err
 	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
 
-	return tx.Commit()
+	return nil
 }
 
-func (r *Repository) Delete(taskID string) error {
+// Bulk applies the action to each task in order and returns the outcome
+// per task, nil meaning success. A task that is missing, blocked or does
+// not fit into the target column fails on its own, in strict mode the
+// first such failure rolls everything back and the remaining tasks are not
+// tried.
+func (r *Repository) Bulk(userID string, req taskModel.BulkRequest) ([]error, error) {
 	tx, err := r.db.Begin()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer tx.Rollback()
 
-	columnID, pos, err := getColumnIDAndPosition(tx, taskID)
+	now := utils.GenerateTimestamp()
+	results := make([]error, 0, len(req.TaskIDs))
+	for _, taskID := range req.TaskIDs {
+		if !req.Strict {
+			if _, err = tx.Exec(postgres.QuerySavepointBulkTask); err != nil {
+				return nil, err
+			}
+		}
+
+		err = applyBulk(tx, userID, taskID, req, now)
+		if err != nil && !isTaskFailure(err) {
+			return nil, err
+		}
+		results = append(results, err)
+
+		if err != nil {
+			if req.Strict {
+				return results, nil
+			}
+			if _, err = tx.Exec(postgres.QueryRollbackBulkTask); err != nil {
+				return nil, err
+			}
+		}
+		if !req.Strict {
+			if _, err = tx.Exec(postgres.QueryReleaseBulkTask); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return results, tx.Commit()
+}
+
+func applyBulk(tx *sql.Tx, userID, taskID string, req taskModel.BulkRequest, now time.Time) error {
+	before, err := LockTask(tx, taskID)
 	if err != nil {
 		return err
 	}
 
-	_, err = r.db.Exec(postgres.QueryMoveTaskForDelete, columnID, pos)
+	var after *taskModel.Task
+	action := history.ActionUpdate
+	switch req.Action {
+	case taskModel.BulkMove:
+		if before.ColumnID == *req.ColumnID {
+			return nil
+		}
+		action = history.ActionMove
+		after, err = MoveTask(tx, before, *req.ColumnID, nil, now)
+	case taskModel.BulkSetDone:
+		after, err = UpdateTaskContent(tx, taskID, taskModel.UpdateRequest{Done: req.Done}, now)
+	case taskModel.BulkSetDeadline:
+		after, err = SetTaskDeadline(tx, taskID, req.Deadline, now)
+	case taskModel.BulkDelete:
+		action = history.ActionDelete
+		err = DeleteTask(tx, before)
+	default:
+		err = fmt.Errorf("unknown bulk action %q", req.Action)
+	}
 	if err != nil {
 		return err
 	}
 
-	_, err = r.db.Exec(postgres.QueryDeleteTask, taskID)
+	return history.RecordTask(tx, userID, action, before, after)
+}
+
+func isTaskFailure(err error) bool {
+	return errors.Is(err, sql.ErrNoRows) ||
+		errors.Is(err, ErrTaskLimitReached) ||
+		errors.Is(err, ErrTaskBlocked)
+}
+
+func (r *Repository) GetAccessible(taskIDs []string, userID string) ([]string, error) {
+	rows, err := r.db.Query(postgres.QueryGetAccessibleTasks, pq.Array(taskIDs), userID, postgres.OwnBoardsOnly())
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer rows.Close()
 
-	return tx.Commit()
+	var accessible []string
+	for rows.Next() {
+		var taskID string
+		if err = rows.Scan(&taskID); err != nil {
+			return nil, err
+		}
+		accessible = append(accessible, taskID)
+	}
+
+	return accessible, rows.Err()
+}
+
+// GetMentioned returns the users with one of the lowercased usernames who
+// can see the task's board, leaving out its author.
+func (r *Repository) GetMentioned(taskID, authorID string, usernames []string) ([]taskModel.Mention, error) {
+	rows, err := r.db.Query(postgres.QueryGetMentionedUsers, taskID, pq.Array(usernames), authorID, postgres.OwnBoardsOnly())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var mentions []taskModel.Mention
+	for rows.Next() {
+		var mention taskModel.Mention
+		err = rows.Scan(
+			&mention.UserID,
+			&mention.Email,
+			&mention.Username,
+			&mention.BoardID,
+			&mention.BoardName,
+			&mention.Author,
+		)
+		if err != nil {
+			return nil, err
+		}
+		mentions = append(mentions, mention)
+	}
+
+	return mentions, rows.Err()
 }
 
-func (r *Repository) GetUserByColumn(columnID string) (*string, error) {
-	var userID string
-	err := r.db.QueryRow(postgres.QueryGetUserByColumnID, columnID).Scan(&userID)
-	return &userID, err
+func (r *Repository) HasColumnAccess(columnID, userID string) (bool, error) {
+	var hasAccess bool
+	err := r.db.QueryRow(postgres.QueryHasColumnAccess, columnID, userID, postgres.OwnBoardsOnly()).Scan(&hasAccess)
+	return hasAccess, err
 }
 
-func (r *Repository) GetUserByTask(taskID string) (*string, error) {
-	var userID string
-	err := r.db.QueryRow(postgres.QueryGetUserByTaskID, taskID).Scan(&userID)
-	return &userID, err
+func (r *Repository) HasTaskAccess(taskID, userID string) (bool, error) {
+	var hasAccess bool
+	err := r.db.QueryRow(postgres.QueryHasTaskAccess, taskID, userID, postgres.OwnBoardsOnly()).Scan(&hasAccess)
+	return hasAccess, err
 }
 
-func getColumnIDAndPosition(tx *sql.Tx, taskID string) (string, int, error) {
-	var columnID string
-	var pos int
-	err := tx.QueryRow(postgres.QueryGetColumnIDAndPosition, taskID).Scan(&columnID, &pos)
+func scanTask(row *sql.Row) (*taskModel.Task, error) {
+	var task taskModel.Task
+	err := row.Scan(
+		&task.ID,
+		&task.ColumnID,
+		&task.CreatedAt,
+		&task.UpdatedAt,
+		&task.Name,
+		&task.Description,
+		&task.Position,
+		&task.Done,
+		&task.Deadline,
+		pq.Array(&task.BlockedBy),
+		pq.Array(&task.Blocks),
+	)
 	if err != nil {
-		return "", -1, err
+		return nil, err
 	}
-	return columnID, pos, err
+	return &task, nil
 }
 
-func checkPosition(tx *sql.Tx, columnID string, pos int) error {
-	var maxPos int
-	err := tx.QueryRow(postgres.QueryGetMaxTaskPosition, columnID).Scan(&maxPos)
-	if pos > maxPos || pos <= 0 {
-		return ErrIncorrectPosition
+func checkBlockers(tx *sql.Tx, taskID, columnID string) error {
+	var enforce, flagged bool
+	err := tx.QueryRow(postgres.QueryGetColumnEnforcement, columnID).Scan(&enforce, &flagged)
+	if err != nil {
+		return err
+	}
+	if !enforce || !flagged {
+		return nil
+	}
+
+	var openBlockers int
+	err = tx.QueryRow(postgres.QueryGetOpenBlockersCount, taskID).Scan(&openBlockers)
+	if err != nil {
+		return err
+	}
+	if openBlockers > 0 {
+		return ErrTaskBlocked
 	}
-	return err
+	return nil
 }
@@ -0,0 +1,231 @@
+package taskRepo
+
+import (
+	"database/sql"
+	"errors"
+	"kanban/internal/postgres"
+	taskModel "kanban/internal/task/model"
+	"kanban/internal/utils"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4"
+	migratePostgres "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+)
+
+// The tests run against the database in KANBAN_TEST_DATABASE_URL, which
+// is migrated to the latest version first. Without it they are skipped.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	uri := os.Getenv("KANBAN_TEST_DATABASE_URL")
+	if uri == "" {
+		t.Skip("KANBAN_TEST_DATABASE_URL is not set")
+	}
+
+	db, err := sql.Open("postgres", uri)
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+
+	driver, err := migratePostgres.WithInstance(db, &migratePostgres.Config{})
+	if err != nil {
+		t.Fatalf("migration driver: %v", err)
+	}
+	m, err := migrate.NewWithDatabaseInstance("file://../../../db/migrations", "postgres", driver)
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if err = m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		t.Fatalf("migrate up: %v", err)
+	}
+
+	return db
+}
+
+type fixture struct {
+	t       *testing.T
+	db      *sql.DB
+	userID  string
+	boardID string
+	tasks   *Repository
+}
+
+// newFixture creates a user with a board of its own, both removed again
+// when the test ends.
+func newFixture(t *testing.T, enforceDependencies bool) *fixture {
+	db := openTestDB(t)
+	now := utils.GenerateTimestamp()
+	userID, workspaceID, boardID := utils.NewUUID(), utils.NewUUID(), utils.NewUUID()
+
+	for _, stmt := range []struct {
+		query string
+		args  []any
+	}{
+		{`INSERT INTO "user" (id, created_at, email, username, hashed_password) VALUES ($1, $2, $3, 'test', '')`,
+			[]any{userID, now, userID + "@example.org"}},
+		{`INSERT INTO workspace (id, created_at, updated_at, name) VALUES ($1, $2, $2, 'test')`,
+			[]any{workspaceID, now}},
+		{`INSERT INTO workspace_member (workspace_id, user_id, role, created_at) VALUES ($1, $2, 'owner', $3)`,
+			[]any{workspaceID, userID, now}},
+		{postgres.QueryCreateBoard, []any{boardID, userID, workspaceID, now, now, "test", enforceDependencies}},
+	} {
+		if _, err := db.Exec(stmt.query, stmt.args...); err != nil {
+			t.Fatalf("create fixture: %v", err)
+		}
+	}
+
+	t.Cleanup(func() {
+		db.Exec(`DELETE FROM workspace WHERE id = $1`, workspaceID)
+		db.Exec(`DELETE FROM "user" WHERE id = $1`, userID)
+		db.Close()
+	})
+
+	return &fixture{t: t, db: db, userID: userID, boardID: boardID, tasks: NewRepository(db)}
+}
+
+// createColumn adds a column with the flag, empty for none. The column
+// repository depends on this package, so the query is run directly.
+func (f *fixture) createColumn(name, flag string) string {
+	f.t.Helper()
+	id := utils.NewUUID()
+	now := utils.GenerateTimestamp()
+	var position int
+	err := f.db.QueryRow(`SELECT COUNT(*) + 1 FROM "column" WHERE board_id = $1`, f.boardID).Scan(&position)
+	if err == nil {
+		_, err = f.db.Exec(postgres.QueryCreateColumn, id, f.boardID, now, now, name, position, flag)
+	}
+	if err != nil {
+		f.t.Fatalf("create column %s: %v", name, err)
+	}
+	return id
+}
+
+func (f *fixture) createTasks(columnID string, names ...string) map[string]string {
+	f.t.Helper()
+	ids := map[string]string{}
+	for _, name := range names {
+		task, err := f.tasks.Create(taskModel.Task{ID: utils.NewUUID(), ColumnID: columnID, Name: name}, f.userID)
+		if err != nil {
+			f.t.Fatalf("create task %s: %v", name, err)
+		}
+		ids[name] = task.ID
+	}
+	return ids
+}
+
+func TestAddDependency(t *testing.T) {
+	type dependency struct{ blocker, blocked string }
+
+	tests := []struct {
+		name     string
+		existing []dependency
+		add      dependency
+		wantErr  error
+	}{
+		{name: "blocker", add: dependency{"A", "B"}},
+		{name: "added twice", existing: []dependency{{"A", "B"}}, add: dependency{"A", "B"}},
+		{name: "self-dependency", add: dependency{"A", "A"}, wantErr: ErrDependencyCycle},
+		{name: "direct cycle", existing: []dependency{{"A", "B"}}, add: dependency{"B", "A"}, wantErr: ErrDependencyCycle},
+		{name: "cycle through another task", existing: []dependency{{"A", "B"}, {"B", "C"}}, add: dependency{"C", "A"}, wantErr: ErrDependencyCycle},
+		{name: "shared blocker", existing: []dependency{{"A", "B"}, {"A", "C"}}, add: dependency{"B", "C"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t, false)
+			ids := f.createTasks(f.createColumn("todo", ""), "A", "B", "C")
+
+			for _, d := range tt.existing {
+				if _, err := f.tasks.AddDependency(ids[d.blocker], ids[d.blocked]); err != nil {
+					t.Fatalf("add %s -> %s: %v", d.blocker, d.blocked, err)
+				}
+			}
+
+			task, err := f.tasks.AddDependency(ids[tt.add.blocker], ids[tt.add.blocked])
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error: got %v, want %v", err, tt.wantErr)
+				}
+				blocked, err := f.tasks.Get(ids[tt.add.blocked])
+				if err != nil {
+					t.Fatalf("get task: %v", err)
+				}
+				if slices.Contains(blocked.BlockedBy, ids[tt.add.blocker]) {
+					t.Errorf("dependency %s -> %s was stored", tt.add.blocker, tt.add.blocked)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("add dependency: %v", err)
+			}
+			blockers := 0
+			for _, id := range task.BlockedBy {
+				if id == ids[tt.add.blocker] {
+					blockers++
+				}
+			}
+			if task.ID != ids[tt.add.blocked] || blockers != 1 {
+				t.Errorf("blocked task: got %s blocked by %v", task.ID, task.BlockedBy)
+			}
+		})
+	}
+}
+
+func TestMoveBlockedTask(t *testing.T) {
+	tests := []struct {
+		name        string
+		enforce     bool
+		blockerDone bool
+		flag        string
+		wantErr     error
+	}{
+		{name: "open blocker, flagged column", enforce: true, flag: "in_progress", wantErr: ErrTaskBlocked},
+		{name: "open blocker, done column", enforce: true, flag: "done", wantErr: ErrTaskBlocked},
+		{name: "done blocker, flagged column", enforce: true, blockerDone: true, flag: "in_progress"},
+		{name: "open blocker, column without flag", enforce: true},
+		{name: "open blocker, dependencies not enforced", flag: "in_progress"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t, tt.enforce)
+			todo := f.createColumn("todo", "")
+			target := f.createColumn("target", tt.flag)
+			ids := f.createTasks(todo, "blocker", "task")
+
+			if _, err := f.tasks.AddDependency(ids["blocker"], ids["task"]); err != nil {
+				t.Fatalf("add dependency: %v", err)
+			}
+			if tt.blockerDone {
+				done := true
+				if _, err := f.tasks.UpdateContent(ids["blocker"], f.userID, taskModel.UpdateRequest{Done: &done}); err != nil {
+					t.Fatalf("finish blocker: %v", err)
+				}
+			}
+
+			moved, err := f.tasks.UpdateColumn(ids["task"], f.userID, taskModel.UpdateRequest{ColumnID: &target})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error: got %v, want %v", err, tt.wantErr)
+				}
+				task, err := f.tasks.Get(ids["task"])
+				if err != nil {
+					t.Fatalf("get task: %v", err)
+				}
+				if task.ColumnID != todo {
+					t.Errorf("task moved to %s despite the error", task.ColumnID)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("move: %v", err)
+			}
+			if moved.ColumnID != target {
+				t.Errorf("column: got %s, want %s", moved.ColumnID, target)
+			}
+		})
+	}
+}
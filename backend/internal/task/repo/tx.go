@@ -0,0 +1,188 @@
+package taskRepo
+
+import (
+	"database/sql"
+	"kanban/internal/postgres"
+	taskModel "kanban/internal/task/model"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// The functions below make one change to a task in a transaction of the
+// caller. Every write path of tasks goes through them, recording the
+// change in the history is left to the caller.
+
+// LockTask returns the task and locks it until the transaction ends.
+func LockTask(tx *sql.Tx, taskID string) (*taskModel.Task, error) {
+	return scanTask(tx.QueryRow(postgres.QueryGetTaskForUpdate, taskID))
+}
+
+// GetColumnTasks returns the tasks of the column in their order.
+func GetColumnTasks(tx *sql.Tx, columnID string) ([]taskModel.Task, error) {
+	rows, err := tx.Query(postgres.QueryGetAllTasks, columnID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []taskModel.Task
+	for rows.Next() {
+		var task taskModel.Task
+		if err = rows.Scan(
+			&task.ID,
+			&task.ColumnID,
+			&task.CreatedAt,
+			&task.UpdatedAt,
+			&task.Name,
+			&task.Description,
+			&task.Position,
+			&task.Done,
+			&task.Deadline,
+			pq.Array(&task.BlockedBy),
+			pq.Array(&task.Blocks),
+		); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks, rows.Err()
+}
+
+// NextPosition returns the position after the last task of the column.
+func NextPosition(tx *sql.Tx, columnID string) (int, error) {
+	var pos int
+	err := tx.QueryRow(postgres.QueryGetMaxTaskPosition, columnID).Scan(&pos)
+	return pos, err
+}
+
+// CreateTask appends the task to the end of its column.
+func CreateTask(tx *sql.Tx, task taskModel.Task, now time.Time) (*taskModel.Task, error) {
+	err := checkLimit(tx, task.ColumnID)
+	if err != nil {
+		return nil, err
+	}
+
+	task.Position, err = NextPosition(tx, task.ColumnID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanTask(tx.QueryRow(
+		postgres.QueryCreateTask,
+		task.ID,
+		task.ColumnID,
+		now,
+		now,
+		task.Name,
+		task.Description,
+		task.Position,
+		false,
+	))
+}
+
+// UpdateTaskContent sets the content fields that are given in the request.
+func UpdateTaskContent(tx *sql.Tx, taskID string, req taskModel.UpdateRequest, now time.Time) (*taskModel.Task, error) {
+	return scanTask(tx.QueryRow(
+		postgres.QueryUpdateTaskContent,
+		req.Name,
+		req.Description,
+		req.Done,
+		req.Deadline,
+		now,
+		taskID,
+	))
+}
+
+// SetTaskDeadline sets the deadline of the task, nil clears it.
+func SetTaskDeadline(tx *sql.Tx, taskID string, deadline *time.Time, now time.Time) (*taskModel.Task, error) {
+	return scanTask(tx.QueryRow(postgres.QueryUpdateTaskDeadline, deadline, now, taskID))
+}
+
+// MoveTask puts the task into another column at the position, or at its
+// end if the position is nil.
+func MoveTask(tx *sql.Tx, task *taskModel.Task, columnID string, position *int, now time.Time) (*taskModel.Task, error) {
+	err := checkLimit(tx, columnID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkBlockers(tx, task.ID, columnID)
+	if err != nil {
+		return nil, err
+	}
+
+	pos, err := NextPosition(tx, columnID)
+	if err != nil {
+		return nil, err
+	}
+	if position != nil {
+		if *position > pos || *position <= 0 {
+			return nil, ErrIncorrectPosition
+		}
+		pos = *position
+	}
+
+	_, err = tx.Exec(postgres.QueryMoveTasksForInsert, columnID, pos)
+	if err != nil {
+		return nil, err
+	}
+
+	moved, err := scanTask(tx.QueryRow(postgres.QueryUpdateTaskColumn, columnID, pos, now, task.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(postgres.QueryMoveTaskForDelete, task.ColumnID, task.Position)
+	if err != nil {
+		return nil, err
+	}
+
+	return moved, nil
+}
+
+// RepositionTask moves the task to the position within its column.
+func RepositionTask(tx *sql.Tx, task *taskModel.Task, position int, now time.Time) (*taskModel.Task, error) {
+	maxPos, err := NextPosition(tx, task.ColumnID)
+	if err != nil {
+		return nil, err
+	}
+	if position >= maxPos || position <= 0 {
+		return nil, ErrIncorrectPosition
+	}
+
+	if position > task.Position {
+		_, err = tx.Exec(postgres.QueryMoveTasksUp, task.ColumnID, task.Position, position)
+	} else if position < task.Position {
+		_, err = tx.Exec(postgres.QueryMoveTasksDown, task.ColumnID, position, task.Position)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return scanTask(tx.QueryRow(postgres.QueryUpdateTaskPosition, position, now, task.ID))
+}
+
+// DeleteTask removes the task and closes the gap it leaves in its column.
+func DeleteTask(tx *sql.Tx, task *taskModel.Task) error {
+	_, err := tx.Exec(postgres.QueryDeleteTask, task.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(postgres.QueryMoveTaskForDelete, task.ColumnID, task.Position)
+	return err
+}
+
+func checkLimit(tx *sql.Tx, columnID string) error {
+	var count int
+	err := tx.QueryRow(postgres.QueryGetTasksCount, columnID).Scan(&count)
+	if err != nil {
+		return err
+	}
+	if count >= MaxTasks {
+		return ErrTaskLimitReached
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package taskService
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	taskModel "kanban/internal/task/model"
+)
+
+var ErrBadBulkRequest error = errors.New("missing field for bulk action")
+
+// BulkUpdateTasks applies the request to the tasks in allowed, the others
+// fail as inaccessible without being touched.
+func (s *Service) BulkUpdateTasks(userID string, req taskModel.BulkRequest, allowed map[string]bool) (*taskModel.BulkResult, error) {
+	if (req.Action == taskModel.BulkMove && req.ColumnID == nil) ||
+		(req.Action == taskModel.BulkSetDone && req.Done == nil) {
+		return nil, fmt.Errorf("taskService.BulkUpdateTasks: %w", ErrBadBulkRequest)
+	}
+
+	result := &taskModel.BulkResult{
+		Action:  req.Action,
+		Strict:  req.Strict,
+		Results: make([]taskModel.BulkTaskResult, len(req.TaskIDs)),
+	}
+
+	var permitted []string
+	for i, taskID := range req.TaskIDs {
+		result.Results[i].TaskID = taskID
+		if !allowed[taskID] {
+			result.Results[i].Status = taskModel.BulkStatusFailed
+			result.Results[i].Error = "access denied"
+			continue
+		}
+		permitted = append(permitted, taskID)
+	}
+	if len(permitted) == 0 {
+		return result, nil
+	}
+
+	apply := req
+	apply.TaskIDs = permitted
+	outcomes, err := s.repo.Bulk(userID, apply)
+	if err != nil {
+		return nil, fmt.Errorf("taskService.BulkUpdateTasks: %w", err)
+	}
+
+	failed := false
+	next := 0
+	for i := range result.Results {
+		if !allowed[result.Results[i].TaskID] {
+			continue
+		}
+		if next >= len(outcomes) {
+			result.Results[i].Status = taskModel.BulkStatusSkipped
+			continue
+		}
+
+		if err := outcomes[next]; err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = ErrTaskNotFound
+			}
+			result.Results[i].Status = taskModel.BulkStatusFailed
+			result.Results[i].Error = err.Error()
+			failed = true
+		} else {
+			result.Results[i].Status = taskModel.BulkStatusOK
+			result.Applied++
+		}
+		next++
+	}
+
+	if failed && req.Strict {
+		for i := range result.Results {
+			if result.Results[i].Status == taskModel.BulkStatusOK {
+				result.Results[i].Status = taskModel.BulkStatusRolledBack
+			}
+		}
+		result.Applied = 0
+	}
+
+	return result, nil
+}
+
+func (s *Service) GetAccessibleTasks(taskIDs []string, userID string) (map[string]bool, error) {
+	accessible, err := s.repo.GetAccessible(taskIDs, userID)
+	if err != nil {
+		return nil, fmt.Errorf("taskService.GetAccessibleTasks: %w", err)
+	}
+
+	allowed := make(map[string]bool, len(accessible))
+	for _, taskID := range accessible {
+		allowed[taskID] = true
+	}
+	return allowed, nil
+}
@@ -0,0 +1,178 @@
+package taskService
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	taskModel "kanban/internal/task/model"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var ErrInvalidCSV error = errors.New("invalid csv")
+var ErrMissingCSVColumn error = errors.New("csv has no column for a mapped field")
+var ErrEmptyCSV error = errors.New("csv has no rows")
+
+// deadlineLayouts are tried in order for the deadline column. Dates
+// without a time are taken as midnight UTC.
+var deadlineLayouts = []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02 15:04", time.DateOnly}
+
+var doneValues = map[string]bool{
+	"": false, "false": false, "no": false, "0": false,
+	"true": true, "yes": true, "1": true, "x": true, "done": true,
+}
+
+// ImportTasks creates a task for every valid row of the CSV. Invalid rows
+// are reported with the reason and skipped, the valid ones are created
+// together or not at all.
+func (s *Service) ImportTasks(columnID, userID string, query taskModel.ImportQuery, r io.Reader) (*taskModel.ImportResult, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if query.Delimiter != "" {
+		reader.Comma, _ = utf8.DecodeRuneInString(query.Delimiter)
+		if !validDelimiter(reader.Comma) {
+			return nil, fmt.Errorf("taskService.ImportTasks: %w: delimiter %q", ErrInvalidCSV, query.Delimiter)
+		}
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("taskService.ImportTasks: %w", ErrEmptyCSV)
+		}
+		return nil, fmt.Errorf("taskService.ImportTasks: %w", csvError(err))
+	}
+
+	fields, err := mapColumns(header, query)
+	if err != nil {
+		return nil, fmt.Errorf("taskService.ImportTasks: %w", err)
+	}
+
+	result := &taskModel.ImportResult{Errors: []taskModel.ImportRowError{}}
+	var tasks []taskModel.Task
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("taskService.ImportTasks: %w", csvError(err))
+		}
+		row, _ := reader.FieldPos(0)
+
+		task, rowErr := parseRow(record, fields)
+		if rowErr != nil {
+			rowErr.Row = row
+			result.Errors = append(result.Errors, *rowErr)
+			continue
+		}
+		tasks = append(tasks, *task)
+	}
+
+	if len(tasks) == 0 && len(result.Errors) == 0 {
+		return nil, fmt.Errorf("taskService.ImportTasks: %w", ErrEmptyCSV)
+	}
+
+	if len(tasks) > 0 {
+		if err = s.repo.CreateMany(columnID, userID, tasks); err != nil {
+			return nil, fmt.Errorf("taskService.ImportTasks: %w", err)
+		}
+	}
+	result.Created = len(tasks)
+
+	return result, nil
+}
+
+// mapColumns finds the index of every task field in the header, -1 for
+// optional fields without a column. Header names match case-insensitively.
+func mapColumns(header []string, query taskModel.ImportQuery) (map[string]int, error) {
+	mapping := map[string]string{
+		"name":        query.Name,
+		"description": query.Description,
+		"deadline":    query.Deadline,
+		"done":        query.Done,
+	}
+
+	fields := map[string]int{}
+	for field, column := range mapping {
+		explicit := column != ""
+		if !explicit {
+			column = field
+		}
+
+		fields[field] = -1
+		for i, name := range header {
+			if strings.EqualFold(strings.TrimSpace(name), column) {
+				fields[field] = i
+				break
+			}
+		}
+
+		if fields[field] < 0 && (explicit || field == "name") {
+			return nil, fmt.Errorf("%w: %s", ErrMissingCSVColumn, column)
+		}
+	}
+	return fields, nil
+}
+
+func parseRow(record []string, fields map[string]int) (*taskModel.Task, *taskModel.ImportRowError) {
+	value := func(field string) string {
+		i := fields[field]
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	task := &taskModel.Task{
+		Name:        value("name"),
+		Description: value("description"),
+	}
+	if task.Name == "" {
+		return nil, &taskModel.ImportRowError{Field: "name", Message: "name is required"}
+	}
+
+	done, ok := doneValues[strings.ToLower(value("done"))]
+	if !ok {
+		return nil, &taskModel.ImportRowError{Field: "done", Message: "expected true/false, yes/no or 1/0"}
+	}
+	task.Done = done
+
+	if raw := value("deadline"); raw != "" {
+		deadline, ok := parseDeadline(raw)
+		if !ok {
+			return nil, &taskModel.ImportRowError{Field: "deadline", Message: "expected a date like 2006-01-02 or an RFC 3339 timestamp"}
+		}
+		task.Deadline = &deadline
+	}
+
+	return task, nil
+}
+
+// csvError marks malformed input as ErrInvalidCSV and keeps errors of the
+// underlying reader, such as an oversized body, as they are.
+func csvError(err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return fmt.Errorf("%w: %v", ErrInvalidCSV, parseErr)
+	}
+	return err
+}
+
+// validDelimiter mirrors the check of csv.Reader, which otherwise fails
+// every read with an error of its own.
+func validDelimiter(r rune) bool {
+	return r != 0 && r != '"' && r != '\r' && r != '\n' && r != utf8.RuneError
+}
+
+func parseDeadline(raw string) (time.Time, bool) {
+	for _, layout := range deadlineLayouts {
+		if deadline, err := time.Parse(layout, raw); err == nil {
+			return deadline, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,75 @@
+package taskService
+
+import (
+	"fmt"
+	"kanban/internal/notify"
+	taskModel "kanban/internal/task/model"
+	"log"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// mentionPattern matches @username at the start of the text or after a
+// character that cannot be part of an email address.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_.@-])@([\p{L}\p{N}_.-]+)`)
+
+// mentions returns the lowercased usernames mentioned in the texts, a
+// trailing dot is taken for the end of the sentence.
+func mentions(texts ...string) []string {
+	var usernames []string
+	for _, text := range texts {
+		for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+			username := strings.ToLower(strings.TrimRight(match[1], "."))
+			if username != "" && !slices.Contains(usernames, username) {
+				usernames = append(usernames, username)
+			}
+		}
+	}
+	return usernames
+}
+
+// notifyMentions lets the users mentioned in the task's name or description
+// know about it. Users who were already mentioned before the change are
+// not notified again. The task is saved by then, so failures are only
+// logged.
+func (s *Service) notifyMentions(task *taskModel.Task, authorID string, before []string) {
+	var usernames []string
+	for _, username := range mentions(task.Name, task.Description) {
+		if !slices.Contains(before, username) {
+			usernames = append(usernames, username)
+		}
+	}
+	if len(usernames) == 0 {
+		return
+	}
+
+	mentioned, err := s.repo.GetMentioned(task.ID, authorID, usernames)
+	if err != nil {
+		log.Printf("Failed to find users mentioned in task %s: %v", task.ID, err)
+		return
+	}
+
+	for _, mention := range mentioned {
+		err = s.notifier.Notify(buildMention(task, mention))
+		if err != nil {
+			log.Printf("Failed to notify user %s of a mention in task %s: %v", mention.UserID, task.ID, err)
+		}
+	}
+}
+
+func buildMention(task *taskModel.Task, mention taskModel.Mention) notify.Notification {
+	body := fmt.Sprintf(
+		"Hi %s,\n\n%s mentioned you in task %q on board %q.",
+		mention.Username, mention.Author, task.Name, mention.BoardName,
+	)
+	return notify.Notification{
+		UserID:  mention.UserID,
+		Email:   mention.Email,
+		Kind:    notify.KindMention,
+		Title:   fmt.Sprintf("%s mentioned you in %q", mention.Author, task.Name),
+		Body:    body,
+		BoardID: &mention.BoardID,
+		TaskID:  &task.ID,
+	}
+}
@@ -0,0 +1,156 @@
+package taskService
+
+import (
+	"kanban/internal/notify"
+	taskModel "kanban/internal/task/model"
+	"slices"
+	"testing"
+)
+
+func TestMentions(t *testing.T) {
+	tests := []struct {
+		name  string
+		texts []string
+		want  []string
+	}{
+		{name: "none", texts: []string{"Release notes"}},
+		{name: "start of the text", texts: []string{"@alice please review"}, want: []string{"alice"}},
+		{name: "case is ignored", texts: []string{"ask @Alice and @ALICE"}, want: []string{"alice"}},
+		{name: "end of a sentence", texts: []string{"Ask @bob.smith."}, want: []string{"bob.smith"}},
+		{name: "punctuation around", texts: []string{"(@carol), @dave!"}, want: []string{"carol", "dave"}},
+		{name: "email address", texts: []string{"mail alice@example.org"}},
+		{name: "bare at sign", texts: []string{"meet @ 10"}},
+		{name: "non-latin username", texts: []string{"спросить @мария"}, want: []string{"мария"}},
+		{name: "name and description", texts: []string{"@alice", "cc @bob and @alice"}, want: []string{"alice", "bob"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mentions(tt.texts...)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// stubRepo serves a single task and the users who can see its board, the
+// rest of Repository is left nil.
+type stubRepo struct {
+	Repository
+	task      taskModel.Task
+	users     []string
+	usernames []string
+}
+
+func (r *stubRepo) Get(taskID string) (*taskModel.Task, error) {
+	task := r.task
+	return &task, nil
+}
+
+func (r *stubRepo) UpdateContent(taskID, userID string, req taskModel.UpdateRequest) (*taskModel.Task, error) {
+	if req.Name != nil {
+		r.task.Name = *req.Name
+	}
+	if req.Description != nil {
+		r.task.Description = *req.Description
+	}
+	task := r.task
+	return &task, nil
+}
+
+func (r *stubRepo) GetMentioned(taskID, authorID string, usernames []string) ([]taskModel.Mention, error) {
+	r.usernames = usernames
+	var mentioned []taskModel.Mention
+	for _, username := range usernames {
+		if username != authorID && slices.Contains(r.users, username) {
+			mentioned = append(mentioned, taskModel.Mention{UserID: username, Username: username, BoardID: "board", Author: authorID})
+		}
+	}
+	return mentioned, nil
+}
+
+type stubNotifier struct {
+	sent []notify.Notification
+}
+
+func (n *stubNotifier) Notify(notification notify.Notification) error {
+	n.sent = append(n.sent, notification)
+	return nil
+}
+
+func TestUpdateTaskNotifiesMentions(t *testing.T) {
+	text := func(s string) *string { return &s }
+
+	tests := []struct {
+		name        string
+		description string
+		req         taskModel.UpdateRequest
+		wantAsked   []string
+		wantNotify  []string
+	}{
+		{
+			name:       "new mention",
+			req:        taskModel.UpdateRequest{Description: text("@bob please review")},
+			wantAsked:  []string{"bob"},
+			wantNotify: []string{"bob"},
+		},
+		{
+			name:        "mentioned before",
+			description: "@bob please review",
+			req:         taskModel.UpdateRequest{Description: text("@bob please review today")},
+		},
+		{
+			name:        "only the added mention",
+			description: "@bob please review",
+			req:         taskModel.UpdateRequest{Description: text("@bob and @carol please review")},
+			wantAsked:   []string{"carol"},
+			wantNotify:  []string{"carol"},
+		},
+		{
+			name:      "no access to the board",
+			req:       taskModel.UpdateRequest{Name: text("Release with @mallory")},
+			wantAsked: []string{"mallory"},
+		},
+		{
+			name:      "author mentions themselves",
+			req:       taskModel.UpdateRequest{Description: text("note to @alice")},
+			wantAsked: []string{"alice"},
+		},
+		{
+			name:        "text unchanged",
+			description: "@bob please review",
+			req:         taskModel.UpdateRequest{Done: new(bool)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubRepo{
+				task:  taskModel.Task{ID: "task", Name: "Release", Description: tt.description},
+				users: []string{"alice", "bob", "carol"},
+			}
+			notifier := &stubNotifier{}
+			s := NewService(repo, notifier)
+
+			task, err := s.UpdateTask("task", "alice", tt.req)
+			if err != nil {
+				t.Fatalf("update: %v", err)
+			}
+
+			if !slices.Equal(repo.usernames, tt.wantAsked) {
+				t.Errorf("looked up: got %v, want %v", repo.usernames, tt.wantAsked)
+			}
+			var notified []string
+			for _, n := range notifier.sent {
+				if n.Kind != notify.KindMention || *n.TaskID != task.ID || *n.BoardID != "board" {
+					t.Errorf("notification: got %+v", n)
+				}
+				notified = append(notified, n.UserID)
+			}
+			if !slices.Equal(notified, tt.wantNotify) {
+				t.Errorf("notified: got %v, want %v", notified, tt.wantNotify)
+			}
+		})
+	}
+}
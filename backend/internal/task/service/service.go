@@ -4,12 +4,15 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"kanban/internal/notify"
 	taskModel "kanban/internal/task/model"
+	"kanban/internal/utils"
 	"reflect"
 )
 
 var ErrTaskNotFound error = errors.New("task not found")
 var ErrBadUpdateRequest error = errors.New("invalid combination of fields")
+var ErrDependencyNotFound error = errors.New("dependency not found")
 
 type updateCase string
 const (
@@ -20,37 +23,47 @@ const (
 )
 
 type Repository interface {
-	Create(task taskModel.Task) error
+	Create(task taskModel.Task, userID string) (*taskModel.Task, error)
+	CreateMany(columnID, userID string, tasks []taskModel.Task) error
 	GetAll(columnID string) ([]taskModel.Task, error)
 	Get(taskID string) (*taskModel.Task, error)
-	UpdateContent(taskID string, req taskModel.UpdateRequest) error
-	UpdateColumn(taskID string, req taskModel.UpdateRequest) error
-	UpdatePosition(taskID string, req taskModel.UpdateRequest) error
-	Delete(taskID string) error
-	GetUserByColumn(columnID string) (*string, error)
-	GetUserByTask(taskID string) (*string, error)
+	UpdateContent(taskID, userID string, req taskModel.UpdateRequest) (*taskModel.Task, error)
+	UpdateColumn(taskID, userID string, req taskModel.UpdateRequest) (*taskModel.Task, error)
+	UpdatePosition(taskID, userID string, req taskModel.UpdateRequest) (*taskModel.Task, error)
+	Delete(taskID, userID string) error
+	AddDependency(blockerID, blockedID string) (*taskModel.Task, error)
+	DeleteDependency(blockerID, blockedID string) error
+	Bulk(userID string, req taskModel.BulkRequest) ([]error, error)
+	GetAccessible(taskIDs []string, userID string) ([]string, error)
+	GetMentioned(taskID, authorID string, usernames []string) ([]taskModel.Mention, error)
+	HasColumnAccess(columnID, userID string) (bool, error)
+	HasTaskAccess(taskID, userID string) (bool, error)
 }
 
 type Service struct {
-	repo Repository
+	repo     Repository
+	notifier notify.Notifier
 }
 
-func NewService(repo Repository) *Service {
-	return &Service{repo: repo}
+func NewService(repo Repository, notifier notify.Notifier) *Service {
+	return &Service{repo: repo, notifier: notifier}
 }
 
-func (s *Service) CreateTask(columnID string, req taskModel.CreateRequest) error {
+func (s *Service) CreateTask(columnID, userID string, req taskModel.CreateRequest) (*taskModel.Task, error) {
 	task := taskModel.Task{
+		ID: utils.NewUUID(),
 		ColumnID: columnID,
 		Name: req.Name,
 	}
 
-	err := s.repo.Create(task)
+	created, err := s.repo.Create(task, userID)
 	if err != nil {
-		return fmt.Errorf("taskService.CreateTask: %w", err)
+		return nil, fmt.Errorf("taskService.CreateTask: %w", err)
 	}
 
-	return nil
+	s.notifyMentions(created, userID, nil)
+
+	return created, nil
 }
 
 func (s *Service) GetAllTasks(columnID string) ([]taskModel.Task, error) {
@@ -74,33 +87,46 @@ func (s *Service) GetTask(taskID string) (*taskModel.Task, error) {
 	return task, nil
 }
 
-func (s *Service) UpdateTask(taskID string, req taskModel.UpdateRequest) error {
+func (s *Service) UpdateTask(taskID, userID string, req taskModel.UpdateRequest) (*taskModel.Task, error) {
 	updCase := validateUpdateTaskRequest(req)
 
+	var task *taskModel.Task
 	var err error
+	var mentioned []string
 	switch updCase {
 	case caseContent:
-		err = s.repo.UpdateContent(taskID, req)
+		if req.Name != nil || req.Description != nil {
+			task, err = s.repo.Get(taskID)
+			if err != nil {
+				break
+			}
+			mentioned = mentions(task.Name, task.Description)
+		}
+		task, err = s.repo.UpdateContent(taskID, userID, req)
 	case caseColumn:
-		err = s.repo.UpdateColumn(taskID, req)
+		task, err = s.repo.UpdateColumn(taskID, userID, req)
 	case casePosition:
-		err = s.repo.UpdatePosition(taskID, req)
+		task, err = s.repo.UpdatePosition(taskID, userID, req)
 	default:
-		return fmt.Errorf("taskService.UpdateTask: %w", ErrBadUpdateRequest)
+		return nil, fmt.Errorf("taskService.UpdateTask: %w", ErrBadUpdateRequest)
 	}
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return fmt.Errorf("taskService.UpdateTask: %w", ErrTaskNotFound)
+			return nil, fmt.Errorf("taskService.UpdateTask: %w", ErrTaskNotFound)
 		}
-		return fmt.Errorf("taskService.UpdateTask: %w", err)
+		return nil, fmt.Errorf("taskService.UpdateTask: %w", err)
 	}
 
-	return nil
+	if req.Name != nil || req.Description != nil {
+		s.notifyMentions(task, userID, mentioned)
+	}
+
+	return task, nil
 }
 
-func (s *Service) DeleteTask(taskID string) error {
-	err := s.repo.Delete(taskID)
+func (s *Service) DeleteTask(taskID, userID string) error {
+	err := s.repo.Delete(taskID, userID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return fmt.Errorf("taskService.DeleteTask: %w", ErrTaskNotFound)
@@ -111,29 +137,49 @@ func (s *Service) DeleteTask(taskID string) error {
 	return nil
 }
 
+func (s *Service) AddDependency(taskID, blockerID string) (*taskModel.Task, error) {
+	task, err := s.repo.AddDependency(blockerID, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("taskService.AddDependency: %w", err)
+	}
 
-func (s *Service) GetUserByColumn(columnID string) (*string, error) {
-	userID, err := s.repo.GetUserByColumn(columnID)
+	return task, nil
+}
+
+func (s *Service) DeleteDependency(taskID, blockerID string) error {
+	err := s.repo.DeleteDependency(blockerID, taskID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("taskService.DeleteDependency: %w", ErrDependencyNotFound)
+		}
+		return fmt.Errorf("taskService.DeleteDependency: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) HasColumnAccess(columnID, userID string) (bool, error) {
+	hasAccess, err := s.repo.HasColumnAccess(columnID, userID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("taskService.GetUserByColumn: %w", ErrTaskNotFound)
+			return false, fmt.Errorf("taskService.HasColumnAccess: %w", ErrTaskNotFound)
 		}
-		return nil, fmt.Errorf("taskService.GetUserByColumn: %w", err)
+		return false, fmt.Errorf("taskService.HasColumnAccess: %w", err)
 	}
 
-	return userID, nil
+	return hasAccess, nil
 }
 
-func (s *Service) GetUserByTask(taskID string) (*string, error) {
-	userID, err := s.repo.GetUserByTask(taskID)
-		if err != nil {
+func (s *Service) HasTaskAccess(taskID, userID string) (bool, error) {
+	hasAccess, err := s.repo.HasTaskAccess(taskID, userID)
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("taskService.GetUserByTask: %w", ErrTaskNotFound)
+			return false, fmt.Errorf("taskService.HasTaskAccess: %w", ErrTaskNotFound)
 		}
-		return nil, fmt.Errorf("taskService.GetUserByTask: %w", err)
+		return false, fmt.Errorf("taskService.HasTaskAccess: %w", err)
 	}
 
-	return userID, nil
+	return hasAccess, nil
 }
 
 func validateUpdateTaskRequest(req taskModel.UpdateRequest) updateCase {
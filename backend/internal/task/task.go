@@ -2,6 +2,7 @@ package task
 
 import (
 	"database/sql"
+	"kanban/internal/notify"
 	taskHandler "kanban/internal/task/handler"
 	taskProxy "kanban/internal/task/proxy"
 	taskRepo "kanban/internal/task/repo"
@@ -12,13 +13,17 @@ import (
 
 func Init(db *sql.DB, grp *gin.RouterGroup) {
 	repo := taskRepo.NewRepository(db)
-	service := taskService.NewService(repo)
+	service := taskService.NewService(repo, notify.New(db))
 	proxy := taskProxy.NewProxy(service)
 	handler := taskHandler.NewHandler(proxy)
 
 	grp.POST("/columns/:id/tasks", handler.CreateTaskHandler())
 	grp.GET("/columns/:id/tasks", handler.GetAllTasksHandler())
+	grp.POST("/columns/:id/tasks/import", handler.ImportTasksHandler())
+	grp.POST("/tasks/bulk", handler.BulkUpdateTasksHandler())
 	grp.GET("/tasks/:id", handler.GetTaskHandler())
 	grp.PATCH("/tasks/:id", handler.UpdateTaskHandler())
 	grp.DELETE("/tasks/:id", handler.DeleteTaskHandler())
+	grp.POST("/tasks/:id/blockers", handler.AddDependencyHandler())
+	grp.DELETE("/tasks/:id/blockers/:blockerID", handler.DeleteDependencyHandler())
 }
\ No newline at end of file
@@ -0,0 +1,75 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA1 seed of RFC 6238 appendix B, "12345678901234567890".
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// The RFC lists 8 digit codes, ours are their last 6 digits.
+func TestCode(t *testing.T) {
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+		{unix: 20000000000, want: "353130"},
+	}
+
+	for _, tt := range tests {
+		t.Run(time.Unix(tt.unix, 0).UTC().Format(time.RFC3339), func(t *testing.T) {
+			got, err := Code(rfcSecret, Step(time.Unix(tt.unix, 0)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("code: got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	// 1111111111 falls into step 37037037, 1111111109 into the one before.
+	now := time.Unix(1111111111, 0)
+	step := Step(now)
+
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		now      time.Time
+		wantStep int64
+		wantOK   bool
+	}{
+		{name: "current step", secret: rfcSecret, code: "050471", now: now, wantStep: step, wantOK: true},
+		{name: "previous step within skew", secret: rfcSecret, code: "081804", now: now, wantStep: step - 1, wantOK: true},
+		{name: "code of the next step within skew", secret: rfcSecret, code: "050471", now: now.Add(-Period), wantStep: step, wantOK: true},
+		{name: "two steps late", secret: rfcSecret, code: "081804", now: now.Add(Period), wantOK: false},
+		{name: "two steps early", secret: rfcSecret, code: "050471", now: now.Add(-2 * Period), wantOK: false},
+		{name: "last second of the step", secret: rfcSecret, code: "287082", now: time.Unix(59, 0), wantStep: 1, wantOK: true},
+		{name: "lower case secret", secret: "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", code: "050471", now: now, wantStep: step, wantOK: true},
+		{name: "wrong code", secret: rfcSecret, code: "123456", now: now, wantOK: false},
+		{name: "8 digit code", secret: rfcSecret, code: "14050471", now: now, wantOK: false},
+		{name: "short code", secret: rfcSecret, code: "50471", now: now, wantOK: false},
+		{name: "broken secret", secret: "not base32!", code: "050471", now: now, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStep, ok := Validate(tt.secret, tt.code, tt.now)
+			if ok != tt.wantOK {
+				t.Fatalf("valid: got %v, want %v", ok, tt.wantOK)
+			}
+			if ok && gotStep != tt.wantStep {
+				t.Errorf("step: got %d, want %d", gotStep, tt.wantStep)
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package utils
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,4 +16,20 @@ func NewUUID() string {
 
 func GenerateTimestamp() time.Time {
 	return time.Now()
+}
+
+// GenerateToken returns a URL-safe random token carrying size bytes of entropy.
+func GenerateToken(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// HashToken returns the hex-encoded SHA-256 of a token, for storing
+// high-entropy secrets that only need to be compared, never recovered.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
\ No newline at end of file
@@ -0,0 +1,335 @@
+package workspaceHandler
+
+import (
+	"errors"
+	authctx "kanban/internal/auth/context"
+	boardModel "kanban/internal/board/model"
+	"kanban/internal/config"
+	workspaceModel "kanban/internal/workspace/model"
+	workspaceProxy "kanban/internal/workspace/proxy"
+	workspaceService "kanban/internal/workspace/service"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Proxy interface {
+	CreateWorkspace(userID string, req workspaceModel.Request) (*workspaceModel.Workspace, error)
+	GetWorkspaces(userID string) ([]workspaceModel.Workspace, error)
+	GetWorkspace(workspaceID, userID string) (*workspaceModel.Workspace, error)
+	UpdateWorkspace(workspaceID, userID string, req workspaceModel.Request) (*workspaceModel.Workspace, error)
+	DeleteWorkspace(workspaceID, userID string) error
+	GetBoards(workspaceID, userID string) ([]boardModel.Board, error)
+	GetMembers(workspaceID, userID string) ([]workspaceModel.Member, error)
+	AddMember(workspaceID, userID string, req workspaceModel.AddMemberRequest) (*workspaceModel.Member, error)
+	UpdateMember(workspaceID, memberID, userID string, req workspaceModel.UpdateMemberRequest) error
+	RemoveMember(workspaceID, memberID, userID string) error
+}
+
+type Handler struct {
+	proxy Proxy
+}
+
+func NewHandler(proxy Proxy) *Handler {
+	return &Handler{proxy: proxy}
+}
+
+func (h *Handler) CreateWorkspaceHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req workspaceModel.Request
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid request body",
+			})
+			return
+		}
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		workspace, err := h.proxy.CreateWorkspace(userID, req)
+		if err != nil {
+			log.Printf("Failed to create workspace: %v", err)
+			h.handleError(ctx, err, "Failed to create workspace")
+			return
+		}
+
+		ctx.Header("Location", config.Get().APIPrefix+"/workspaces/"+workspace.ID)
+		ctx.JSON(http.StatusCreated, workspace)
+	}
+}
+
+func (h *Handler) GetWorkspacesHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		workspaces, err := h.proxy.GetWorkspaces(userID)
+		if err != nil {
+			log.Printf("Failed to get workspaces: %v", err)
+			h.handleError(ctx, err, "Failed to get workspaces")
+			return
+		}
+
+		ctx.JSON(http.StatusOK, workspaces)
+	}
+}
+
+func (h *Handler) GetWorkspaceHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		workspaceID := ctx.Param("id")
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		workspace, err := h.proxy.GetWorkspace(workspaceID, userID)
+		if err != nil {
+			log.Printf("Failed to get workspace: %v", err)
+			h.handleError(ctx, err, "Failed to get workspace")
+			return
+		}
+
+		ctx.JSON(http.StatusOK, workspace)
+	}
+}
+
+func (h *Handler) UpdateWorkspaceHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req workspaceModel.Request
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid request body",
+			})
+			return
+		}
+
+		workspaceID := ctx.Param("id")
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		workspace, err := h.proxy.UpdateWorkspace(workspaceID, userID, req)
+		if err != nil {
+			log.Printf("Failed to update workspace: %v", err)
+			h.handleError(ctx, err, "Failed to update workspace")
+			return
+		}
+
+		ctx.JSON(http.StatusOK, workspace)
+	}
+}
+
+func (h *Handler) DeleteWorkspaceHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		workspaceID := ctx.Param("id")
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		if err := h.proxy.DeleteWorkspace(workspaceID, userID); err != nil {
+			log.Printf("Failed to delete workspace: %v", err)
+			h.handleError(ctx, err, "Failed to delete workspace")
+			return
+		}
+
+		ctx.Status(http.StatusOK)
+	}
+}
+
+func (h *Handler) GetBoardsHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		workspaceID := ctx.Param("id")
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		boards, err := h.proxy.GetBoards(workspaceID, userID)
+		if err != nil {
+			log.Printf("Failed to get workspace boards: %v", err)
+			h.handleError(ctx, err, "Failed to get boards")
+			return
+		}
+
+		ctx.JSON(http.StatusOK, boards)
+	}
+}
+
+func (h *Handler) GetMembersHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		workspaceID := ctx.Param("id")
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		members, err := h.proxy.GetMembers(workspaceID, userID)
+		if err != nil {
+			log.Printf("Failed to get workspace members: %v", err)
+			h.handleError(ctx, err, "Failed to get members")
+			return
+		}
+
+		ctx.JSON(http.StatusOK, members)
+	}
+}
+
+func (h *Handler) AddMemberHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req workspaceModel.AddMemberRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid request body",
+			})
+			return
+		}
+
+		workspaceID := ctx.Param("id")
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		member, err := h.proxy.AddMember(workspaceID, userID, req)
+		if err != nil {
+			log.Printf("Failed to add workspace member: %v", err)
+			h.handleError(ctx, err, "Failed to add member")
+			return
+		}
+
+		ctx.JSON(http.StatusCreated, member)
+	}
+}
+
+func (h *Handler) UpdateMemberHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req workspaceModel.UpdateMemberRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"detail": "Invalid request body",
+			})
+			return
+		}
+
+		workspaceID := ctx.Param("id")
+		memberID := ctx.Param("userID")
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		if err := h.proxy.UpdateMember(workspaceID, memberID, userID, req); err != nil {
+			log.Printf("Failed to update workspace member: %v", err)
+			h.handleError(ctx, err, "Failed to update member")
+			return
+		}
+
+		ctx.Status(http.StatusOK)
+	}
+}
+
+func (h *Handler) RemoveMemberHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		workspaceID := ctx.Param("id")
+		memberID := ctx.Param("userID")
+
+		userID, ok := authctx.GetUserID(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"detail": "No token",
+			})
+			return
+		}
+
+		if err := h.proxy.RemoveMember(workspaceID, memberID, userID); err != nil {
+			log.Printf("Failed to remove workspace member: %v", err)
+			h.handleError(ctx, err, "Failed to remove member")
+			return
+		}
+
+		ctx.Status(http.StatusOK)
+	}
+}
+
+func (h *Handler) handleError(ctx *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, workspaceProxy.ErrForbidden):
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"detail": "Access denied",
+		})
+	case errors.Is(err, workspaceService.ErrWorkspaceNotFound):
+		ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+			"detail": "Workspace not found",
+		})
+	case errors.Is(err, workspaceService.ErrUserNotFound):
+		ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+			"detail": "User not found",
+		})
+	case errors.Is(err, workspaceService.ErrMemberNotFound):
+		ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+			"detail": "Member not found",
+		})
+	case errors.Is(err, workspaceService.ErrUserNotVerified):
+		ctx.AbortWithStatusJSON(http.StatusConflict, gin.H{
+			"detail": "User has not verified their email",
+		})
+	case errors.Is(err, workspaceService.ErrAlreadyMember):
+		ctx.AbortWithStatusJSON(http.StatusConflict, gin.H{
+			"detail": "User is already a member",
+		})
+	case errors.Is(err, workspaceService.ErrLastOwner):
+		ctx.AbortWithStatusJSON(http.StatusConflict, gin.H{
+			"detail": "Workspace must keep an owner",
+		})
+	case errors.Is(err, workspaceService.ErrPersonalWorkspace):
+		ctx.AbortWithStatusJSON(http.StatusConflict, gin.H{
+			"detail": "Personal workspace cannot be shared or deleted",
+		})
+	default:
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"detail": message,
+		})
+	}
+}
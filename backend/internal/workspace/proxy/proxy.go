@@ -0,0 +1,150 @@
+package workspaceProxy
+
+import (
+	"errors"
+	"fmt"
+	boardModel "kanban/internal/board/model"
+	workspaceModel "kanban/internal/workspace/model"
+)
+
+var ErrForbidden = errors.New("access denied")
+
+type Service interface {
+	CreateWorkspace(userID string, req workspaceModel.Request) (*workspaceModel.Workspace, error)
+	GetWorkspaces(userID string) ([]workspaceModel.Workspace, error)
+	GetWorkspace(workspaceID, userID string) (*workspaceModel.Workspace, error)
+	UpdateWorkspace(workspaceID, userID string, req workspaceModel.Request) (*workspaceModel.Workspace, error)
+	DeleteWorkspace(workspaceID string) error
+	GetBoards(workspaceID string) ([]boardModel.Board, error)
+	GetMembers(workspaceID string) ([]workspaceModel.Member, error)
+	AddMember(workspaceID string, req workspaceModel.AddMemberRequest) (*workspaceModel.Member, error)
+	UpdateMember(workspaceID, memberID string, req workspaceModel.UpdateMemberRequest) error
+	RemoveMember(workspaceID, memberID string) error
+	GetRole(workspaceID, userID string) (*string, error)
+}
+
+type Proxy struct {
+	service Service
+}
+
+func NewProxy(service Service) *Proxy {
+	return &Proxy{service: service}
+}
+
+func (p *Proxy) CreateWorkspace(userID string, req workspaceModel.Request) (*workspaceModel.Workspace, error) {
+	return p.service.CreateWorkspace(userID, req)
+}
+
+func (p *Proxy) GetWorkspaces(userID string) ([]workspaceModel.Workspace, error) {
+	return p.service.GetWorkspaces(userID)
+}
+
+func (p *Proxy) GetWorkspace(workspaceID, userID string) (*workspaceModel.Workspace, error) {
+	if err := p.checkRole(workspaceID, userID, workspaceModel.RoleMember); err != nil {
+		return nil, fmt.Errorf("workspaceProxy.GetWorkspace: %w", err)
+	}
+
+	return p.service.GetWorkspace(workspaceID, userID)
+}
+
+func (p *Proxy) UpdateWorkspace(workspaceID, userID string, req workspaceModel.Request) (*workspaceModel.Workspace, error) {
+	if err := p.checkRole(workspaceID, userID, workspaceModel.RoleAdmin); err != nil {
+		return nil, fmt.Errorf("workspaceProxy.UpdateWorkspace: %w", err)
+	}
+
+	return p.service.UpdateWorkspace(workspaceID, userID, req)
+}
+
+func (p *Proxy) DeleteWorkspace(workspaceID, userID string) error {
+	if err := p.checkRole(workspaceID, userID, workspaceModel.RoleOwner); err != nil {
+		return fmt.Errorf("workspaceProxy.DeleteWorkspace: %w", err)
+	}
+
+	return p.service.DeleteWorkspace(workspaceID)
+}
+
+func (p *Proxy) GetBoards(workspaceID, userID string) ([]boardModel.Board, error) {
+	if err := p.checkRole(workspaceID, userID, workspaceModel.RoleMember); err != nil {
+		return nil, fmt.Errorf("workspaceProxy.GetBoards: %w", err)
+	}
+
+	return p.service.GetBoards(workspaceID)
+}
+
+func (p *Proxy) GetMembers(workspaceID, userID string) ([]workspaceModel.Member, error) {
+	if err := p.checkRole(workspaceID, userID, workspaceModel.RoleMember); err != nil {
+		return nil, fmt.Errorf("workspaceProxy.GetMembers: %w", err)
+	}
+
+	return p.service.GetMembers(workspaceID)
+}
+
+// AddMember lets admins add members and admins, only owners add owners.
+func (p *Proxy) AddMember(workspaceID, userID string, req workspaceModel.AddMemberRequest) (*workspaceModel.Member, error) {
+	required := workspaceModel.RoleAdmin
+	if req.Role == workspaceModel.RoleOwner {
+		required = workspaceModel.RoleOwner
+	}
+	if err := p.checkRole(workspaceID, userID, required); err != nil {
+		return nil, fmt.Errorf("workspaceProxy.AddMember: %w", err)
+	}
+
+	return p.service.AddMember(workspaceID, req)
+}
+
+// UpdateMember lets admins change roles below owner, only owners grant or
+// take away ownership.
+func (p *Proxy) UpdateMember(workspaceID, memberID, userID string, req workspaceModel.UpdateMemberRequest) error {
+	required := workspaceModel.RoleAdmin
+	current, err := p.service.GetRole(workspaceID, memberID)
+	if err != nil {
+		return fmt.Errorf("workspaceProxy.UpdateMember: %w", err)
+	}
+	if req.Role == workspaceModel.RoleOwner || *current == workspaceModel.RoleOwner {
+		required = workspaceModel.RoleOwner
+	}
+	if err = p.checkRole(workspaceID, userID, required); err != nil {
+		return fmt.Errorf("workspaceProxy.UpdateMember: %w", err)
+	}
+
+	return p.service.UpdateMember(workspaceID, memberID, req)
+}
+
+// RemoveMember lets every member leave. Admins remove others, only owners
+// remove owners.
+func (p *Proxy) RemoveMember(workspaceID, memberID, userID string) error {
+	required := workspaceModel.RoleMember
+	if memberID != userID {
+		required = workspaceModel.RoleAdmin
+		current, err := p.service.GetRole(workspaceID, memberID)
+		if err != nil {
+			return fmt.Errorf("workspaceProxy.RemoveMember: %w", err)
+		}
+		if *current == workspaceModel.RoleOwner {
+			required = workspaceModel.RoleOwner
+		}
+	}
+	if err := p.checkRole(workspaceID, userID, required); err != nil {
+		return fmt.Errorf("workspaceProxy.RemoveMember: %w", err)
+	}
+
+	return p.service.RemoveMember(workspaceID, memberID)
+}
+
+// rank orders roles by privilege, non-members rank lowest.
+var rank = map[string]int{
+	workspaceModel.RoleMember: 1,
+	workspaceModel.RoleAdmin:  2,
+	workspaceModel.RoleOwner:  3,
+}
+
+func (p *Proxy) checkRole(workspaceID, userID, required string) error {
+	role, err := p.service.GetRole(workspaceID, userID)
+	if err != nil {
+		return fmt.Errorf("workspaceProxy.checkRole: %w", err)
+	}
+	if rank[*role] < rank[required] {
+		return ErrForbidden
+	}
+	return nil
+}
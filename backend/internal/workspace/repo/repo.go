@@ -0,0 +1,280 @@
+package workspaceRepo
+
+import (
+	"database/sql"
+	"fmt"
+	boardModel "kanban/internal/board/model"
+	"kanban/internal/postgres"
+	"kanban/internal/utils"
+	workspaceModel "kanban/internal/workspace/model"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Create stores the workspace with its creator as the owner.
+func (r *Repository) Create(workspace workspaceModel.Workspace, userID string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("workspaceRepo.Create: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(postgres.QueryCreateWorkspace, workspace.ID, workspace.CreatedAt, workspace.Name)
+	if err != nil {
+		return fmt.Errorf("workspaceRepo.Create: %w", err)
+	}
+
+	_, err = tx.Exec(postgres.QueryAddWorkspaceMember, workspace.ID, userID, workspaceModel.RoleOwner, workspace.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("workspaceRepo.Create: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("workspaceRepo.Create: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) GetAll(userID string) ([]workspaceModel.Workspace, error) {
+	rows, err := r.db.Query(postgres.QueryGetWorkspaces, userID)
+	if err != nil {
+		return nil, fmt.Errorf("workspaceRepo.GetAll: %w", err)
+	}
+	defer rows.Close()
+
+	workspaces := []workspaceModel.Workspace{}
+	for rows.Next() {
+		var workspace workspaceModel.Workspace
+		if err := rows.Scan(
+			&workspace.ID,
+			&workspace.CreatedAt,
+			&workspace.UpdatedAt,
+			&workspace.Name,
+			&workspace.Personal,
+			&workspace.Role,
+		); err != nil {
+			return nil, fmt.Errorf("workspaceRepo.GetAll: %w", err)
+		}
+		workspaces = append(workspaces, workspace)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("workspaceRepo.GetAll: %w", err)
+	}
+
+	return workspaces, nil
+}
+
+// Get returns the workspace with the role of the user in it, empty if the
+// user is not a member.
+func (r *Repository) Get(workspaceID, userID string) (*workspaceModel.Workspace, error) {
+	var workspace workspaceModel.Workspace
+	err := r.db.QueryRow(postgres.QueryGetWorkspace, workspaceID, userID).Scan(
+		&workspace.ID,
+		&workspace.CreatedAt,
+		&workspace.UpdatedAt,
+		&workspace.Name,
+		&workspace.Personal,
+		&workspace.Role,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("workspaceRepo.Get: %w", err)
+	}
+	return &workspace, nil
+}
+
+func (r *Repository) Update(workspaceID, userID string, req workspaceModel.Request) (*workspaceModel.Workspace, error) {
+	var workspace workspaceModel.Workspace
+	err := r.db.QueryRow(postgres.QueryUpdateWorkspace, utils.GenerateTimestamp(), req.Name, workspaceID, userID).Scan(
+		&workspace.ID,
+		&workspace.CreatedAt,
+		&workspace.UpdatedAt,
+		&workspace.Name,
+		&workspace.Personal,
+		&workspace.Role,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("workspaceRepo.Update: %w", err)
+	}
+	return &workspace, nil
+}
+
+func (r *Repository) Delete(workspaceID string) error {
+	res, err := r.db.Exec(postgres.QueryDeleteWorkspace, workspaceID)
+	if err != nil {
+		return fmt.Errorf("workspaceRepo.Delete: %w", err)
+	}
+	return checkAffected("workspaceRepo.Delete", res)
+}
+
+func (r *Repository) GetBoards(workspaceID string) ([]boardModel.Board, error) {
+	rows, err := r.db.Query(postgres.QueryGetWorkspaceBoards, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("workspaceRepo.GetBoards: %w", err)
+	}
+	defer rows.Close()
+
+	boards := []boardModel.Board{}
+	for rows.Next() {
+		var board boardModel.Board
+		if err := rows.Scan(
+			&board.ID,
+			&board.UserID,
+			&board.WorkspaceID,
+			&board.CreatedAt,
+			&board.UpdatedAt,
+			&board.Name,
+			&board.EnforceDependencies,
+		); err != nil {
+			return nil, fmt.Errorf("workspaceRepo.GetBoards: %w", err)
+		}
+		boards = append(boards, board)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("workspaceRepo.GetBoards: %w", err)
+	}
+
+	return boards, nil
+}
+
+func (r *Repository) GetMembers(workspaceID string) ([]workspaceModel.Member, error) {
+	rows, err := r.db.Query(postgres.QueryGetWorkspaceMembers, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("workspaceRepo.GetMembers: %w", err)
+	}
+	defer rows.Close()
+
+	members := []workspaceModel.Member{}
+	for rows.Next() {
+		var member workspaceModel.Member
+		if err := rows.Scan(
+			&member.UserID,
+			&member.Email,
+			&member.Username,
+			&member.Role,
+			&member.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("workspaceRepo.GetMembers: %w", err)
+		}
+		members = append(members, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("workspaceRepo.GetMembers: %w", err)
+	}
+
+	return members, nil
+}
+
+// GetCandidate finds the user to add by email and whether they verified it.
+func (r *Repository) GetCandidate(email string) (*string, bool, error) {
+	var userID string
+	var verified bool
+	err := r.db.QueryRow(postgres.QueryGetWorkspaceMemberCandidate, email).Scan(&userID, &verified)
+	if err != nil {
+		return nil, false, fmt.Errorf("workspaceRepo.GetCandidate: %w", err)
+	}
+	return &userID, verified, nil
+}
+
+func (r *Repository) AddMember(workspaceID, userID, role string) (*workspaceModel.Member, error) {
+	var member workspaceModel.Member
+	err := r.db.QueryRow(postgres.QueryAddWorkspaceMember, workspaceID, userID, role, utils.GenerateTimestamp()).Scan(
+		&member.UserID,
+		&member.Email,
+		&member.Username,
+		&member.Role,
+		&member.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("workspaceRepo.AddMember: %w", err)
+	}
+	return &member, nil
+}
+
+func (r *Repository) UpdateMember(workspaceID, userID, role string) error {
+	res, err := r.db.Exec(postgres.QueryUpdateWorkspaceMember, role, workspaceID, userID)
+	if err != nil {
+		return fmt.Errorf("workspaceRepo.UpdateMember: %w", err)
+	}
+	return checkAffected("workspaceRepo.UpdateMember", res)
+}
+
+func (r *Repository) DeleteMember(workspaceID, userID string) error {
+	res, err := r.db.Exec(postgres.QueryDeleteWorkspaceMember, workspaceID, userID)
+	if err != nil {
+		return fmt.Errorf("workspaceRepo.DeleteMember: %w", err)
+	}
+	return checkAffected("workspaceRepo.DeleteMember", res)
+}
+
+func (r *Repository) CountOwners(workspaceID string) (int, error) {
+	var count int
+	err := r.db.QueryRow(postgres.QueryCountWorkspaceOwners, workspaceID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("workspaceRepo.CountOwners: %w", err)
+	}
+	return count, nil
+}
+
+// GetRole returns the role of the user in the workspace, empty if the user
+// is not a member.
+func (r *Repository) GetRole(workspaceID, userID string) (*string, error) {
+	var role string
+	err := r.db.QueryRow(postgres.QueryGetWorkspaceRole, workspaceID, userID).Scan(&role)
+	if err != nil {
+		return nil, fmt.Errorf("workspaceRepo.GetRole: %w", err)
+	}
+	return &role, nil
+}
+
+// CanManageBoard reports whether the user may change the board itself and
+// who has access to it: owners and admins of its workspace and its creator
+// while they are still a member. Other packages share this lookup.
+func (r *Repository) CanManageBoard(boardID, userID string) (bool, error) {
+	var canManage bool
+	err := r.db.QueryRow(postgres.QueryCanManageBoard, boardID, userID, postgres.OwnBoardsOnly()).Scan(&canManage)
+	if err != nil {
+		return false, fmt.Errorf("workspaceRepo.CanManageBoard: %w", err)
+	}
+	return canManage, nil
+}
+
+// CanShareBoard reports whether the user may invite others to the board or
+// publish it: everyone who can manage it and users invited to it as admins.
+// Other packages share this lookup.
+func (r *Repository) CanShareBoard(boardID, userID string) (bool, error) {
+	var canShare bool
+	err := r.db.QueryRow(postgres.QueryCanShareBoard, boardID, userID, postgres.OwnBoardsOnly()).Scan(&canShare)
+	if err != nil {
+		return false, fmt.Errorf("workspaceRepo.CanShareBoard: %w", err)
+	}
+	return canShare, nil
+}
+
+func (r *Repository) IsPersonal(workspaceID string) (bool, error) {
+	var personal bool
+	err := r.db.QueryRow(postgres.QueryIsPersonalWorkspace, workspaceID).Scan(&personal)
+	if err != nil {
+		return false, fmt.Errorf("workspaceRepo.IsPersonal: %w", err)
+	}
+	return personal, nil
+}
+
+func checkAffected(op string, res sql.Result) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, sql.ErrNoRows)
+	}
+	return nil
+}
@@ -0,0 +1,246 @@
+package workspaceService
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	boardModel "kanban/internal/board/model"
+	"kanban/internal/config"
+	"kanban/internal/utils"
+	workspaceModel "kanban/internal/workspace/model"
+
+	"github.com/lib/pq"
+)
+
+const uniqueViolation pq.ErrorCode = "23505"
+
+var ErrWorkspaceNotFound = errors.New("workspace not found")
+var ErrUserNotFound = errors.New("user not found")
+var ErrUserNotVerified = errors.New("user has not verified their email")
+var ErrMemberNotFound = errors.New("member not found")
+var ErrAlreadyMember = errors.New("user is already a member")
+var ErrLastOwner = errors.New("workspace must keep an owner")
+var ErrPersonalWorkspace = errors.New("personal workspace cannot be shared or deleted")
+
+type Repository interface {
+	Create(workspace workspaceModel.Workspace, userID string) error
+	GetAll(userID string) ([]workspaceModel.Workspace, error)
+	Get(workspaceID, userID string) (*workspaceModel.Workspace, error)
+	Update(workspaceID, userID string, req workspaceModel.Request) (*workspaceModel.Workspace, error)
+	Delete(workspaceID string) error
+	IsPersonal(workspaceID string) (bool, error)
+	GetBoards(workspaceID string) ([]boardModel.Board, error)
+	GetMembers(workspaceID string) ([]workspaceModel.Member, error)
+	GetCandidate(email string) (*string, bool, error)
+	AddMember(workspaceID, userID, role string) (*workspaceModel.Member, error)
+	UpdateMember(workspaceID, userID, role string) error
+	DeleteMember(workspaceID, userID string) error
+	CountOwners(workspaceID string) (int, error)
+	GetRole(workspaceID, userID string) (*string, error)
+}
+
+type Service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+func (s *Service) CreateWorkspace(userID string, req workspaceModel.Request) (*workspaceModel.Workspace, error) {
+	now := utils.GenerateTimestamp()
+	workspace := workspaceModel.Workspace{
+		ID:        utils.NewUUID(),
+		CreatedAt: now,
+		UpdatedAt: now,
+		Name:      req.Name,
+		Role:      workspaceModel.RoleOwner,
+	}
+
+	if err := s.repo.Create(workspace, userID); err != nil {
+		return nil, fmt.Errorf("workspaceService.CreateWorkspace: %w", err)
+	}
+
+	return &workspace, nil
+}
+
+func (s *Service) GetWorkspaces(userID string) ([]workspaceModel.Workspace, error) {
+	workspaces, err := s.repo.GetAll(userID)
+	if err != nil {
+		return nil, fmt.Errorf("workspaceService.GetWorkspaces: %w", err)
+	}
+
+	return workspaces, nil
+}
+
+func (s *Service) GetWorkspace(workspaceID, userID string) (*workspaceModel.Workspace, error) {
+	workspace, err := s.repo.Get(workspaceID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("workspaceService.GetWorkspace: %w", ErrWorkspaceNotFound)
+		}
+		return nil, fmt.Errorf("workspaceService.GetWorkspace: %w", err)
+	}
+
+	return workspace, nil
+}
+
+func (s *Service) UpdateWorkspace(workspaceID, userID string, req workspaceModel.Request) (*workspaceModel.Workspace, error) {
+	workspace, err := s.repo.Update(workspaceID, userID, req)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("workspaceService.UpdateWorkspace: %w", ErrWorkspaceNotFound)
+		}
+		return nil, fmt.Errorf("workspaceService.UpdateWorkspace: %w", err)
+	}
+
+	return workspace, nil
+}
+
+// DeleteWorkspace deletes the workspace with all of its boards. Personal
+// workspaces go away only with their user.
+func (s *Service) DeleteWorkspace(workspaceID string) error {
+	if err := s.checkShared(workspaceID); err != nil {
+		return fmt.Errorf("workspaceService.DeleteWorkspace: %w", err)
+	}
+
+	if err := s.repo.Delete(workspaceID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("workspaceService.DeleteWorkspace: %w", ErrWorkspaceNotFound)
+		}
+		return fmt.Errorf("workspaceService.DeleteWorkspace: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetBoards(workspaceID string) ([]boardModel.Board, error) {
+	boards, err := s.repo.GetBoards(workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("workspaceService.GetBoards: %w", err)
+	}
+
+	return boards, nil
+}
+
+func (s *Service) GetMembers(workspaceID string) ([]workspaceModel.Member, error) {
+	members, err := s.repo.GetMembers(workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("workspaceService.GetMembers: %w", err)
+	}
+
+	return members, nil
+}
+
+// AddMember adds a registered user by email. While unverified users are
+// limited to their own boards, they cannot be added to shared workspaces.
+func (s *Service) AddMember(workspaceID string, req workspaceModel.AddMemberRequest) (*workspaceModel.Member, error) {
+	if err := s.checkShared(workspaceID); err != nil {
+		return nil, fmt.Errorf("workspaceService.AddMember: %w", err)
+	}
+
+	userID, verified, err := s.repo.GetCandidate(req.Email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("workspaceService.AddMember: %w", ErrUserNotFound)
+		}
+		return nil, fmt.Errorf("workspaceService.AddMember: %w", err)
+	}
+	if !verified && config.Get().UnverifiedAccess == config.UnverifiedAccessOwnBoards {
+		return nil, fmt.Errorf("workspaceService.AddMember: %w", ErrUserNotVerified)
+	}
+
+	member, err := s.repo.AddMember(workspaceID, *userID, req.Role)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return nil, fmt.Errorf("workspaceService.AddMember: %w", ErrAlreadyMember)
+		}
+		return nil, fmt.Errorf("workspaceService.AddMember: %w", err)
+	}
+
+	return member, nil
+}
+
+func (s *Service) UpdateMember(workspaceID, memberID string, req workspaceModel.UpdateMemberRequest) error {
+	if req.Role != workspaceModel.RoleOwner {
+		if err := s.checkNotLastOwner(workspaceID, memberID); err != nil {
+			return fmt.Errorf("workspaceService.UpdateMember: %w", err)
+		}
+	}
+
+	if err := s.repo.UpdateMember(workspaceID, memberID, req.Role); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("workspaceService.UpdateMember: %w", ErrMemberNotFound)
+		}
+		return fmt.Errorf("workspaceService.UpdateMember: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) RemoveMember(workspaceID, memberID string) error {
+	if err := s.checkNotLastOwner(workspaceID, memberID); err != nil {
+		return fmt.Errorf("workspaceService.RemoveMember: %w", err)
+	}
+
+	if err := s.repo.DeleteMember(workspaceID, memberID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("workspaceService.RemoveMember: %w", ErrMemberNotFound)
+		}
+		return fmt.Errorf("workspaceService.RemoveMember: %w", err)
+	}
+
+	return nil
+}
+
+// GetRole returns the role of the user in the workspace, empty if the user
+// is not a member.
+func (s *Service) GetRole(workspaceID, userID string) (*string, error) {
+	role, err := s.repo.GetRole(workspaceID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("workspaceService.GetRole: %w", ErrWorkspaceNotFound)
+		}
+		return nil, fmt.Errorf("workspaceService.GetRole: %w", err)
+	}
+
+	return role, nil
+}
+
+func (s *Service) checkShared(workspaceID string) error {
+	personal, err := s.repo.IsPersonal(workspaceID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrWorkspaceNotFound
+		}
+		return err
+	}
+	if personal {
+		return ErrPersonalWorkspace
+	}
+	return nil
+}
+
+// checkNotLastOwner fails when the member is the only owner left.
+func (s *Service) checkNotLastOwner(workspaceID, memberID string) error {
+	role, err := s.GetRole(workspaceID, memberID)
+	if err != nil {
+		return err
+	}
+	if *role == "" {
+		return ErrMemberNotFound
+	}
+	if *role != workspaceModel.RoleOwner {
+		return nil
+	}
+
+	owners, err := s.repo.CountOwners(workspaceID)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return ErrLastOwner
+	}
+	return nil
+}
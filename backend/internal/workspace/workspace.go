@@ -0,0 +1,29 @@
+package workspace
+
+import (
+	"database/sql"
+	workspaceHandler "kanban/internal/workspace/handler"
+	workspaceProxy "kanban/internal/workspace/proxy"
+	workspaceRepo "kanban/internal/workspace/repo"
+	workspaceService "kanban/internal/workspace/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Init(db *sql.DB, grp *gin.RouterGroup) {
+	repo := workspaceRepo.NewRepository(db)
+	service := workspaceService.NewService(repo)
+	proxy := workspaceProxy.NewProxy(service)
+	handler := workspaceHandler.NewHandler(proxy)
+
+	grp.POST("/workspaces", handler.CreateWorkspaceHandler())
+	grp.GET("/workspaces", handler.GetWorkspacesHandler())
+	grp.GET("/workspaces/:id", handler.GetWorkspaceHandler())
+	grp.PUT("/workspaces/:id", handler.UpdateWorkspaceHandler())
+	grp.DELETE("/workspaces/:id", handler.DeleteWorkspaceHandler())
+	grp.GET("/workspaces/:id/boards", handler.GetBoardsHandler())
+	grp.GET("/workspaces/:id/members", handler.GetMembersHandler())
+	grp.POST("/workspaces/:id/members", handler.AddMemberHandler())
+	grp.PATCH("/workspaces/:id/members/:userID", handler.UpdateMemberHandler())
+	grp.DELETE("/workspaces/:id/members/:userID", handler.RemoveMemberHandler())
+}